@@ -0,0 +1,9 @@
+data "morpheus_cluster_kubeconfig" "tf_example_cluster_kubeconfig" {
+  cluster_id = morpheus_vsphere_mks_cluster.tf_example_vsphere_mks_cluster.id
+}
+
+provider "kubernetes" {
+  host                   = data.morpheus_cluster_kubeconfig.tf_example_cluster_kubeconfig.api_endpoint
+  cluster_ca_certificate = data.morpheus_cluster_kubeconfig.tf_example_cluster_kubeconfig.cluster_ca_certificate
+  token                  = data.morpheus_cluster_kubeconfig.tf_example_cluster_kubeconfig.token
+}
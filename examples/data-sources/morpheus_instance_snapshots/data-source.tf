@@ -0,0 +1,3 @@
+data "morpheus_instance_snapshots" "tf_example_instance_snapshots" {
+  instance_id = 1
+}
@@ -0,0 +1,54 @@
+data "morpheus_blueprint" "web_app" {
+  name = "Web App"
+}
+
+data "morpheus_group" "morpheus_lab" {
+  name = "MORPHEUS"
+}
+
+data "morpheus_cloud" "morpheus_vsphere" {
+  name = "MORPHEUSVCENTER"
+}
+
+resource "morpheus_app" "tf_example_app" {
+  name         = "tfapp"
+  description  = "Terraform app example"
+  blueprint_id = data.morpheus_blueprint.web_app.id
+  group_id     = data.morpheus_group.morpheus_lab.id
+  cloud_id     = data.morpheus_cloud.morpheus_vsphere.id
+  environment  = "dev"
+
+  custom_options = {
+    "appVersion" = "1.4.2"
+  }
+
+  tier {
+    name           = "Web"
+    instance_count = 2
+    config = jsonencode({
+      plan = {
+        id = 110
+      }
+    })
+  }
+
+  tier {
+    name = "Database"
+
+    instance {
+      config = jsonencode({
+        volumes = [
+          {
+            rootVolume = true
+            name       = "root"
+            size       = 50
+          }
+        ]
+      })
+    }
+  }
+
+  tags = {
+    "owner" = "platform-team"
+  }
+}
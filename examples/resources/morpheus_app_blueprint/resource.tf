@@ -0,0 +1,52 @@
+resource "morpheus_app_blueprint" "tf_example_app_blueprint" {
+  name        = "tfappblueprint"
+  description = "tf example app blueprint"
+  category    = "web"
+
+  tier {
+    name         = "Web"
+    tier_index   = 0
+    boot_order   = 1
+    linked_tiers = ["Database"]
+
+    instance {
+      instance_type_code = "nginx"
+      name               = "web-$${sequence}"
+      layout_id          = 206
+      plan_id            = 110
+
+      cloud_config {
+        environment = "Production"
+        group       = "MORPHEUS"
+        cloud       = "MORPHEUSVCENTER"
+        config = jsonencode({
+          plan = {
+            id = 112
+          }
+        })
+      }
+    }
+  }
+
+  tier {
+    name       = "Database"
+    tier_index = 1
+    boot_order = 0
+
+    instance {
+      instance_type_code = "mysql"
+      name               = "db-$${sequence}"
+      layout_id          = 215
+      plan_id            = 112
+      config = jsonencode({
+        volumes = [
+          {
+            rootVolume = true
+            name       = "root"
+            size       = 50
+          }
+        ]
+      })
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import morpheus_bluecat_integration.tf_example_bluecat_integration 1
\ No newline at end of file
@@ -0,0 +1,9 @@
+resource "morpheus_bluecat_integration" "tf_example_bluecat_integration" {
+  name           = "tf-example-bluecat"
+  enabled        = true
+  url            = "https://bluecat.example.local"
+  username       = "morpheus"
+  password       = "password123"
+  network_filter = "10.0.0.0/8"
+  zone_filter    = "example.local"
+}
\ No newline at end of file
@@ -0,0 +1 @@
+terraform import morpheus_cluster_namespace.tf_example_cluster_namespace 1/2
\ No newline at end of file
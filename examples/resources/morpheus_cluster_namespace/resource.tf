@@ -0,0 +1,30 @@
+data "morpheus_group" "product_team" {
+  name = "Product Team"
+}
+
+data "morpheus_tenant" "product_tenant" {
+  name = "Product"
+}
+
+resource "morpheus_cluster_namespace" "tf_example_cluster_namespace" {
+  cluster_id       = morpheus_kubernetes_cluster.tf_example_kubernetes_cluster.id
+  name             = "product-team"
+  description      = "Terraform cluster namespace example"
+  active           = true
+  all_group_access = false
+
+  group_access {
+    group_id = data.morpheus_group.product_team.id
+    default  = true
+  }
+
+  tenant_ids = [data.morpheus_tenant.product_tenant.id]
+
+  quota {
+    requests_cpu    = "4"
+    requests_memory = "8Gi"
+    limits_cpu      = "8"
+    limits_memory   = "16Gi"
+    pods            = 50
+  }
+}
@@ -0,0 +1,16 @@
+resource "morpheus_file_share" "tf_example_nfs_file_share" {
+  name                  = "tfexample-nfs-backups"
+  type                  = "nfs"
+  host                  = "nfs01.example.local"
+  export_folder         = "/exports/morpheus/backups"
+  default_backup_target = true
+}
+
+resource "morpheus_file_share" "tf_example_cifs_file_share" {
+  name          = "tfexample-cifs-archives"
+  type          = "cifs"
+  host          = "fs01.example.local"
+  export_folder = "archives"
+  username      = "EXAMPLE\\svc-morpheus"
+  password      = "password123"
+}
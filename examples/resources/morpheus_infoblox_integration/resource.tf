@@ -0,0 +1,15 @@
+resource "morpheus_infoblox_integration" "tf_example_infoblox_integration" {
+  name               = "tf-example-infoblox"
+  enabled            = true
+  url                = "https://infoblox.example.local/wapi/v2.2.1"
+  credential_id      = 12
+  network_filter     = "10.0.0.0/8"
+  zone_filter        = "example.local"
+  tenant_match       = "Tenant"
+  extra_attributes   = jsonencode({ "Owner" = "morpheus" })
+  inventory_existing = true
+}
+
+output "infoblox_pools" {
+  value = morpheus_infoblox_integration.tf_example_infoblox_integration.pools
+}
\ No newline at end of file
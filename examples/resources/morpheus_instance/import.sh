@@ -0,0 +1 @@
+terraform import morpheus_instance.tf_example_instance 1
@@ -0,0 +1,66 @@
+data "morpheus_group" "morpheus_lab" {
+  name = "platform engineering"
+}
+
+data "morpheus_cloud" "azure" {
+  name = "Azure East"
+}
+
+data "morpheus_instance_type" "ubuntu" {
+  name = "Ubuntu"
+}
+
+data "morpheus_instance_layout" "ubuntu" {
+  name    = "Azure Ubuntu"
+  version = "22.04"
+}
+
+data "morpheus_network" "subnet" {
+  name = "default"
+}
+
+data "morpheus_plan" "azure" {
+  name           = "Standard_B2s"
+  provision_type = "azure"
+}
+
+resource "morpheus_instance" "tf_example_instance" {
+  name               = "azuredemo"
+  description        = "Terraform instance example"
+  cloud_id           = data.morpheus_cloud.azure.id
+  group_id           = data.morpheus_group.morpheus_lab.id
+  instance_type_id   = data.morpheus_instance_type.ubuntu.id
+  instance_layout_id = data.morpheus_instance_layout.ubuntu.id
+  plan_id            = data.morpheus_plan.azure.id
+  environment        = "dev"
+  labels             = ["demo", "terraform"]
+
+  config = {
+    resourceGroupType = "new"
+    availabilityZone  = "1"
+  }
+
+  layout_options = {
+    securityGroup = "default"
+  }
+
+  interfaces {
+    network_id = data.morpheus_network.subnet.id
+  }
+
+  volumes {
+    root = true
+    name = "root"
+    size = 30
+  }
+
+  tags = {
+    name = "ubuntutf"
+  }
+
+  evar {
+    name   = "application"
+    value  = "demo"
+    export = true
+  }
+}
@@ -0,0 +1 @@
+terraform import morpheus_instance_snapshot.tf_example_instance_snapshot 1/5
\ No newline at end of file
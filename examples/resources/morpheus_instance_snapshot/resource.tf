@@ -0,0 +1,6 @@
+resource "morpheus_instance_snapshot" "tf_example_instance_snapshot" {
+  instance_id    = 1
+  name           = "pre-upgrade"
+  description    = "Snapshot taken before the application upgrade"
+  revert_trigger = "CHG0012345"
+}
@@ -1,11 +1,19 @@
 resource "morpheus_ipv4_ip_pool" "tf_example_ipv4_pool" {
-  name = "Terraform Example IPv4 IP pool"
+  name            = "Terraform Example IPv4 IP pool"
+  gateway         = "192.168.1.1"
+  netmask         = "255.255.255.0"
+  dns_servers     = ["192.168.1.2", "192.168.1.3"]
+  dns_search_path = "example.local"
+  visibility      = "private"
+  tenant_ids      = [1]
   ip_range {
-    starting_address = "192.168.1.1"
-    ending_address   = "192.168.1.10"
+    starting_address = "192.168.1.10"
+    ending_address   = "192.168.1.100"
+    description      = "Application servers"
   }
   ip_range {
-    starting_address = "10.0.0.1"
-    ending_address   = "10.0.0.10"
+    starting_address = "192.168.1.150"
+    ending_address   = "192.168.1.200"
+    description      = "Database servers"
   }
 }
\ No newline at end of file
@@ -0,0 +1 @@
+terraform import morpheus_ipv4_ip_pool_address.tf_example_ipv4_ip_pool_address 1/2
\ No newline at end of file
@@ -0,0 +1,18 @@
+resource "morpheus_ipv4_ip_pool_address" "tf_example_ipv4_ip_pool_address" {
+  pool_id     = morpheus_ipv4_ip_pool.tf_example_ipv4_pool.id
+  ip_address  = "192.168.1.25"
+  hostname    = "tfexample-app01"
+  type        = "static"
+  description = "Terraform example static IP address"
+}
+
+resource "morpheus_vsphere_instance" "tf_example_vsphere_instance" {
+  name = "tfexample-app01"
+  # ...
+
+  interfaces {
+    network_id = data.morpheus_network.vmnetwork.id
+    ip_mode    = "static"
+    ip_address = morpheus_ipv4_ip_pool_address.tf_example_ipv4_ip_pool_address.ip_address
+  }
+}
\ No newline at end of file
@@ -0,0 +1,80 @@
+data "morpheus_group" "morpheus_lab" {
+  name = "MORPHEUS"
+}
+
+data "morpheus_cloud" "morpheus_aws" {
+  name = "MORPHEUSAWS"
+}
+
+data "morpheus_network" "private_subnet" {
+  name = "private-subnet-a"
+}
+
+data "morpheus_plan" "master_nodes" {
+  name           = "T3 Large - 2 Core, 8GB Memory"
+  provision_type = "amazon"
+}
+
+data "morpheus_plan" "worker_nodes" {
+  name           = "T3 XLarge - 4 Core, 16GB Memory"
+  provision_type = "amazon"
+}
+
+resource "morpheus_kubernetes_cluster" "tf_example_kubernetes_cluster" {
+  name              = "tfaws"
+  description       = "Terraform MKS on Amazon cluster example"
+  cluster_type      = "kubernetes-cluster"
+  cloud_id          = data.morpheus_cloud.morpheus_aws.id
+  group_id          = data.morpheus_group.morpheus_lab.id
+  cluster_layout_id = 312
+  pod_cidr          = "172.20.0.0/16"
+  service_cidr      = "172.30.0.0/16"
+
+  layout_options = {
+    "cniPlugin" = "calico"
+  }
+
+  config = {
+    "availabilityId" = "us-east-1a"
+    "securityId"     = "sg-0123456789abcdef0"
+  }
+
+  master_node_pool {
+    plan_id = data.morpheus_plan.master_nodes.id
+
+    network_interface {
+      network_id = data.morpheus_network.private_subnet.id
+    }
+
+    storage_volume {
+      root         = true
+      size         = 30
+      name         = "root"
+      storage_type = 2
+    }
+
+    tags = {
+      "app" = "mksmaster"
+    }
+  }
+
+  worker_node_pool {
+    count   = 3
+    plan_id = data.morpheus_plan.worker_nodes.id
+
+    network_interface {
+      network_id = data.morpheus_network.private_subnet.id
+    }
+
+    storage_volume {
+      root         = true
+      size         = 30
+      name         = "root"
+      storage_type = 2
+    }
+
+    tags = {
+      "app" = "mksworker"
+    }
+  }
+}
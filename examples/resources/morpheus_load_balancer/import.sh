@@ -0,0 +1 @@
+terraform import morpheus_load_balancer.tf_example_f5 1
\ No newline at end of file
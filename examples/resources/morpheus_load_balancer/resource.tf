@@ -0,0 +1,13 @@
+resource "morpheus_load_balancer" "tf_example_f5" {
+  name        = "tf-example-f5"
+  description = "Terraform example F5 load balancer"
+  type_code   = "f5"
+  host        = "f5.example.local"
+  port        = 443
+  username    = "admin"
+  password    = "password123"
+  visibility  = "private"
+  config = jsonencode({
+    partition = "Common"
+  })
+}
\ No newline at end of file
@@ -0,0 +1 @@
+terraform import morpheus_load_balancer_monitor.tf_example_http_monitor 1/2
\ No newline at end of file
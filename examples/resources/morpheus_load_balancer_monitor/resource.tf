@@ -0,0 +1,10 @@
+resource "morpheus_load_balancer_monitor" "tf_example_http_monitor" {
+  load_balancer_id = morpheus_load_balancer.tf_example_f5.id
+  name             = "tf-example-http"
+  description      = "Terraform example HTTP health monitor"
+  monitor_type     = "http"
+  interval         = 5
+  timeout          = 16
+  send_data        = "GET /health HTTP/1.1\\r\\nHost: app.example.local\\r\\n\\r\\n"
+  receive_data     = "OK"
+}
\ No newline at end of file
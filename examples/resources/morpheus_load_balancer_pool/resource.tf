@@ -0,0 +1,18 @@
+resource "morpheus_load_balancer_pool" "tf_example_web_pool" {
+  load_balancer_id = morpheus_load_balancer.tf_example_f5.id
+  name             = "tf-example-web"
+  description      = "Terraform example web pool"
+  balance_mode     = "roundrobin"
+  monitor_ids      = [morpheus_load_balancer_monitor.tf_example_http_monitor.id]
+
+  member {
+    instance_id = morpheus_vsphere_instance.tf_example_vsphere_instance.id
+    port        = 8080
+  }
+
+  member {
+    ip_address = "10.100.0.25"
+    port       = 8080
+    weight     = 2
+  }
+}
\ No newline at end of file
@@ -0,0 +1 @@
+terraform import morpheus_load_balancer_virtual_server.tf_example_web_vip 1/2
\ No newline at end of file
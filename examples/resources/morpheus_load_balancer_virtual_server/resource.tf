@@ -0,0 +1,11 @@
+resource "morpheus_load_balancer_virtual_server" "tf_example_web_vip" {
+  load_balancer_id = morpheus_load_balancer.tf_example_f5.id
+  name             = "tf-example-web-vip"
+  description      = "Terraform example web virtual server"
+  vip_address      = "10.100.0.200"
+  vip_port         = 443
+  vip_protocol     = "https"
+  vip_hostname     = "app.example.local"
+  pool_id          = morpheus_load_balancer_pool.tf_example_web_pool.id
+  ssl_profile      = "clientssl"
+}
\ No newline at end of file
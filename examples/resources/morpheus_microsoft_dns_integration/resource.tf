@@ -0,0 +1,9 @@
+resource "morpheus_microsoft_dns_integration" "tf_example_microsoft_dns_integration" {
+  name           = "tf-example-microsoft-dns"
+  enabled        = true
+  server         = "dc01.example.local"
+  username       = "EXAMPLE\\morpheus"
+  password       = "password123"
+  zone_filter    = "example.local"
+  create_pointer = true
+}
\ No newline at end of file
@@ -0,0 +1,48 @@
+data "morpheus_cloud" "tf_example_cloud" {
+  name = "MVM Cloud"
+}
+
+resource "morpheus_network" "tf_example_network" {
+  name                  = "tf-example-vlan-100"
+  display_name          = "Terraform Example VLAN 100"
+  description           = "Terraform example network"
+  cloud_id              = data.morpheus_cloud.tf_example_cloud.id
+  type_id               = 5
+  cidr                  = "10.100.0.0/24"
+  gateway               = "10.100.0.1"
+  dns_primary           = "10.100.0.2"
+  dns_secondary         = "10.100.0.3"
+  search_domains        = "example.local"
+  vlan_id               = 100
+  dhcp_server           = false
+  allow_static_override = true
+  pool_id               = morpheus_ipv4_ip_pool.tf_example_ipv4_pool.id
+  network_domain_id     = morpheus_network_domain.tf_example_network_domain.id
+  visibility            = "private"
+  all_group_access      = false
+  group_access {
+    group_id = 1
+    default  = true
+  }
+  tenant_ids = [1]
+}
+
+resource "morpheus_network" "tf_example_aws_subnet" {
+  name             = "tf-example-aws-subnet"
+  cloud_id         = 3
+  type_id          = 12
+  resource_pool_id = 25
+  cidr             = "172.31.100.0/24"
+  config = jsonencode({
+    availabilityZone = "us-east-1a"
+  })
+}
+
+resource "morpheus_network" "tf_example_discovered_network" {
+  name           = "VM Network"
+  cloud_id       = 4
+  type_id        = 2
+  adopt_existing = true
+  dns_primary    = "10.0.0.2"
+  pool_id        = morpheus_ipv4_ip_pool.tf_example_ipv4_pool.id
+}
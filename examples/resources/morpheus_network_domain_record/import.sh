@@ -0,0 +1 @@
+terraform import morpheus_network_domain_record.tf_example_a_record 1/2
\ No newline at end of file
@@ -0,0 +1,15 @@
+resource "morpheus_network_domain_record" "tf_example_a_record" {
+  domain_id = morpheus_network_domain.tf_example_network_domain.id
+  name      = "app01"
+  type      = "A"
+  content   = morpheus_ipv4_ip_pool_address.tf_example_ipv4_ip_pool_address.ip_address
+  ttl       = 3600
+}
+
+resource "morpheus_network_domain_record" "tf_example_cname_record" {
+  domain_id = morpheus_network_domain.tf_example_network_domain.id
+  name      = "app"
+  type      = "CNAME"
+  content   = morpheus_network_domain_record.tf_example_a_record.fqdn
+  ttl       = 3600
+}
\ No newline at end of file
@@ -0,0 +1,23 @@
+resource "morpheus_network_group" "tf_example_network_group" {
+  name             = "tf-example-network-group"
+  description      = "Terraform example network group"
+  network_ids      = [morpheus_network.tf_example_network.id, 12]
+  subnet_ids       = [morpheus_network_subnet.tf_example_network_subnet.id]
+  visibility       = "private"
+  all_group_access = false
+  group_access {
+    group_id = 1
+    default  = false
+  }
+  tenant_ids = [1]
+}
+
+resource "morpheus_vsphere_instance" "tf_example_vsphere_instance" {
+  name = "tfexample-app01"
+  # ...
+
+  interfaces {
+    network_id    = morpheus_network_group.tf_example_network_group.id
+    network_group = true
+  }
+}
\ No newline at end of file
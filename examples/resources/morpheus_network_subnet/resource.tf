@@ -0,0 +1,15 @@
+resource "morpheus_network_subnet" "tf_example_network_subnet" {
+  network_id            = morpheus_network.tf_example_network.id
+  name                  = "tf-example-subnet"
+  description           = "Terraform example network subnet"
+  cidr                  = "10.100.0.0/26"
+  gateway               = "10.100.0.1"
+  dns_primary           = "10.100.0.2"
+  dns_secondary         = "10.100.0.3"
+  dhcp_server           = true
+  allow_static_override = false
+  pool_id               = morpheus_ipv4_ip_pool.tf_example_ipv4_pool.id
+  visibility            = "private"
+  all_group_access      = true
+  tenant_ids            = [1]
+}
\ No newline at end of file
@@ -0,0 +1 @@
+terraform import morpheus_phpipam_integration.tf_example_phpipam_integration 1
\ No newline at end of file
@@ -0,0 +1,7 @@
+resource "morpheus_phpipam_integration" "tf_example_phpipam_integration" {
+  name           = "tf-example-phpipam"
+  enabled        = true
+  url            = "https://phpipam.example.local/api/morpheus"
+  credential_id  = 12
+  network_filter = "10.0.0.0/8"
+}
\ No newline at end of file
@@ -0,0 +1,8 @@
+resource "morpheus_powerdns_integration" "tf_example_powerdns_integration" {
+  name           = "tf-example-powerdns"
+  enabled        = true
+  url            = "https://powerdns.example.local:8081"
+  api_key        = "powerdns-api-key"
+  zone_filter    = "example.local"
+  create_pointer = false
+}
\ No newline at end of file
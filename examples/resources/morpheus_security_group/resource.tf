@@ -0,0 +1,13 @@
+resource "morpheus_security_group" "tf_example_security_group" {
+  name        = "tf-example-web"
+  description = "Terraform example security group"
+  visibility  = "private"
+  tenant_ids  = [1]
+}
+
+resource "morpheus_security_group" "tf_example_aws_security_group" {
+  name             = "tf-example-aws-web"
+  description      = "Terraform example AWS security group"
+  cloud_id         = 3
+  resource_pool_id = 25
+}
\ No newline at end of file
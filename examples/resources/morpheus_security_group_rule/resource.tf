@@ -0,0 +1,32 @@
+resource "morpheus_security_group_rule" "tf_example_https" {
+  security_group_id = morpheus_security_group.tf_example_security_group.id
+  name              = "Allow HTTPS"
+  direction         = "ingress"
+  protocol          = "tcp"
+  port_range        = "443"
+  source_type       = "cidr"
+  source            = "0.0.0.0/0"
+  destination_type  = "instance"
+  policy            = "accept"
+}
+
+resource "morpheus_security_group_rule" "tf_example_app_ports" {
+  security_group_id = morpheus_security_group.tf_example_security_group.id
+  name              = "Allow app ports from the load balancers"
+  direction         = "ingress"
+  protocol          = "tcp"
+  port_range        = "8000-8100"
+  source_type       = "group"
+  source_group_id   = morpheus_security_group.tf_example_aws_security_group.id
+  destination_type  = "instance"
+}
+
+resource "morpheus_security_group_rule" "tf_example_egress" {
+  security_group_id = morpheus_security_group.tf_example_security_group.id
+  name              = "Allow all outbound traffic"
+  direction         = "egress"
+  protocol          = "any"
+  source_type       = "instance"
+  destination_type  = "cidr"
+  destination       = "0.0.0.0/0"
+}
\ No newline at end of file
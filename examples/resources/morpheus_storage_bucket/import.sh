@@ -0,0 +1 @@
+terraform import morpheus_storage_bucket.tf_example_s3_storage_bucket 1
\ No newline at end of file
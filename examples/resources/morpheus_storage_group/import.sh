@@ -0,0 +1 @@
+terraform import morpheus_storage_group.tf_example_storage_group 1
\ No newline at end of file
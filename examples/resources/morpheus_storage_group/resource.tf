@@ -0,0 +1,4 @@
+resource "morpheus_storage_group" "tf_example_storage_group" {
+  name              = "tfexample-cpg-ssd"
+  storage_server_id = 1
+}
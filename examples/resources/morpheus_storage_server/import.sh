@@ -0,0 +1 @@
+terraform import morpheus_storage_server.tf_example_3par_storage_server 1
\ No newline at end of file
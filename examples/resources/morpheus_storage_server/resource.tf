@@ -0,0 +1,15 @@
+resource "morpheus_storage_server" "tf_example_3par_storage_server" {
+  name       = "tfexample-3par"
+  type_code  = "3par"
+  url        = "https://3par.example.local:8080"
+  username   = "3paradm"
+  password   = "password123"
+  visibility = "private"
+}
+
+resource "morpheus_storage_server" "tf_example_pure_storage_server" {
+  name          = "tfexample-pure"
+  type_code     = "pureStorage"
+  url           = "https://pure.example.local"
+  credential_id = 3
+}
@@ -0,0 +1,12 @@
+data "morpheus_storage_volume_type" "tf_example_storage_volume_type" {
+  name = "3PAR Volume"
+}
+
+resource "morpheus_storage_volume" "tf_example_storage_volume" {
+  name              = "tfexample-data01"
+  type_id           = data.morpheus_storage_volume_type.tf_example_storage_volume_type.id
+  size              = 100
+  storage_server_id = 1
+  storage_group_id  = 2
+  instance_id       = 12
+}
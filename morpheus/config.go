@@ -1,8 +1,13 @@
 package morpheus
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/gomorpheus/morpheus-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -22,9 +27,46 @@ type Config struct {
 	// Scope            string // "scope"
 	// GrantType            string  // "bearer"
 
-	Insecure bool
+	Insecure   bool
+	CACertFile string
+	CACertPEM  string
+	ClientCert string
+	ClientKey  string
 
+	// PollDelay and PollInterval control how long running operations,
+	// such as provisioning an instance, are waited on
+	PollDelay    time.Duration
+	PollInterval time.Duration
+
+	// MaxRetries, RetryWaitMin and RetryWaitMax control how failed API
+	// requests are retried and MaxRequestsPerSecond limits how quickly
+	// requests are sent
+	MaxRetries           int
+	RetryWaitMin         time.Duration
+	RetryWaitMax         time.Duration
+	MaxRequestsPerSecond int
+
+	client *morpheus.Client
+	proxy  *applianceProxy
+}
+
+const (
+	defaultPollDelay    = 10 * time.Second
+	defaultPollInterval = 30 * time.Second
+	// StateChangeConf ignores poll intervals of three minutes or more
+	maxPollInterval = 3 * time.Minute
+	// deletePollInterval is how often a deleted instance is checked until
+	// it is removed, instances are removed quickly so this does not use the
+	// provider poll settings
+	deletePollInterval = 1 * time.Second
+)
+
+// providerMeta is the meta value passed to every resource and data source,
+// it holds the API client along with the provider configuration so that
+// resources can look up provider level settings such as the poll intervals.
+type providerMeta struct {
 	client *morpheus.Client
+	config *Config
 }
 
 func (c *Config) Client() (*morpheus.Client, diag.Diagnostics) {
@@ -32,7 +74,19 @@ func (c *Config) Client() (*morpheus.Client, diag.Diagnostics) {
 	debug := logging.IsDebugOrHigher() && os.Getenv("MORPHEUS_API_HTTPTRACE") == "true"
 
 	if c.client == nil {
-		client := morpheus.NewClient(c.Url, morpheus.WithDebug(debug))
+		tlsConfig, err := c.TLSConfig()
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		proxy, err := newApplianceProxy(c.Url, newRetryTransport(transport, c))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		c.proxy = proxy
+
+		client := morpheus.NewClient(proxy.Url, morpheus.WithDebug(debug))
 		// should validate url here too, and maybe ping it
 		// logging with access token or username and password?
 		if c.Username != "" {
@@ -50,3 +104,66 @@ func (c *Config) Client() (*morpheus.Client, diag.Diagnostics) {
 	}
 	return c.client, nil
 }
+
+// Close stops the proxy the client sends its requests through, the client
+// cannot be used afterwards
+func (c *Config) Close() error {
+	if c.proxy == nil {
+		return nil
+	}
+	return c.proxy.Close()
+}
+
+// TLSConfig builds the TLS configuration described by the insecure, CA
+// certificate and client certificate settings
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.Insecure,
+	}
+
+	caCert := []byte(c.CACertPEM)
+	if c.CACertFile != "" {
+		var err error
+		caCert, err = os.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ca_cert_file: %s", err)
+		}
+	}
+	if len(caCert) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no PEM encoded certificates were found in the CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		clientCert, err := readPEM(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client_cert: %s", err)
+		}
+		clientKey, err := readPEM(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client_key: %s", err)
+		}
+		certificate, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns value when it is PEM encoded, otherwise value is treated
+// as the path of the file to read
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
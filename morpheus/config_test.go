@@ -0,0 +1,181 @@
+package morpheus
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+)
+
+// newTestTLSAppliance starts an appliance with a certificate issued by a
+// private CA and returns it along with the PEM encoded CA certificate
+func newTestTLSAppliance(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"path":    r.URL.Path,
+		})
+	}))
+	t.Cleanup(server.Close)
+	caCert := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})
+	return server, string(caCert)
+}
+
+func testConfigWhoami(t *testing.T, config *Config) (*morpheus.Response, error) {
+	t.Helper()
+	client, diags := config.Client()
+	if diags.HasError() {
+		t.Fatalf("unable to create the client: %v", diags)
+	}
+	t.Cleanup(func() { config.Close() })
+	return client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   "/api/whoami",
+		Result: &morpheus.StandardResult{},
+	})
+}
+
+func TestConfigClient_caCertPEM(t *testing.T) {
+	server, caCert := newTestTLSAppliance(t)
+
+	resp, err := testConfigWhoami(t, &Config{
+		Url:         server.URL,
+		AccessToken: fakeMorpheusAccessToken,
+		CACertPEM:   caCert,
+	})
+	if err != nil {
+		t.Fatalf("expected the appliance certificate to be trusted with ca_cert_pem: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected HTTP 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestConfigClient_untrustedCertificate(t *testing.T) {
+	server, _ := newTestTLSAppliance(t)
+
+	resp, err := testConfigWhoami(t, &Config{
+		Url:         server.URL,
+		AccessToken: fakeMorpheusAccessToken,
+	})
+	if err == nil {
+		t.Fatalf("expected the request to fail when the appliance certificate is not trusted")
+	}
+	if resp == nil || !strings.Contains(string(resp.Body), "certificate") {
+		t.Fatalf("expected a certificate verification error, got %s", resp)
+	}
+}
+
+func TestConfigClient_insecure(t *testing.T) {
+	server, _ := newTestTLSAppliance(t)
+
+	_, err := testConfigWhoami(t, &Config{
+		Url:         server.URL,
+		AccessToken: fakeMorpheusAccessToken,
+		Insecure:    true,
+	})
+	if err != nil {
+		t.Fatalf("expected certificate verification to be skipped with insecure: %s", err)
+	}
+}
+
+func TestConfigClient_clientCertificate(t *testing.T) {
+	clientCert, clientKey := newTestClientCertificate(t)
+	certificate, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+	if err != nil {
+		t.Fatalf("unable to load the client certificate: %s", err)
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		t.Fatalf("unable to parse the client certificate: %s", err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(leaf)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": true})
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	if _, err := testConfigWhoami(t, &Config{
+		Url:         server.URL,
+		AccessToken: fakeMorpheusAccessToken,
+		Insecure:    true,
+	}); err == nil {
+		t.Fatalf("expected the request to fail without a client certificate")
+	}
+
+	if _, err := testConfigWhoami(t, &Config{
+		Url:         server.URL,
+		AccessToken: fakeMorpheusAccessToken,
+		Insecure:    true,
+		ClientCert:  clientCert,
+		ClientKey:   clientKey,
+	}); err != nil {
+		t.Fatalf("expected the client certificate to be presented to the appliance: %s", err)
+	}
+}
+
+// newTestClientCertificate returns a PEM encoded self signed client
+// certificate and its private key
+func newTestClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate the client key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create the client certificate: %s", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to encode the client key: %s", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return string(certPEM), string(keyPEM)
+}
+
+func TestConfigClient_invalidCACert(t *testing.T) {
+	config := &Config{
+		Url:       "https://morpheus.example.com",
+		CACertPEM: "not a certificate",
+	}
+	if _, diags := config.Client(); !diags.HasError() {
+		t.Fatalf("expected an error for a CA bundle without certificates")
+	}
+}
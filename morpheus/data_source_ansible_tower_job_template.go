@@ -33,7 +33,7 @@ func dataSourceMorpheusAnsibleTowerJobTemplate() *schema.Resource {
 }
 
 func dataSourceMorpheusAnsibleTowerJobTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -54,7 +54,7 @@ func dataSourceMorpheusClouds() *schema.Resource {
 }
 
 func dataSourceMorpheusCloudsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -88,12 +88,12 @@ func dataSourceMorpheusCloudsRead(ctx context.Context, d *schema.ResourceData, m
 		sortOrder = "desc"
 	}
 
-	resp, err = client.ListClouds(&morpheus.Request{
-		QueryParams: map[string]string{
-			"max":       "250",
-			"sort":      "id",
-			"direction": sortOrder,
-		},
+	clouds, resp, err := listAllPages(client.ListClouds, map[string]string{
+		"sort":      "id",
+		"direction": sortOrder,
+	}, func(resp *morpheus.Response) (*[]morpheus.Cloud, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListCloudsResult)
+		return result.Clouds, result.Meta
 	})
 
 	if err != nil {
@@ -105,14 +105,11 @@ func dataSourceMorpheusCloudsRead(ctx context.Context, d *schema.ResourceData, m
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("API RESPONSE: %s", resp)
 
 	cloudIDs := []int64{}
 
 	// store resource data
-	result := resp.Result.(*morpheus.ListCloudsResult)
-	clouds := result.Clouds
-	for _, cloud := range *clouds {
+	for _, cloud := range clouds {
 		if len(names) > 0 {
 			if regexCheck(names, cloud.Name) {
 				cloudIDs = append(cloudIDs, cloud.ID)
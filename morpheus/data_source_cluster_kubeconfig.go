@@ -0,0 +1,269 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMorpheusClusterKubeconfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides the kubeconfig and API credentials of a Morpheus Kubernetes cluster, which can be used to configure the kubernetes and helm providers.",
+		ReadContext: dataSourceMorpheusClusterKubeconfigRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the Kubernetes cluster",
+				Required:    true,
+			},
+			"api_endpoint": {
+				Type:        schema.TypeString,
+				Description: "The API URL of the cluster",
+				Computed:    true,
+			},
+			"cluster_ca_certificate": {
+				Type:        schema.TypeString,
+				Description: "The PEM encoded CA certificate of the cluster API",
+				Computed:    true,
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "The service account token used to access the cluster API",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Description: "The URL of the API proxy used to reach the cluster API, if the cluster has one",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"kubernetes_version": {
+				Type:        schema.TypeString,
+				Description: "The Kubernetes version of the cluster",
+				Computed:    true,
+			},
+			"kubeconfig": {
+				Type:        schema.TypeString,
+				Description: "A kubeconfig document for the cluster that authenticates with the service account token",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func dataSourceMorpheusClusterKubeconfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	clusterId := int64(d.Get("cluster_id").(int))
+
+	// The API credentials are only available once the cluster is ok
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{statusProvisioning, statusPending, statusSyncing, statusUpgrading},
+		Target:  []string{statusOk},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetCluster(clusterId, &morpheus.Request{})
+			if err != nil {
+				log.Printf("API FAILURE: %s - %s", resp, err)
+				return "", "", err
+			}
+			var cluster ClusterApiProxyPayload
+			if err := json.Unmarshal(resp.Body, &cluster); err != nil {
+				return "", "", err
+			}
+			return &cluster, cluster.Cluster.Status, nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutRead),
+		MinTimeout:   30 * time.Second,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	clusterResult, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error waiting for cluster %d to be ok: %s", clusterId, err)
+	}
+	cluster := clusterResult.(*ClusterApiProxyPayload).Cluster
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/clusters/%d/api-config", clusterId),
+		Result: &ClusterApiConfigResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	apiConfig := resp.Result.(*ClusterApiConfigResult)
+
+	caCertificate, err := clusterCACertificate(apiConfig.ServiceCert)
+	if err != nil {
+		return diag.Errorf("error reading the CA certificate of cluster %d: %s", clusterId, err)
+	}
+
+	var proxyURL string
+	if cluster.ApiProxy != nil && cluster.ApiProxy.ID != 0 {
+		proxyURL, err = clusterProxyURL(client, cluster.ApiProxy.ID)
+		if err != nil {
+			return diag.Errorf("error reading the API proxy of cluster %d: %s", clusterId, err)
+		}
+	}
+
+	kubeconfig, err := clusterKubeconfig(cluster.Name, apiConfig.ServiceUrl, caCertificate, apiConfig.ServiceToken, proxyURL)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(int64ToString(clusterId))
+	d.Set("api_endpoint", apiConfig.ServiceUrl)
+	d.Set("cluster_ca_certificate", caCertificate)
+	d.Set("token", apiConfig.ServiceToken)
+	d.Set("proxy_url", proxyURL)
+	d.Set("kubernetes_version", apiConfig.ServiceVersion)
+	d.Set("kubeconfig", kubeconfig)
+	return diags
+}
+
+// clusterCACertificate returns the CA certificate of a cluster as PEM, the
+// API returns it either as PEM or as base64 encoded PEM
+func clusterCACertificate(serviceCert string) (string, error) {
+	serviceCert = strings.TrimSpace(serviceCert)
+	if serviceCert == "" || strings.HasPrefix(serviceCert, "-----BEGIN") {
+		return serviceCert, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(serviceCert)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// clusterProxyURL returns the url of the network proxy used to reach the
+// cluster API
+func clusterProxyURL(client *morpheus.Client, proxyId int64) (string, error) {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/networks/proxies/%d", proxyId),
+		Result: &GetNetworkProxyResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		if resp != nil && resp.StatusCode == 404 {
+			return "", fmt.Errorf("network proxy %d not found", proxyId)
+		}
+		return "", err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	proxy := resp.Result.(*GetNetworkProxyResult).NetworkProxy
+	if proxy == nil {
+		return "", fmt.Errorf("network proxy %d not found", proxyId)
+	}
+
+	proxyURL := &url.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("%s:%d", proxy.ProxyHost, proxy.ProxyPort),
+	}
+	if proxy.ProxyUser != "" {
+		proxyURL.User = url.UserPassword(proxy.ProxyUser, proxy.ProxyPassword)
+	}
+	return proxyURL.String(), nil
+}
+
+// clusterKubeconfig renders a kubeconfig for the cluster. The document is
+// written as JSON, which is valid YAML and accepted by kubectl and the
+// kubernetes and helm providers.
+func clusterKubeconfig(name string, server string, caCertificate string, token string, proxyURL string) (string, error) {
+	cluster := map[string]interface{}{
+		"server": server,
+	}
+	if caCertificate != "" {
+		cluster["certificate-authority-data"] = base64.StdEncoding.EncodeToString([]byte(caCertificate))
+	}
+	if proxyURL != "" {
+		cluster["proxy-url"] = proxyURL
+	}
+
+	kubeconfig := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Config",
+		"clusters": []map[string]interface{}{
+			{
+				"name":    name,
+				"cluster": cluster,
+			},
+		},
+		"users": []map[string]interface{}{
+			{
+				"name": name,
+				"user": map[string]interface{}{
+					"token": token,
+				},
+			},
+		},
+		"contexts": []map[string]interface{}{
+			{
+				"name": name,
+				"context": map[string]interface{}{
+					"cluster": name,
+					"user":    name,
+				},
+			},
+		},
+		"current-context": name,
+	}
+
+	output, err := json.MarshalIndent(kubeconfig, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+type ClusterApiProxyPayload struct {
+	Cluster struct {
+		ID       int64  `json:"id"`
+		Name     string `json:"name"`
+		Status   string `json:"status"`
+		ApiProxy *struct {
+			ID int64 `json:"id"`
+		} `json:"apiProxy"`
+	} `json:"cluster"`
+}
+
+type ClusterApiConfigResult struct {
+	ServiceUrl     string `json:"serviceUrl"`
+	ServiceToken   string `json:"serviceToken"`
+	ServiceCert    string `json:"serviceCert"`
+	ServiceVersion string `json:"serviceVersion"`
+}
+
+type GetNetworkProxyResult struct {
+	NetworkProxy *NetworkProxy `json:"networkProxy"`
+}
+
+type NetworkProxy struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	ProxyHost     string `json:"proxyHost"`
+	ProxyPort     int64  `json:"proxyPort"`
+	ProxyUser     string `json:"proxyUser"`
+	ProxyPassword string `json:"proxyPassword"`
+}
@@ -40,7 +40,7 @@ func dataSourceMorpheusCypherSecret() *schema.Resource {
 }
 
 func dataSourceMorpheusCypherSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
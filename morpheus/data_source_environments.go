@@ -54,7 +54,7 @@ func dataSourceMorpheusEnvironments() *schema.Resource {
 }
 
 func dataSourceMorpheusEnvironmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -76,12 +76,12 @@ func dataSourceMorpheusEnvironmentsRead(ctx context.Context, d *schema.ResourceD
 		sortOrder = "desc"
 	}
 
-	resp, err = client.ListEnvironments(&morpheus.Request{
-		QueryParams: map[string]string{
-			"max":       "50",
-			"sort":      "id",
-			"direction": sortOrder,
-		},
+	environments, resp, err := listAllPages(client.ListEnvironments, map[string]string{
+		"sort":      "id",
+		"direction": sortOrder,
+	}, func(resp *morpheus.Response) (*[]morpheus.Environment, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListEnvironmentsResult)
+		return result.Environments, result.Meta
 	})
 
 	if err != nil {
@@ -93,14 +93,11 @@ func dataSourceMorpheusEnvironmentsRead(ctx context.Context, d *schema.ResourceD
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("API RESPONSE: %s", resp)
 
 	environmentIDs := []int64{}
 
 	// store resource data
-	result := resp.Result.(*morpheus.ListEnvironmentsResult)
-	environments := result.Environments
-	for _, environment := range *environments {
+	for _, environment := range environments {
 		environmentIDs = append(environmentIDs, environment.ID)
 	}
 	d.SetId("1")
@@ -56,7 +56,7 @@ func dataSourceMorpheusGroups() *schema.Resource {
 }
 
 func dataSourceMorpheusGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -100,12 +100,12 @@ func dataSourceMorpheusGroupsRead(ctx context.Context, d *schema.ResourceData, m
 		sortOrder = "desc"
 	}
 
-	resp, err = client.ListGroups(&morpheus.Request{
-		QueryParams: map[string]string{
-			"max":       "50",
-			"sort":      "id",
-			"direction": sortOrder,
-		},
+	groups, resp, err := listAllPages(client.ListGroups, map[string]string{
+		"sort":      "id",
+		"direction": sortOrder,
+	}, func(resp *morpheus.Response) (*[]morpheus.Group, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListGroupsResult)
+		return result.Groups, result.Meta
 	})
 
 	if err != nil {
@@ -117,14 +117,11 @@ func dataSourceMorpheusGroupsRead(ctx context.Context, d *schema.ResourceData, m
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("API RESPONSE: %s", resp)
 
 	var groupIDs []string
 
 	// store resource data
-	result := resp.Result.(*morpheus.ListGroupsResult)
-	groups := result.Groups
-	for _, group := range *groups {
+	for _, group := range groups {
 		if regexCheck(locations, group.Location) && regexCheck(names, group.Name) {
 			groupIDs = append(groupIDs, strconv.Itoa(int(group.ID)))
 		}
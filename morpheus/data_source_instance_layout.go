@@ -48,7 +48,7 @@ func dataSourceMorpheusInstanceLayout() *schema.Resource {
 }
 
 func dataSourceMorpheusInstanceLayoutRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -98,17 +98,16 @@ func dataSourceMorpheusInstanceLayoutRead(ctx context.Context, d *schema.Resourc
 
 func FindInstanceLayoutByNameAndVersion(client *morpheus.Client, name string, version string) (*morpheus.Response, error) {
 	// Find by name, then get by ID
-	resp, err := client.ListInstanceLayouts(&morpheus.Request{
-		QueryParams: map[string]string{
-			"name": name,
-			"max":  "5000",
-		},
+	layouts, resp, err := listAllPages(client.ListInstanceLayouts, map[string]string{
+		"name": name,
+	}, func(resp *morpheus.Response) (*[]morpheus.InstanceLayout, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListInstanceLayoutsResult)
+		return result.InstanceLayouts, result.Meta
 	})
 	if err != nil {
 		return resp, err
 	}
-	listResult := resp.Result.(*morpheus.ListInstanceLayoutsResult)
-	for _, layout := range *listResult.InstanceLayouts {
+	for _, layout := range layouts {
 		if layout.ContainerVersion == version {
 			return client.GetInstanceLayout(layout.ID, &morpheus.Request{})
 		}
@@ -0,0 +1,107 @@
+package morpheus
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMorpheusInstanceSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Morpheus instance snapshots data source.",
+		ReadContext: dataSourceMorpheusInstanceSnapshotsRead,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the instance to list snapshots for",
+				Required:    true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"snapshots": {
+				Type:        schema.TypeList,
+				Description: "The snapshots of the instance",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the instance snapshot",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the instance snapshot",
+							Computed:    true,
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Description: "The description of the instance snapshot",
+							Computed:    true,
+						},
+						"snapshot_type": {
+							Type:        schema.TypeString,
+							Description: "The type of the instance snapshot",
+							Computed:    true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Description: "The status of the instance snapshot",
+							Computed:    true,
+						},
+						"date_created": {
+							Type:        schema.TypeString,
+							Description: "The date the instance snapshot was created",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMorpheusInstanceSnapshotsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	instanceId := int64(d.Get("instance_id").(int))
+
+	snapshots, resp, err := listInstanceSnapshots(client, instanceId)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %v", resp, err)
+			return diag.Errorf("instance %d not found", instanceId)
+		} else {
+			log.Printf("API FAILURE: %s - %v", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+
+	snapshotIDs := []int64{}
+	var snapshotList []map[string]interface{}
+
+	// store resource data
+	for _, snapshot := range snapshots {
+		snapshotIDs = append(snapshotIDs, snapshot.ID)
+		snapshotList = append(snapshotList, map[string]interface{}{
+			"id":            snapshot.ID,
+			"name":          snapshot.Name,
+			"description":   snapshot.Description,
+			"snapshot_type": snapshot.SnapshotType,
+			"status":        snapshot.Status,
+			"date_created":  snapshot.DateCreated,
+		})
+	}
+	d.SetId(int64ToString(instanceId))
+	d.Set("ids", snapshotIDs)
+	d.Set("snapshots", snapshotList)
+	return diags
+}
@@ -62,7 +62,7 @@ func dataSourceMorpheusNetwork() *schema.Resource {
 }
 
 func dataSourceMorpheusNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -46,7 +46,7 @@ func dataSourceMorpheusNetworkGroup() *schema.Resource {
 }
 
 func dataSourceMorpheusNetworkGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -60,7 +60,7 @@ func dataSourceMorpheusNetworks() *schema.Resource {
 }
 
 func dataSourceMorpheusNetworksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -95,7 +95,6 @@ func dataSourceMorpheusNetworksRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	params := make(map[string]string)
-	params["max"] = "250"
 	params["sort"] = "id"
 	params["direction"] = sortOrder
 
@@ -104,8 +103,9 @@ func dataSourceMorpheusNetworksRead(ctx context.Context, d *schema.ResourceData,
 		params["zoneId"] = cloud_id_string
 	}
 
-	resp, err = client.ListNetworks(&morpheus.Request{
-		QueryParams: params,
+	networks, resp, err := listAllPages(client.ListNetworks, params, func(resp *morpheus.Response) (*[]morpheus.Network, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListNetworksResult)
+		return result.Networks, result.Meta
 	})
 
 	if err != nil {
@@ -117,14 +117,11 @@ func dataSourceMorpheusNetworksRead(ctx context.Context, d *schema.ResourceData,
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("API RESPONSE: %s", resp)
 
 	var networksIDs []string
 
 	// store resource data
-	result := resp.Result.(*morpheus.ListNetworksResult)
-	networks := result.Networks
-	for _, network := range *networks {
+	for _, network := range networks {
 		if len(names) > 0 {
 			if regexCheck(names, network.Name) {
 				networksIDs = append(networksIDs, strconv.Itoa(int(network.ID)))
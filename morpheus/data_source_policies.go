@@ -55,7 +55,7 @@ func dataSourceMorpheusPolicies() *schema.Resource {
 }
 
 func dataSourceMorpheusPoliciesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -99,12 +99,12 @@ func dataSourceMorpheusPoliciesRead(ctx context.Context, d *schema.ResourceData,
 		sortOrder = "desc"
 	}
 
-	resp, err = client.ListPolicies(&morpheus.Request{
-		QueryParams: map[string]string{
-			"max":       "100",
-			"sort":      "id",
-			"direction": sortOrder,
-		},
+	policies, resp, err := listAllPages(client.ListPolicies, map[string]string{
+		"sort":      "id",
+		"direction": sortOrder,
+	}, func(resp *morpheus.Response) (*[]morpheus.Policy, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListPoliciesResult)
+		return result.Policies, result.Meta
 	})
 
 	if err != nil {
@@ -116,14 +116,11 @@ func dataSourceMorpheusPoliciesRead(ctx context.Context, d *schema.ResourceData,
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("API RESPONSE: %s", resp)
 
 	var policyIDs []string
 
 	// store resource data
-	result := resp.Result.(*morpheus.ListPoliciesResult)
-	policies := result.Policies
-	for _, policy := range *policies {
+	for _, policy := range policies {
 		if regexCheck(policyTypes, policy.PolicyType.Name) && regexCheck(names, policy.Name) {
 			policyIDs = append(policyIDs, strconv.Itoa(int(policy.ID)))
 		}
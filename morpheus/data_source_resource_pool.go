@@ -50,7 +50,7 @@ func dataSourceMorpheusResourcePool() *schema.Resource {
 }
 
 func dataSourceMorpheusResourcePoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
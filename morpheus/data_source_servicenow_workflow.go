@@ -39,7 +39,7 @@ func dataSourceMorpheusServiceNowWorkflow() *schema.Resource {
 }
 
 func dataSourceMorpheusServiceNowWorkflowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
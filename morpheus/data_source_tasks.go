@@ -55,7 +55,7 @@ func dataSourceMorpheusTasks() *schema.Resource {
 }
 
 func dataSourceMorpheusTasksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -99,12 +99,12 @@ func dataSourceMorpheusTasksRead(ctx context.Context, d *schema.ResourceData, me
 		sortOrder = "desc"
 	}
 
-	resp, err = client.ListTasks(&morpheus.Request{
-		QueryParams: map[string]string{
-			"max":       "100",
-			"sort":      "id",
-			"direction": sortOrder,
-		},
+	tasks, resp, err := listAllPages(client.ListTasks, map[string]string{
+		"sort":      "id",
+		"direction": sortOrder,
+	}, func(resp *morpheus.Response) (*[]morpheus.Task, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListTasksResult)
+		return result.Tasks, result.Meta
 	})
 
 	if err != nil {
@@ -116,14 +116,11 @@ func dataSourceMorpheusTasksRead(ctx context.Context, d *schema.ResourceData, me
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("API RESPONSE: %s", resp)
 
 	var taskIDs []string
 
 	// store resource data
-	result := resp.Result.(*morpheus.ListTasksResult)
-	tasks := result.Tasks
-	for _, task := range *tasks {
+	for _, task := range tasks {
 		if regexCheck(taskTypes, task.TaskType.Name) && regexCheck(names, task.Name) {
 			taskIDs = append(taskIDs, strconv.Itoa(int(task.ID)))
 		}
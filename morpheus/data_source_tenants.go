@@ -55,7 +55,7 @@ func dataSourceMorpheusTenants() *schema.Resource {
 }
 
 func dataSourceMorpheusTenantsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -88,12 +88,12 @@ func dataSourceMorpheusTenantsRead(ctx context.Context, d *schema.ResourceData,
 		sortOrder = "desc"
 	}
 
-	resp, err = client.ListTenants(&morpheus.Request{
-		QueryParams: map[string]string{
-			"max":       "100",
-			"sort":      "id",
-			"direction": sortOrder,
-		},
+	tenants, resp, err := listAllPages(client.ListTenants, map[string]string{
+		"sort":      "id",
+		"direction": sortOrder,
+	}, func(resp *morpheus.Response) (*[]morpheus.Tenant, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListTenantsResult)
+		return result.Accounts, result.Meta
 	})
 
 	if err != nil {
@@ -105,14 +105,11 @@ func dataSourceMorpheusTenantsRead(ctx context.Context, d *schema.ResourceData,
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("API RESPONSE: %s", resp)
 
 	var tenantIDs []string
 
 	// store resource data
-	result := resp.Result.(*morpheus.ListTenantsResult)
-	tenants := result.Accounts
-	for _, tenant := range *tenants {
+	for _, tenant := range tenants {
 		if regexCheck(names, tenant.Name) {
 			tenantIDs = append(tenantIDs, strconv.Itoa(int(tenant.ID)))
 		}
@@ -55,7 +55,7 @@ func dataSourceMorpheusUserGroups() *schema.Resource {
 }
 
 func dataSourceMorpheusUserGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -88,12 +88,12 @@ func dataSourceMorpheusUserGroupsRead(ctx context.Context, d *schema.ResourceDat
 		sortOrder = "desc"
 	}
 
-	resp, err = client.ListUserGroups(&morpheus.Request{
-		QueryParams: map[string]string{
-			"max":       "100",
-			"sort":      "id",
-			"direction": sortOrder,
-		},
+	userGroups, resp, err := listAllPages(client.ListUserGroups, map[string]string{
+		"sort":      "id",
+		"direction": sortOrder,
+	}, func(resp *morpheus.Response) (*[]morpheus.UserGroup, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListUserGroupsResult)
+		return result.UserGroups, result.Meta
 	})
 
 	if err != nil {
@@ -105,14 +105,11 @@ func dataSourceMorpheusUserGroupsRead(ctx context.Context, d *schema.ResourceDat
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("API RESPONSE: %s", resp)
 
 	var userGroupIDs []string
 
 	// store resource data
-	result := resp.Result.(*morpheus.ListUserGroupsResult)
-	userGroups := result.UserGroups
-	for _, userGroup := range *userGroups {
+	for _, userGroup := range userGroups {
 		if regexCheck(names, userGroup.Name) {
 			userGroupIDs = append(userGroupIDs, strconv.Itoa(int(userGroup.ID)))
 		}
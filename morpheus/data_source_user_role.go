@@ -31,7 +31,7 @@ func dataSourceMorpheusUserRole() *schema.Resource {
 }
 
 func dataSourceMorpheusUserRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -62,7 +62,7 @@ func dataSourceMorpheusVirtualImages() *schema.Resource {
 }
 
 func dataSourceMorpheusVirtualImagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -104,12 +104,29 @@ func dataSourceMorpheusVirtualImagesRead(ctx context.Context, d *schema.Resource
 		sortOrder = "desc"
 	}
 
-	output := ListAllVirtualImages(client, 200, sortOrder, d.Get("source").(string))
+	virtualImages, resp, err := listAllPages(client.ListVirtualImages, map[string]string{
+		"sort":       "id",
+		"direction":  sortOrder,
+		"filterType": d.Get("source").(string),
+	}, func(resp *morpheus.Response) (*[]morpheus.VirtualImage, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListVirtualImagesResult)
+		return result.VirtualImages, result.Meta
+	})
+
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %v", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %v", resp, err)
+			return diag.FromErr(err)
+		}
+	}
 
 	var virtulImageIDs []string
 
 	// store resource data
-	for _, virtualImage := range output {
+	for _, virtualImage := range virtualImages {
 		if regexCheck(imageTypes, virtualImage.ImageType) && regexCheck(names, virtualImage.Name) {
 			virtulImageIDs = append(virtulImageIDs, strconv.Itoa(int(virtualImage.ID)))
 		}
@@ -119,55 +136,3 @@ func dataSourceMorpheusVirtualImagesRead(ctx context.Context, d *schema.Resource
 	d.Set("ids", virtulImageIDs)
 	return diags
 }
-
-func ListAllVirtualImages(client *morpheus.Client, max int, sortOrder string, source string) (images []morpheus.VirtualImage) {
-	// Fetch initial images
-	params := make(map[string]string)
-	params["max"] = strconv.Itoa(max)
-	params["sort"] = "id"
-	params["direction"] = sortOrder
-	params["filterType"] = source
-	resp, err := client.ListVirtualImages(&morpheus.Request{
-		QueryParams: params,
-	})
-	if err != nil {
-		if resp != nil && resp.StatusCode == 404 {
-			log.Printf("API 404: %s - %v", resp, err)
-		} else {
-			log.Printf("API FAILURE: %s - %v", resp, err)
-		}
-	}
-	log.Printf("API RESPONSE: %s", resp)
-
-	result := resp.Result.(*morpheus.ListVirtualImagesResult)
-	pollIterations := result.Meta.Total / int64(max)
-	// Add Page 1 virtual images
-	images = append(images, *result.VirtualImages...)
-	currentPage := 1
-	for currentPage < int(pollIterations) {
-		// Fetch initial images
-		params := make(map[string]string)
-		params["max"] = strconv.Itoa(max)
-		params["sort"] = "id"
-		params["direction"] = sortOrder
-		params["filterType"] = source
-
-		params["offset"] = strconv.Itoa(currentPage * max)
-		resp, err := client.ListVirtualImages(&morpheus.Request{
-			QueryParams: params,
-		})
-		if err != nil {
-			if resp != nil && resp.StatusCode == 404 {
-				log.Printf("API 404: %s - %v", resp, err)
-			} else {
-				log.Printf("API FAILURE: %s - %v", resp, err)
-			}
-		}
-		log.Printf("API RESPONSE: %s", resp)
-
-		result := resp.Result.(*morpheus.ListVirtualImagesResult)
-		images = append(images, *result.VirtualImages...)
-		currentPage++
-	}
-	return images
-}
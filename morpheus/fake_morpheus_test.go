@@ -0,0 +1,627 @@
+package morpheus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// fakeMorpheusAccessToken is the bearer token accepted by the fake Morpheus API
+const fakeMorpheusAccessToken = "fake-morpheus-access-token"
+
+// fakeCollection describes a REST collection emulated by the fake Morpheus API.
+// Objects are stored as decoded JSON and returned under the singular key for
+// single object responses and the plural key for list responses. A path
+// segment of {id} matches the ID of a parent object, the objects of a nested
+// collection are stored separately for each parent.
+type fakeCollection struct {
+	Path     string
+	Singular string
+	Plural   string
+	// Status is assigned to newly created objects that do not specify one,
+	// this lets the provisioning waits of instances and clusters complete.
+	Status string
+}
+
+// fakeMorpheusCollections lists the endpoints used by morpheus.Client that
+// are emulated by the fake Morpheus API.
+var fakeMorpheusCollections = []fakeCollection{
+	{Path: "/api/accounts", Singular: "account", Plural: "accounts"},
+	{Path: "/api/apps", Singular: "app", Plural: "apps", Status: "running"},
+	{Path: "/api/blueprints", Singular: "blueprint", Plural: "blueprints"},
+	{Path: "/api/budgets", Singular: "budget", Plural: "budgets"},
+	{Path: "/api/catalog-item-types", Singular: "catalogItemType", Plural: "catalogItemTypes"},
+	{Path: "/api/clusters", Singular: "cluster", Plural: "clusters", Status: "ok"},
+	{Path: "/api/clusters/{id}/namespaces", Singular: "namespace", Plural: "namespaces"},
+	{Path: "/api/credentials", Singular: "credential", Plural: "credentials"},
+	{Path: "/api/environments", Singular: "environment", Plural: "environments"},
+	{Path: "/api/execute-schedules", Singular: "schedule", Plural: "schedules"},
+	{Path: "/api/groups", Singular: "group", Plural: "groups"},
+	{Path: "/api/instances", Singular: "instance", Plural: "instances", Status: "running"},
+	{Path: "/api/integrations", Singular: "integration", Plural: "integrations"},
+	{Path: "/api/jobs", Singular: "job", Plural: "jobs"},
+	{Path: "/api/key-pairs", Singular: "keyPair", Plural: "keyPairs"},
+	{Path: "/api/library/cluster-layouts", Singular: "layout", Plural: "layouts"},
+	{Path: "/api/library/container-templates", Singular: "containerTemplate", Plural: "containerTemplates"},
+	{Path: "/api/library/instance-types", Singular: "instanceType", Plural: "instanceTypes"},
+	{Path: "/api/library/layouts", Singular: "instanceTypeLayout", Plural: "instanceTypeLayouts"},
+	{Path: "/api/library/option-type-lists", Singular: "optionTypeList", Plural: "optionTypeLists"},
+	{Path: "/api/library/option-types", Singular: "optionType", Plural: "optionTypes"},
+	{Path: "/api/library/spec-templates", Singular: "specTemplate", Plural: "specTemplates"},
+	{Path: "/api/load-balancers", Singular: "loadBalancer", Plural: "loadBalancers"},
+	{Path: "/api/load-balancers/{id}/monitors", Singular: "loadBalancerMonitor", Plural: "loadBalancerMonitors"},
+	{Path: "/api/load-balancers/{id}/pools", Singular: "loadBalancerPool", Plural: "loadBalancerPools"},
+	{Path: "/api/load-balancers/{id}/virtual-servers", Singular: "virtualServer", Plural: "virtualServers"},
+	{Path: "/api/monitoring/contacts", Singular: "contact", Plural: "contacts"},
+	{Path: "/api/networks/domains", Singular: "networkDomain", Plural: "networkDomains"},
+	{Path: "/api/networks/domains/{id}/records", Singular: "networkDomainRecord", Plural: "networkDomainRecords"},
+	{Path: "/api/networks/groups", Singular: "networkGroup", Plural: "networkGroups"},
+	{Path: "/api/networks/pools", Singular: "networkPool", Plural: "networkPools"},
+	{Path: "/api/networks/proxies", Singular: "networkProxy", Plural: "networkProxies"},
+	{Path: "/api/networks", Singular: "network", Plural: "networks"},
+	{Path: "/api/policies", Singular: "policy", Plural: "policies"},
+	{Path: "/api/prices", Singular: "price", Plural: "prices"},
+	{Path: "/api/price-sets", Singular: "priceSet", Plural: "priceSets"},
+	{Path: "/api/roles", Singular: "role", Plural: "roles"},
+	{Path: "/api/service-plans", Singular: "servicePlan", Plural: "servicePlans"},
+	{Path: "/api/snapshots", Singular: "snapshot", Plural: "snapshots"},
+	{Path: "/api/storage-buckets", Singular: "storageBucket", Plural: "storageBuckets"},
+	{Path: "/api/storage-volumes", Singular: "storageVolume", Plural: "storageVolumes", Status: "provisioned"},
+	{Path: "/api/subnets", Singular: "subnet", Plural: "subnets"},
+	{Path: "/api/task-sets", Singular: "taskSet", Plural: "taskSets"},
+	{Path: "/api/tasks", Singular: "task", Plural: "tasks"},
+	{Path: "/api/user-groups", Singular: "userGroup", Plural: "userGroups"},
+	{Path: "/api/users", Singular: "user", Plural: "users"},
+	{Path: "/api/virtual-images", Singular: "virtualImage", Plural: "virtualImages"},
+	{Path: "/api/wiki/pages", Singular: "page", Plural: "pages"},
+	{Path: "/api/zones", Singular: "zone", Plural: "zones"},
+}
+
+// fakeRequest is a request received by the fake Morpheus API
+type fakeRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   map[string]interface{}
+}
+
+// fakeHandler answers a request to a custom route of the fake Morpheus API
+// with a status and a JSON payload. The handler is called with the fake API
+// locked and is passed its store to seed and update objects.
+type fakeHandler func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{})
+
+// fakeStore holds the objects and custom routes of the fake Morpheus API.
+// Its methods do not lock, they are called by fakeMorpheus with its lock held
+// and by custom routes.
+type fakeStore struct {
+	t        *testing.T
+	nextID   int64
+	objects  map[string]map[int64]map[string]interface{}
+	handlers map[string]fakeHandler
+}
+
+// fakeMorpheus is an in-process emulation of the Morpheus REST API backed by
+// an httptest server. It implements generic create, read, list, update and
+// delete semantics for the collections in fakeMorpheusCollections along with
+// the cypher key/value store, which is enough to run acceptance tests without
+// a real appliance.
+type fakeMorpheus struct {
+	fakeStore
+	Server *httptest.Server
+
+	mu       sync.Mutex
+	cypher   map[string]interface{}
+	requests []fakeRequest
+}
+
+// newFakeMorpheus starts a fake Morpheus API that is shut down when the test completes
+func newFakeMorpheus(t *testing.T) *fakeMorpheus {
+	t.Helper()
+	f := &fakeMorpheus{
+		fakeStore: fakeStore{
+			t:        t,
+			nextID:   1,
+			objects:  make(map[string]map[int64]map[string]interface{}),
+			handlers: make(map[string]fakeHandler),
+		},
+		cypher: make(map[string]interface{}),
+	}
+	for _, c := range fakeMorpheusCollections {
+		if !strings.Contains(c.Path, "{id}") {
+			f.objects[c.Path] = make(map[int64]map[string]interface{})
+		}
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Server.Close)
+	return f
+}
+
+// ProviderConfig returns the provider block used to point the provider at the fake API
+func (f *fakeMorpheus) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "morpheus" {
+  url          = %q
+  access_token = %q
+}
+`, f.Server.URL, fakeMorpheusAccessToken)
+}
+
+// Meta configures the provider against the fake API and returns the meta
+// value passed to resources, this is used by tests that call resource
+// operations directly. Long running operations are polled without delay.
+func (f *fakeMorpheus) Meta() interface{} {
+	f.t.Helper()
+	testAccPreCheck(f.t)
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":           f.Server.URL,
+		"access_token":  fakeMorpheusAccessToken,
+		"poll_delay":    "0s",
+		"poll_interval": "10ms",
+	}))
+	if diags.HasError() {
+		f.t.Fatalf("unable to configure the provider: %v", diags)
+	}
+	meta := p.Meta().(*providerMeta)
+	f.t.Cleanup(func() { meta.config.Close() })
+	return meta
+}
+
+// Seed stores an object in a collection and returns its ID, this is used to
+// create the objects looked up by data sources and referenced by resources.
+// The test fails when the path is not one of fakeMorpheusCollections.
+func (f *fakeMorpheus) Seed(path string, object map[string]interface{}) int64 {
+	f.t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fakeStore.Seed(path, object)
+}
+
+// Handle registers a handler for the requests with the method for the path,
+// it takes precedence over the collections and is used to emulate nested
+// endpoints and actions with side effects.
+func (f *fakeMorpheus) Handle(method string, path string, handler fakeHandler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fakeStore.Handle(method, path, handler)
+}
+
+// Update merges fields into a stored object, this is used to simulate
+// changes made outside of Terraform
+func (f *fakeMorpheus) Update(path string, id int64, fields map[string]interface{}) {
+	f.t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fakeStore.Update(path, id, fields)
+}
+
+// Objects returns copies of the objects stored in a collection ordered by ID
+func (f *fakeMorpheus) Objects(path string) []map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fakeStore.Objects(path)
+}
+
+// Count returns the number of objects stored in a collection
+func (f *fakeMorpheus) Count(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fakeStore.Count(path)
+}
+
+// Get returns a copy of a stored object
+func (f *fakeMorpheus) Get(path string, id int64) (map[string]interface{}, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fakeStore.Get(path, id)
+}
+
+// Requests returns the bodies of the requests received with the method for
+// the path, in the order they were received
+func (f *fakeMorpheus) Requests(method string, path string) []map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var bodies []map[string]interface{}
+	for _, r := range f.requests {
+		if r.Method == method && r.Path == path {
+			bodies = append(bodies, copyObject(r.Body))
+		}
+	}
+	return bodies
+}
+
+// Queries returns the query parameters of the requests received with the
+// method for the path, in the order they were received
+func (f *fakeMorpheus) Queries(method string, path string) []url.Values {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var queries []url.Values
+	for _, r := range f.requests {
+		if r.Method == method && r.Path == path {
+			queries = append(queries, r.Query)
+		}
+	}
+	return queries
+}
+
+// Seed stores an object in a collection and returns its ID
+func (s *fakeStore) Seed(path string, object map[string]interface{}) int64 {
+	s.t.Helper()
+	c := s.collection(path)
+	if c == nil {
+		s.t.Fatalf("fake Morpheus API: %s is not a registered collection", path)
+	}
+	return s.insert(c, object)
+}
+
+// Handle registers a handler for the requests with the method for the path
+func (s *fakeStore) Handle(method string, path string, handler fakeHandler) {
+	s.handlers[method+" "+path] = handler
+}
+
+// Update merges fields into a stored object
+func (s *fakeStore) Update(path string, id int64, fields map[string]interface{}) {
+	s.t.Helper()
+	object, ok := s.objects[path][id]
+	if !ok {
+		s.t.Fatalf("fake Morpheus API: %s/%d does not exist", path, id)
+	}
+	mergeObject(object, copyObject(fields))
+}
+
+// Objects returns copies of the objects stored in a collection ordered by ID
+func (s *fakeStore) Objects(path string) []map[string]interface{} {
+	var ids []int64
+	for id := range s.objects[path] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	objects := []map[string]interface{}{}
+	for _, id := range ids {
+		objects = append(objects, copyObject(s.objects[path][id]))
+	}
+	return objects
+}
+
+// Count returns the number of objects stored in a collection
+func (s *fakeStore) Count(path string) int {
+	return len(s.objects[path])
+}
+
+// Get returns a copy of a stored object
+func (s *fakeStore) Get(path string, id int64) (map[string]interface{}, bool) {
+	object, ok := s.objects[path][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(object), true
+}
+
+func (s *fakeStore) collection(path string) *fakeCollection {
+	c := s.match(path)
+	if c == nil || c.Path != path {
+		return nil
+	}
+	return c
+}
+
+// match returns the collection with the longest path that the request path
+// is within, so nested collections such as /api/networks/pools take
+// precedence over /api/networks. The path of a nested collection is
+// returned with the ID of its parent.
+func (s *fakeStore) match(path string) *fakeCollection {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var match *fakeCollection
+	for i := range fakeMorpheusCollections {
+		c := fakeMorpheusCollections[i]
+		collectionSegments := strings.Split(strings.Trim(c.Path, "/"), "/")
+		if len(segments) < len(collectionSegments) {
+			continue
+		}
+		matches := true
+		for j, segment := range collectionSegments {
+			if segment == "{id}" {
+				if _, err := strconv.ParseInt(segments[j], 10, 64); err != nil {
+					matches = false
+				}
+			} else if segment != segments[j] {
+				matches = false
+			}
+		}
+		if !matches {
+			continue
+		}
+		c.Path = "/" + strings.Join(segments[:len(collectionSegments)], "/")
+		if match == nil || len(c.Path) > len(match.Path) {
+			match = &c
+		}
+	}
+	return match
+}
+
+func (s *fakeStore) insert(c *fakeCollection, object map[string]interface{}) int64 {
+	id := s.nextID
+	s.nextID++
+	if s.objects[c.Path] == nil {
+		s.objects[c.Path] = make(map[int64]map[string]interface{})
+	}
+	stored := copyObject(object)
+	stored["id"] = id
+	if _, ok := stored["status"]; !ok && c.Status != "" {
+		stored["status"] = c.Status
+	}
+	s.objects[c.Path][id] = stored
+	return id
+}
+
+func (f *fakeMorpheus) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+fakeMorpheusAccessToken {
+		writeFakeResponse(w, http.StatusUnauthorized, map[string]interface{}{
+			"success": false,
+			"msg":     "Unauthorized",
+		})
+		return
+	}
+
+	// The body is recorded and then replaced so the handlers can decode it
+	data, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	request := fakeRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: make(map[string]interface{})}
+	json.Unmarshal(data, &request.Body)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, request)
+
+	if handler := f.handlers[r.Method+" "+r.URL.Path]; handler != nil {
+		status, payload := handler(&f.fakeStore, copyObject(request.Body))
+		writeFakeResponse(w, status, payload)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/cypher/") {
+		f.handleCypher(w, r, strings.TrimPrefix(r.URL.Path, "/api/cypher/"))
+		return
+	}
+
+	match := f.match(r.URL.Path)
+	if match == nil {
+		writeFakeNotFound(w)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, match.Path), "/"), "/")
+	if segments[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			f.list(w, r, match)
+		case http.MethodPost:
+			f.create(w, r, match)
+		default:
+			writeFakeResponse(w, http.StatusMethodNotAllowed, map[string]interface{}{"success": false})
+		}
+		return
+	}
+
+	id, err := strconv.ParseInt(segments[0], 10, 64)
+	if err != nil {
+		writeFakeNotFound(w)
+		return
+	}
+	object, ok := f.objects[match.Path][id]
+	if !ok {
+		writeFakeNotFound(w)
+		return
+	}
+
+	// Actions such as /api/instances/1/resize are acknowledged and the
+	// request payload is merged into the object
+	if len(segments) > 1 {
+		body := decodeFakeBody(r)
+		if payload, ok := body[match.Singular].(map[string]interface{}); ok {
+			mergeObject(object, payload)
+		}
+		writeFakeResponse(w, http.StatusOK, map[string]interface{}{
+			"success":      true,
+			match.Singular: copyObject(object),
+		})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeResponse(w, http.StatusOK, map[string]interface{}{
+			match.Singular: copyObject(object),
+		})
+	case http.MethodPut:
+		body := decodeFakeBody(r)
+		if payload, ok := body[match.Singular].(map[string]interface{}); ok {
+			mergeObject(object, payload)
+		}
+		writeFakeResponse(w, http.StatusOK, map[string]interface{}{
+			"success":      true,
+			match.Singular: copyObject(object),
+		})
+	case http.MethodDelete:
+		delete(f.objects[match.Path], id)
+		writeFakeResponse(w, http.StatusOK, map[string]interface{}{"success": true})
+	default:
+		writeFakeResponse(w, http.StatusMethodNotAllowed, map[string]interface{}{"success": false})
+	}
+}
+
+func (f *fakeMorpheus) create(w http.ResponseWriter, r *http.Request, c *fakeCollection) {
+	body := decodeFakeBody(r)
+	payload, ok := body[c.Singular].(map[string]interface{})
+	if !ok {
+		// Some endpoints such as instances and clusters accept additional
+		// top level properties alongside the object payload
+		payload = body
+	}
+	id := f.insert(c, payload)
+	writeFakeResponse(w, http.StatusOK, map[string]interface{}{
+		"success":  true,
+		c.Singular: copyObject(f.objects[c.Path][id]),
+	})
+}
+
+// list implements the name and phrase filters along with offset/max paging.
+// Any other query parameter filters on the field of the same name, a
+// parameter such as poolServerId filters on the id of the poolServer field.
+func (f *fakeMorpheus) list(w http.ResponseWriter, r *http.Request, c *fakeCollection) {
+	query := r.URL.Query()
+	var ids []int64
+	for id := range f.objects[c.Path] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var matches []interface{}
+	for _, id := range ids {
+		object := f.objects[c.Path][id]
+		name, _ := object["name"].(string)
+		if query.Get("name") != "" && name != query.Get("name") {
+			continue
+		}
+		if query.Get("phrase") != "" && !strings.Contains(name, query.Get("phrase")) {
+			continue
+		}
+		if !matchFakeFilters(object, query) {
+			continue
+		}
+		matches = append(matches, copyObject(object))
+	}
+
+	total := len(matches)
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	max, err := strconv.Atoi(query.Get("max"))
+	if err != nil || max <= 0 {
+		max = 25
+	}
+	if offset > total {
+		offset = total
+	}
+	end := offset + max
+	if end > total {
+		end = total
+	}
+	page := matches[offset:end]
+	if page == nil {
+		page = []interface{}{}
+	}
+	writeFakeResponse(w, http.StatusOK, map[string]interface{}{
+		c.Plural: page,
+		"meta": map[string]interface{}{
+			"size":   len(page),
+			"total":  total,
+			"offset": offset,
+			"max":    max,
+		},
+	})
+}
+
+// matchFakeFilters reports whether an object has the values of the field
+// filters in a list query
+func matchFakeFilters(object map[string]interface{}, query url.Values) bool {
+	for param := range query {
+		switch param {
+		case "name", "phrase", "max", "offset", "sort", "direction":
+			continue
+		}
+		value, ok := object[param]
+		if !ok && strings.HasSuffix(param, "Id") {
+			if ref, isMap := object[strings.TrimSuffix(param, "Id")].(map[string]interface{}); isMap {
+				value, ok = ref["id"]
+			}
+		}
+		if !ok || fmt.Sprint(value) != query.Get(param) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeMorpheus) handleCypher(w http.ResponseWriter, r *http.Request, key string) {
+	switch r.Method {
+	case http.MethodGet:
+		value, ok := f.cypher[key]
+		if !ok {
+			writeFakeNotFound(w)
+			return
+		}
+		writeFakeResponse(w, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"data":    value,
+			"type":    "string",
+			"cypher": map[string]interface{}{
+				"itemKey": key,
+			},
+		})
+	case http.MethodPost, http.MethodPut:
+		body := decodeFakeBody(r)
+		f.cypher[key] = body["value"]
+		writeFakeResponse(w, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"data":    body["value"],
+			"cypher": map[string]interface{}{
+				"itemKey": key,
+			},
+		})
+	case http.MethodDelete:
+		delete(f.cypher, key)
+		writeFakeResponse(w, http.StatusOK, map[string]interface{}{"success": true})
+	default:
+		writeFakeResponse(w, http.StatusMethodNotAllowed, map[string]interface{}{"success": false})
+	}
+}
+
+func decodeFakeBody(r *http.Request) map[string]interface{} {
+	body := make(map[string]interface{})
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+	return body
+}
+
+func writeFakeNotFound(w http.ResponseWriter) {
+	writeFakeResponse(w, http.StatusNotFound, map[string]interface{}{
+		"success": false,
+		"msg":     "Not Found",
+	})
+}
+
+func writeFakeResponse(w http.ResponseWriter, status int, payload map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+// copyObject returns a deep copy of a decoded JSON object
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(object)
+	copied := make(map[string]interface{})
+	json.Unmarshal(data, &copied)
+	return copied
+}
+
+// mergeObject applies an update payload to a stored object
+func mergeObject(object map[string]interface{}, payload map[string]interface{}) {
+	for k, v := range payload {
+		if k == "id" {
+			continue
+		}
+		object[k] = v
+	}
+}
@@ -0,0 +1,103 @@
+package morpheus
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// instancePowerStates are the statuses that can be requested with the
+// power_state argument of the instance resources
+var instancePowerStates = []string{statusRunning, statusStopped, statusSuspended}
+
+// powerStateSchema is shared by the instance resources. It is computed so
+// that an instance powered on or off outside of terraform shows up as drift.
+func powerStateSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:  "The power state of the instance (running, stopped, suspended)",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice(instancePowerStates, false),
+	}
+}
+
+// instancePowerState returns the power state for an instance status, or an
+// empty string when the instance is in a transitional or failed state
+func instancePowerState(status string) string {
+	for _, powerState := range instancePowerStates {
+		if status == powerState {
+			return powerState
+		}
+	}
+	return ""
+}
+
+// setInstancePowerState starts, stops or suspends the instance so that it
+// matches power_state and waits for the instance to reach that state
+func setInstancePowerState(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := toInt64(d.Id())
+
+	powerState := d.Get("power_state").(string)
+	if powerState == "" {
+		return nil
+	}
+
+	resp, err := client.GetInstance(id, &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	status := resp.Result.(*morpheus.GetInstanceResult).Instance.Status
+	if status == powerState {
+		return nil
+	}
+
+	switch powerState {
+	case statusRunning:
+		resp, err = client.StartInstance(id, &morpheus.Request{})
+	case statusStopped:
+		resp, err = client.StopInstance(id, &morpheus.Request{})
+	case statusSuspended:
+		resp, err = client.SuspendInstance(id, &morpheus.Request{})
+	default:
+		return diag.Errorf("unsupported power state %s", powerState)
+	}
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{status, statusStarting, statusStopping, statusSuspending, statusPending},
+		Target:  []string{powerState},
+		Refresh: func() (interface{}, string, error) {
+			instanceDetails, err := client.GetInstance(id, &morpheus.Request{})
+			if err != nil {
+				return "", "", err
+			}
+			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
+			instance := result.Instance
+			return result, instance.Status, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error waiting for instance %d to be %s: %s", id, powerState, err)
+	}
+	return nil
+}
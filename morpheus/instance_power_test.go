@@ -0,0 +1,141 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// handleFakeInstancePower emulates the start, stop and suspend actions of an
+// instance. An action moves the instance to a transitional status and the
+// instance reaches the final status when it is next fetched, unless complete
+// is false in which case it stays in the transitional status.
+func handleFakeInstancePower(api *fakeMorpheus, instanceId int64, complete bool) {
+	path := "/api/instances/" + int64ToString(instanceId)
+	transitions := map[string][2]string{
+		"start":   {statusStarting, statusRunning},
+		"stop":    {statusStopping, statusStopped},
+		"suspend": {statusSuspending, statusSuspended},
+	}
+
+	final := ""
+	for action, statuses := range transitions {
+		statuses := statuses
+		api.Handle(http.MethodPut, path+"/"+action, func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+			s.Update("/api/instances", instanceId, map[string]interface{}{"status": statuses[0]})
+			if complete {
+				final = statuses[1]
+			}
+			return http.StatusOK, map[string]interface{}{"success": true}
+		})
+	}
+	api.Handle(http.MethodGet, path, func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		instance, _ := s.Get("/api/instances", instanceId)
+		if final != "" {
+			s.Update("/api/instances", instanceId, map[string]interface{}{"status": final})
+			final = ""
+		}
+		return http.StatusOK, map[string]interface{}{"instance": instance}
+	})
+}
+
+// testInstancePowerData returns the resource data of an instance with the
+// power_state argument
+func testInstancePowerData(t *testing.T, instanceId int64, powerState string) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{
+		"power_state": powerState,
+	})
+	d.SetId(int64ToString(instanceId))
+	return d
+}
+
+func TestSetInstancePowerState(t *testing.T) {
+	cases := []struct {
+		status     string
+		powerState string
+		action     string
+	}{
+		{statusStopped, statusRunning, "start"},
+		{statusSuspended, statusRunning, "start"},
+		{statusRunning, statusStopped, "stop"},
+		{statusRunning, statusSuspended, "suspend"},
+		{statusRunning, statusRunning, ""},
+		{statusStopped, "", ""},
+	}
+	for _, c := range cases {
+		api := newFakeMorpheus(t)
+		instanceId := api.Seed("/api/instances", map[string]interface{}{"name": "tfinstance", "status": c.status})
+		handleFakeInstancePower(api, instanceId, true)
+		d := testInstancePowerData(t, instanceId, c.powerState)
+
+		if diags := setInstancePowerState(context.Background(), d, api.Meta(), time.Minute); diags.HasError() {
+			t.Fatalf("%s to %q: unexpected error: %v", c.status, c.powerState, diags)
+		}
+
+		path := "/api/instances/" + int64ToString(instanceId)
+		for _, action := range []string{"start", "stop", "suspend"} {
+			expected := 0
+			if action == c.action {
+				expected = 1
+			}
+			if requests := api.Requests(http.MethodPut, path+"/"+action); len(requests) != expected {
+				t.Errorf("%s to %q: expected %d %s requests, got %d", c.status, c.powerState, expected, action, len(requests))
+			}
+		}
+
+		expected := c.powerState
+		if expected == "" {
+			expected = c.status
+		}
+		instance, _ := api.Get("/api/instances", instanceId)
+		if instance["status"] != expected {
+			t.Errorf("%s to %q: expected the instance to be %s, got %v", c.status, c.powerState, expected, instance["status"])
+		}
+	}
+}
+
+func TestSetInstancePowerState_unsupported(t *testing.T) {
+	api := newFakeMorpheus(t)
+	instanceId := api.Seed("/api/instances", map[string]interface{}{"name": "tfinstance", "status": statusRunning})
+	handleFakeInstancePower(api, instanceId, true)
+	d := testInstancePowerData(t, instanceId, "hibernated")
+
+	diags := setInstancePowerState(context.Background(), d, api.Meta(), time.Minute)
+	if !diags.HasError() {
+		t.Fatal("expected an error for an unsupported power state")
+	}
+	if expected := "unsupported power state hibernated"; diags[0].Summary != expected {
+		t.Errorf("expected the error %q, got %q", expected, diags[0].Summary)
+	}
+	path := "/api/instances/" + int64ToString(instanceId)
+	for _, action := range []string{"start", "stop", "suspend"} {
+		if requests := api.Requests(http.MethodPut, path+"/"+action); len(requests) != 0 {
+			t.Errorf("expected no %s requests, got %d", action, len(requests))
+		}
+	}
+}
+
+func TestSetInstancePowerState_timeout(t *testing.T) {
+	api := newFakeMorpheus(t)
+	instanceId := api.Seed("/api/instances", map[string]interface{}{"name": "tfinstance", "status": statusRunning})
+	handleFakeInstancePower(api, instanceId, false)
+	d := testInstancePowerData(t, instanceId, statusStopped)
+
+	diags := setInstancePowerState(context.Background(), d, api.Meta(), 100*time.Millisecond)
+	if !diags.HasError() {
+		t.Fatal("expected an error when the instance does not stop")
+	}
+	expected := "error waiting for instance " + int64ToString(instanceId) + " to be stopped: timeout"
+	if !strings.HasPrefix(diags[0].Summary, expected) {
+		t.Errorf("expected the error to start with %q, got %q", expected, diags[0].Summary)
+	}
+	instance, _ := api.Get("/api/instances", instanceId)
+	if instance["status"] != statusStopping {
+		t.Errorf("expected the instance to still be stopping, got %v", instance["status"])
+	}
+}
@@ -0,0 +1,241 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// instanceResizeForceNew forces a new instance when a change to volumes or
+// interfaces cannot be made by the resize API. Volumes can be added or grown
+// and interfaces can be added, but removing either or shrinking a volume
+// requires the instance to be replaced.
+func instanceResizeForceNew(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("volumes") {
+		oldVolumes, newVolumes := d.GetChange("volumes")
+		if len(newVolumes.([]interface{})) < len(oldVolumes.([]interface{})) {
+			if err := d.ForceNew("volumes"); err != nil {
+				return err
+			}
+		} else {
+			// ForceNew on a list of blocks only applies to the number of
+			// blocks, so the size of a shrunk volume is forced instead
+			for i := range oldVolumes.([]interface{}) {
+				if volumeShrunk(oldVolumes.([]interface{})[i], newVolumes.([]interface{})[i]) {
+					if err := d.ForceNew(fmt.Sprintf("volumes.%d.size", i)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if d.HasChange("interfaces") {
+		oldInterfaces, newInterfaces := d.GetChange("interfaces")
+		if len(newInterfaces.([]interface{})) < len(oldInterfaces.([]interface{})) {
+			if err := d.ForceNew("interfaces"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// volumeShrunk reports whether the size of a volume was reduced, a volume
+// without a size takes it from the plan and is never considered shrunk
+func volumeShrunk(oldVolume interface{}, newVolume interface{}) bool {
+	oldSize := oldVolume.(map[string]interface{})["size"].(int)
+	newSize := newVolume.(map[string]interface{})["size"].(int)
+	return newSize != 0 && newSize < oldSize
+}
+
+// instanceResizeStartTimeout is how long to wait for an instance to report
+// that a resize has started. A resize that completes between two polls never
+// reports it, such an instance is recognised by instanceResized and this
+// timeout only applies when the API reports neither.
+var instanceResizeStartTimeout = 2 * time.Minute
+
+// instanceResized reports whether the plan, volumes and interfaces of the
+// instance already match those requested by a resize. Volumes are compared
+// in display order and a volume without a size takes it from the plan.
+func instanceResized(d *schema.ResourceData, instance *morpheus.Instance, volumes []map[string]interface{}, interfaces []map[string]interface{}) bool {
+	if instance.Plan.ID != int64(d.Get("plan_id").(int)) {
+		return false
+	}
+	if len(instance.Volumes) < len(volumes) || len(instance.Interfaces) < len(interfaces) {
+		return false
+	}
+	existingVolumes := instance.Volumes
+	sort.SliceStable(existingVolumes, func(i, j int) bool {
+		return existingVolumes[i].DisplayOrder < existingVolumes[j].DisplayOrder
+	})
+	for i, volume := range volumes {
+		size, _ := volume["size"].(int)
+		if size != 0 && volumeSize(existingVolumes[i].Size) < int64(size) {
+			return false
+		}
+	}
+	return true
+}
+
+// volumeSize returns the size of a volume returned by the API, which is a
+// number or a numeric string
+func volumeSize(value interface{}) int64 {
+	switch v := value.(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case string:
+		return stringToInt64(v)
+	}
+	return 0
+}
+
+// instanceResizePayload builds the body of a resize request for the plan,
+// volume and interface changes of an instance. Volumes and interfaces are
+// matched to those already on the instance by position, anything beyond the
+// existing volumes and interfaces is added. It returns nil when there is
+// nothing to resize.
+func instanceResizePayload(d *schema.ResourceData, instance *morpheus.Instance, volumes []map[string]interface{}, interfaces []map[string]interface{}) map[string]interface{} {
+	if !d.HasChanges("plan_id", "volumes", "interfaces") {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"instance": map[string]interface{}{
+			"plan": map[string]interface{}{
+				"id": d.Get("plan_id"),
+			},
+		},
+		"deleteOriginalVolumes": false,
+	}
+
+	if d.HasChange("volumes") {
+		existingVolumes := instance.Volumes
+		sort.SliceStable(existingVolumes, func(i, j int) bool {
+			return existingVolumes[i].DisplayOrder < existingVolumes[j].DisplayOrder
+		})
+		for i, volume := range volumes {
+			if i < len(existingVolumes) {
+				volume["id"] = existingVolumes[i].ID
+			} else {
+				volume["id"] = -1
+			}
+		}
+		payload["volumes"] = volumes
+	}
+
+	if d.HasChange("interfaces") {
+		for i, networkInterface := range interfaces {
+			if i < len(instance.Interfaces) {
+				networkInterface["id"] = instance.Interfaces[i].ID
+			}
+		}
+		payload["networkInterfaces"] = interfaces
+	}
+
+	return payload
+}
+
+// resizeInstance applies the plan, volume and interface changes of an
+// instance with a resize request and waits for the instance to finish
+// resizing. The instance is fetched first so that the volumes and interfaces
+// are matched against those currently attached to it.
+func resizeInstance(ctx context.Context, d *schema.ResourceData, meta interface{}, volumes []map[string]interface{}, interfaces []map[string]interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	if !d.HasChanges("plan_id", "volumes", "interfaces") {
+		return nil
+	}
+
+	resp, err := client.GetInstance(toInt64(id), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	instance := resp.Result.(*morpheus.GetInstanceResult).Instance
+
+	payload := instanceResizePayload(d, instance, volumes, interfaces)
+	resp, err = client.ResizeInstance(toInt64(id), &morpheus.Request{Body: payload})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	refresh := func() (interface{}, string, error) {
+		instanceDetails, err := client.GetInstance(toInt64(id), &morpheus.Request{})
+		if err != nil {
+			return "", "", err
+		}
+		result := instanceDetails.Result.(*morpheus.GetInstanceResult)
+		instance := result.Instance
+		return result, instance.Status, nil
+	}
+	pollConfig := meta.(*providerMeta).config
+
+	// The instance keeps its previous status until the resize starts, so
+	// wait for it to change before waiting for the resize to finish. An
+	// instance that already has the new plan, volumes and interfaces has
+	// finished resizing without the API reporting it.
+	startConf := &resource.StateChangeConf{
+		Pending: []string{"running", "stopped", "suspended"},
+		Target:  []string{"resizing", "pending", "failed", "warning", "resized"},
+		Refresh: func() (interface{}, string, error) {
+			result, status, err := refresh()
+			if err != nil {
+				return result, status, err
+			}
+			instance := result.(*morpheus.GetInstanceResult).Instance
+			if (status == "running" || status == "stopped" || status == "suspended") && instanceResized(d, instance, volumes, interfaces) {
+				return result, "resized", nil
+			}
+			return result, status, nil
+		},
+		Timeout:      instanceResizeStartTimeout,
+		MinTimeout:   1 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+	started, err := startConf.WaitForStateContext(ctx)
+	if err != nil {
+		if _, ok := err.(*resource.TimeoutError); !ok {
+			return diag.Errorf("error resizing instance: %s", err)
+		}
+		log.Printf("[WARN] instance %s did not report a resizing status, the resize may have already completed", id)
+	} else if status := started.(*morpheus.GetInstanceResult).Instance.Status; status == "running" || status == "stopped" || status == "suspended" {
+		// Only an instance that has already been resized is a target with
+		// one of these statuses
+		return nil
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"resizing", "pending"},
+		Target:       []string{"running", "stopped", "suspended"},
+		Refresh:      refresh,
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		MinTimeout:   1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error resizing instance: %s", err)
+	}
+	return nil
+}
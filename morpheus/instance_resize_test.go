@@ -0,0 +1,216 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testInstanceResizeDiff plans a change of the volumes and interfaces of an
+// existing morpheus_aws_instance and returns the attributes that force a new
+// instance
+func testInstanceResizeDiff(t *testing.T, oldValues map[string]interface{}, newValues map[string]interface{}) []string {
+	t.Helper()
+	r := resourceAwsInstance()
+	config := func(values map[string]interface{}) map[string]interface{} {
+		raw := map[string]interface{}{
+			"name":               "tfinstance",
+			"cloud_id":           1,
+			"group_id":           1,
+			"instance_type_id":   1,
+			"instance_layout_id": 1,
+			"plan_id":            1,
+			"security_group_ids": []interface{}{"sg-1"},
+		}
+		for k, v := range values {
+			raw[k] = v
+		}
+		return raw
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config(oldValues))
+	d.SetId("1")
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config(newValues)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var forceNew []string
+	if diff != nil {
+		for k, attr := range diff.Attributes {
+			if attr.RequiresNew && (strings.HasPrefix(k, "volumes") || strings.HasPrefix(k, "interfaces")) {
+				forceNew = append(forceNew, k)
+			}
+		}
+	}
+	return forceNew
+}
+
+func TestInstanceResizeForceNew(t *testing.T) {
+	volumes := func(sizes ...int) []interface{} {
+		var v []interface{}
+		for _, size := range sizes {
+			v = append(v, map[string]interface{}{"name": "disk", "size": size})
+		}
+		return v
+	}
+	interfaces := func(networkIds ...int) []interface{} {
+		var v []interface{}
+		for _, networkId := range networkIds {
+			v = append(v, map[string]interface{}{"network_id": networkId})
+		}
+		return v
+	}
+
+	cases := []struct {
+		name      string
+		oldValues map[string]interface{}
+		newValues map[string]interface{}
+		forceNew  bool
+	}{
+		{"grow volume", map[string]interface{}{"volumes": volumes(20)}, map[string]interface{}{"volumes": volumes(40)}, false},
+		{"add volume", map[string]interface{}{"volumes": volumes(20)}, map[string]interface{}{"volumes": volumes(20, 10)}, false},
+		{"shrink volume", map[string]interface{}{"volumes": volumes(40)}, map[string]interface{}{"volumes": volumes(20)}, true},
+		{"shrink second volume", map[string]interface{}{"volumes": volumes(20, 40)}, map[string]interface{}{"volumes": volumes(20, 30)}, true},
+		{"volume size from plan", map[string]interface{}{"volumes": volumes(20)}, map[string]interface{}{"volumes": volumes(0)}, false},
+		{"remove volume", map[string]interface{}{"volumes": volumes(20, 10)}, map[string]interface{}{"volumes": volumes(20)}, true},
+		{"add interface", map[string]interface{}{"interfaces": interfaces(1)}, map[string]interface{}{"interfaces": interfaces(1, 2)}, false},
+		{"remove interface", map[string]interface{}{"interfaces": interfaces(1, 2)}, map[string]interface{}{"interfaces": interfaces(1)}, true},
+	}
+	for _, c := range cases {
+		forceNew := testInstanceResizeDiff(t, c.oldValues, c.newValues)
+		if (len(forceNew) > 0) != c.forceNew {
+			t.Errorf("%s: expected force new %t, got the attributes %v", c.name, c.forceNew, forceNew)
+		}
+	}
+}
+
+func TestResizeInstance(t *testing.T) {
+	instanceResizeStartTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { instanceResizeStartTimeout = 2 * time.Minute })
+
+	api := newFakeMorpheus(t)
+	instanceId := api.Seed("/api/instances", map[string]interface{}{
+		"name": "tfinstance",
+		"volumes": []interface{}{
+			map[string]interface{}{"id": 12, "name": "data", "displayOrder": 1},
+			map[string]interface{}{"id": 11, "name": "root", "displayOrder": 0},
+		},
+	})
+
+	r := resourceAwsInstance()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"plan_id": 1,
+		"volumes": []interface{}{
+			map[string]interface{}{"name": "root", "size": 20},
+		},
+	})
+	d.SetId(int64ToString(instanceId))
+	d.MarkNewResource()
+
+	volumes := []map[string]interface{}{
+		{"name": "root", "size": 20},
+		{"name": "data", "size": 40},
+		{"name": "logs", "size": 10},
+	}
+	if diags := resizeInstance(context.Background(), d, api.Meta(), volumes, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests("PUT", "/api/instances/"+int64ToString(instanceId)+"/resize")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 resize request, got %d", len(requests))
+	}
+	// The volumes are matched to those of the instance fetched from the API
+	// in display order, any extra volume is added
+	sent := requests[0]["volumes"].([]interface{})
+	for i, expected := range []float64{11, 12, -1} {
+		if id := sent[i].(map[string]interface{})["id"]; id != expected {
+			t.Errorf("volume %d: expected id %v, got %v", i, expected, id)
+		}
+	}
+}
+
+func TestResizeInstance_alreadyResized(t *testing.T) {
+	api := newFakeMorpheus(t)
+	instanceId := api.Seed("/api/instances", map[string]interface{}{
+		"name": "tfinstance",
+		"plan": map[string]interface{}{"id": 1},
+		"volumes": []interface{}{
+			map[string]interface{}{"id": 11, "name": "root", "displayOrder": 0, "size": 20},
+		},
+	})
+	// The resize completes before the instance is next fetched, so the API
+	// never reports the instance as resizing
+	api.Handle("PUT", "/api/instances/"+int64ToString(instanceId)+"/resize", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		s.Update("/api/instances", instanceId, map[string]interface{}{
+			"plan": body["instance"].(map[string]interface{})["plan"],
+			"volumes": []interface{}{
+				map[string]interface{}{"id": 11, "name": "root", "displayOrder": 0, "size": 40},
+			},
+		})
+		return http.StatusOK, map[string]interface{}{"success": true}
+	})
+
+	r := resourceAwsInstance()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"plan_id": 2,
+		"volumes": []interface{}{
+			map[string]interface{}{"name": "root", "size": 40},
+		},
+	})
+	d.SetId(int64ToString(instanceId))
+	d.MarkNewResource()
+
+	start := time.Now()
+	volumes := []map[string]interface{}{{"name": "root", "size": 40}}
+	if diags := resizeInstance(context.Background(), d, api.Meta(), volumes, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the resize to return once the instance was resized, took %s", elapsed)
+	}
+}
+
+func TestInstanceResized(t *testing.T) {
+	r := resourceAwsInstance()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"plan_id": 2})
+	volumes := []map[string]interface{}{{"name": "root", "size": 20}, {"name": "data", "size": 40}}
+	interfaces := []map[string]interface{}{{"network_id": 1}}
+
+	cases := []struct {
+		name     string
+		instance string
+		expected bool
+	}{
+		{"resized", `{"plan": {"id": 2}, "volumes": [{"size": 40, "displayOrder": 1}, {"size": 20, "displayOrder": 0}], "interfaces": [{"id": 1}]}`, true},
+		{"size as string", `{"plan": {"id": 2}, "volumes": [{"size": "20", "displayOrder": 0}, {"size": "40", "displayOrder": 1}], "interfaces": [{"id": 1}]}`, true},
+		{"previous plan", `{"plan": {"id": 1}, "volumes": [{"size": 20, "displayOrder": 0}, {"size": 40, "displayOrder": 1}], "interfaces": [{"id": 1}]}`, false},
+		{"volume not grown", `{"plan": {"id": 2}, "volumes": [{"size": 20, "displayOrder": 0}, {"size": 30, "displayOrder": 1}], "interfaces": [{"id": 1}]}`, false},
+		{"volume not added", `{"plan": {"id": 2}, "volumes": [{"size": 20, "displayOrder": 0}], "interfaces": [{"id": 1}]}`, false},
+		{"interface not added", `{"plan": {"id": 2}, "volumes": [{"size": 20, "displayOrder": 0}, {"size": 40, "displayOrder": 1}]}`, false},
+	}
+	for _, c := range cases {
+		var instance morpheus.Instance
+		if err := json.Unmarshal([]byte(c.instance), &instance); err != nil {
+			t.Fatalf("%s: %s", c.name, err)
+		}
+		if resized := instanceResized(d, &instance, volumes, interfaces); resized != c.expected {
+			t.Errorf("%s: expected %t, got %t", c.name, c.expected, resized)
+		}
+	}
+
+	// A volume without a size takes it from the plan
+	var instance morpheus.Instance
+	json.Unmarshal([]byte(`{"plan": {"id": 2}, "volumes": [{"size": 10, "displayOrder": 0}]}`), &instance)
+	if !instanceResized(d, &instance, []map[string]interface{}{{"name": "root", "size": 0}}, nil) {
+		t.Errorf("expected a volume without a size to match any size")
+	}
+}
@@ -0,0 +1,151 @@
+package morpheus
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+)
+
+// getNetworkIntegration fetches an IPAM or DNS integration. The integration
+// is read into a local type as the SDK integration does not include the
+// network and zone filter settings.
+func getNetworkIntegration(client *morpheus.Client, id string) (*NetworkIntegration, *morpheus.Response, error) {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/integrations/%s", id),
+		Result: &GetNetworkIntegrationResult{},
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetNetworkIntegrationResult)
+	if result.Integration == nil {
+		return nil, resp, fmt.Errorf("integration not found in response data") // should not happen
+	}
+	return result.Integration, resp, nil
+}
+
+// networkIntegrationPools returns the IP pools discovered by an IPAM
+// integration. The appliance filters the pools on their pool server, which
+// is the integration, and the poolServer of each pool is checked as well so
+// that an appliance ignoring the filter does not list every pool.
+func networkIntegrationPools(client *morpheus.Client, integrationId int64) ([]map[string]interface{}, error) {
+	pools, resp, err := listAllPages(func(req *morpheus.Request) (*morpheus.Response, error) {
+		req.Method = "GET"
+		req.Path = "/api/networks/pools"
+		req.Result = &ListNetworkIntegrationPoolsResult{}
+		return client.Execute(req)
+	}, map[string]string{
+		"poolServerId": int64ToString(integrationId),
+	}, func(resp *morpheus.Response) (*[]NetworkIntegrationPool, *morpheus.MetaResult) {
+		result := resp.Result.(*ListNetworkIntegrationPoolsResult)
+		return result.NetworkPools, result.Meta
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+
+	var discovered []map[string]interface{}
+	for _, pool := range pools {
+		if pool.PoolServer.ID != integrationId {
+			continue
+		}
+		discovered = append(discovered, map[string]interface{}{
+			"id":   pool.ID,
+			"name": pool.Name,
+			"cidr": pool.Cidr,
+		})
+	}
+	return discovered, nil
+}
+
+// networkIntegrationZones returns the DNS zones discovered by an IPAM or DNS
+// integration, which Morpheus stores as network domains referencing the
+// integration with a refType of AccountIntegration. The domains are filtered
+// by the appliance and checked again here like the pools.
+func networkIntegrationZones(client *morpheus.Client, integrationId int64) ([]map[string]interface{}, error) {
+	domains, resp, err := listAllPages(func(req *morpheus.Request) (*morpheus.Response, error) {
+		req.Method = "GET"
+		req.Path = "/api/networks/domains"
+		req.Result = &ListNetworkIntegrationZonesResult{}
+		return client.Execute(req)
+	}, map[string]string{
+		"refType": "AccountIntegration",
+		"refId":   int64ToString(integrationId),
+	}, func(resp *morpheus.Response) (*[]NetworkIntegrationZone, *morpheus.MetaResult) {
+		result := resp.Result.(*ListNetworkIntegrationZonesResult)
+		return result.NetworkDomains, result.Meta
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+
+	var discovered []map[string]interface{}
+	for _, domain := range domains {
+		if domain.RefType != "AccountIntegration" || domain.RefId != integrationId {
+			continue
+		}
+		discovered = append(discovered, map[string]interface{}{
+			"id":   domain.ID,
+			"name": domain.Name,
+		})
+	}
+	return discovered, nil
+}
+
+type NetworkIntegration struct {
+	ID                  int64  `json:"id"`
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	Enabled             bool   `json:"enabled"`
+	ServiceUrl          string `json:"serviceUrl"`
+	ServiceUsername     string `json:"serviceUsername"`
+	ServicePasswordHash string `json:"servicePasswordHash"`
+	ServiceTokenHash    string `json:"serviceTokenHash"`
+	NetworkFilter       string `json:"networkFilter"`
+	ZoneFilter          string `json:"zoneFilter"`
+	TenantMatch         string `json:"tenantMatch"`
+	Credential          struct {
+		ID int64 `json:"id"`
+	} `json:"credential"`
+	Config struct {
+		ExtraAttributes   string `json:"extraAttributes"`
+		InventoryExisting bool   `json:"inventoryExisting"`
+		CreatePointer     bool   `json:"createPointer"`
+	} `json:"config"`
+}
+
+type GetNetworkIntegrationResult struct {
+	Integration *NetworkIntegration `json:"integration"`
+}
+
+type NetworkIntegrationPool struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Cidr       string `json:"cidr"`
+	PoolServer struct {
+		ID int64 `json:"id"`
+	} `json:"poolServer"`
+}
+
+type ListNetworkIntegrationPoolsResult struct {
+	NetworkPools *[]NetworkIntegrationPool `json:"networkPools"`
+	Meta         *morpheus.MetaResult      `json:"meta"`
+}
+
+type NetworkIntegrationZone struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	RefType string `json:"refType"`
+	RefId   int64  `json:"refId"`
+}
+
+type ListNetworkIntegrationZonesResult struct {
+	NetworkDomains *[]NetworkIntegrationZone `json:"networkDomains"`
+	Meta           *morpheus.MetaResult      `json:"meta"`
+}
@@ -0,0 +1,165 @@
+package morpheus
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// testNetworkIntegrationAPI seeds an Infoblox integration along with pools
+// and network domains that belong to it and to other integrations and clouds
+func testNetworkIntegrationAPI(t *testing.T) (*fakeMorpheus, int64) {
+	t.Helper()
+	api := newFakeMorpheus(t)
+	integrationId := api.Seed("/api/integrations", map[string]interface{}{
+		"name": "tfinfoblox",
+		"type": "infoblox",
+	})
+	otherId := api.Seed("/api/integrations", map[string]interface{}{
+		"name": "tfbluecat",
+		"type": "bluecat",
+	})
+
+	api.Seed("/api/networks/pools", map[string]interface{}{
+		"name":       "pool-a",
+		"cidr":       "10.0.0.0/24",
+		"poolServer": map[string]interface{}{"id": integrationId, "name": "tfinfoblox"},
+	})
+	api.Seed("/api/networks/pools", map[string]interface{}{
+		"name":       "pool-other",
+		"cidr":       "10.1.0.0/24",
+		"poolServer": map[string]interface{}{"id": otherId, "name": "tfbluecat"},
+	})
+	api.Seed("/api/networks/pools", map[string]interface{}{
+		"name": "pool-morpheus",
+		"cidr": "10.2.0.0/24",
+	})
+	api.Seed("/api/networks/pools", map[string]interface{}{
+		"name":       "pool-b",
+		"cidr":       "10.3.0.0/24",
+		"poolServer": map[string]interface{}{"id": integrationId, "name": "tfinfoblox"},
+	})
+
+	api.Seed("/api/networks/domains", map[string]interface{}{
+		"name":    "example.com",
+		"refType": "AccountIntegration",
+		"refId":   integrationId,
+	})
+	// A cloud with the same ID as the integration
+	api.Seed("/api/networks/domains", map[string]interface{}{
+		"name":    "cloud.local",
+		"refType": "ComputeZone",
+		"refId":   integrationId,
+	})
+	api.Seed("/api/networks/domains", map[string]interface{}{
+		"name":    "other.com",
+		"refType": "AccountIntegration",
+		"refId":   otherId,
+	})
+	return api, integrationId
+}
+
+func testNetworkIntegrationNames(items []map[string]interface{}) []string {
+	var names []string
+	for _, item := range items {
+		names = append(names, item["name"].(string))
+	}
+	return names
+}
+
+func TestNetworkIntegrationPoolsAndZones(t *testing.T) {
+	api, integrationId := testNetworkIntegrationAPI(t)
+	client := api.Meta().(*providerMeta).client
+
+	pools, err := networkIntegrationPools(client, integrationId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if names := testNetworkIntegrationNames(pools); !reflect.DeepEqual(names, []string{"pool-a", "pool-b"}) {
+		t.Errorf("expected the pools of the integration, got %v", names)
+	}
+	if pools[0]["cidr"] != "10.0.0.0/24" {
+		t.Errorf("expected the cidr of the pool, got %v", pools[0]["cidr"])
+	}
+
+	zones, err := networkIntegrationZones(client, integrationId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if names := testNetworkIntegrationNames(zones); !reflect.DeepEqual(names, []string{"example.com"}) {
+		t.Errorf("expected the zones of the integration, got %v", names)
+	}
+
+	// The lists are filtered by the appliance rather than fetching every
+	// pool and domain
+	queries := api.Queries("GET", "/api/networks/pools")
+	if len(queries) != 1 || queries[0].Get("poolServerId") != int64ToString(integrationId) {
+		t.Errorf("expected the pools to be filtered on the pool server, got %v", queries)
+	}
+	queries = api.Queries("GET", "/api/networks/domains")
+	if len(queries) != 1 || queries[0].Get("refType") != "AccountIntegration" || queries[0].Get("refId") != int64ToString(integrationId) {
+		t.Errorf("expected the domains to be filtered on the integration, got %v", queries)
+	}
+}
+
+func TestNetworkIntegrationPoolsAndZones_unfiltered(t *testing.T) {
+	api, integrationId := testNetworkIntegrationAPI(t)
+	client := api.Meta().(*providerMeta).client
+
+	// An appliance that ignores the filters returns every pool and domain
+	api.Handle("GET", "/api/networks/pools", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		pools := s.Objects("/api/networks/pools")
+		return 200, map[string]interface{}{
+			"networkPools": pools,
+			"meta":         map[string]interface{}{"total": len(pools)},
+		}
+	})
+	api.Handle("GET", "/api/networks/domains", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		domains := s.Objects("/api/networks/domains")
+		return 200, map[string]interface{}{
+			"networkDomains": domains,
+			"meta":           map[string]interface{}{"total": len(domains)},
+		}
+	})
+
+	pools, err := networkIntegrationPools(client, integrationId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if names := testNetworkIntegrationNames(pools); !reflect.DeepEqual(names, []string{"pool-a", "pool-b"}) {
+		t.Errorf("expected the pools of the integration, got %v", names)
+	}
+	zones, err := networkIntegrationZones(client, integrationId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if names := testNetworkIntegrationNames(zones); !reflect.DeepEqual(names, []string{"example.com"}) {
+		t.Errorf("expected the zones of the integration, got %v", names)
+	}
+}
+
+func TestResourceInfobloxIntegrationRead_poolsAndZones(t *testing.T) {
+	api, integrationId := testNetworkIntegrationAPI(t)
+
+	d := resourceInfobloxIntegration().Data(nil)
+	d.SetId(int64ToString(integrationId))
+	if diags := resourceInfobloxIntegrationRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if count := d.Get("pools.#").(int); count != 2 {
+		t.Fatalf("expected 2 pools, got %d", count)
+	}
+	if name := d.Get("pools.1.name"); name != "pool-b" {
+		t.Errorf("expected the second pool to be pool-b, got %v", name)
+	}
+	if cidr := d.Get("pools.1.cidr"); cidr != "10.3.0.0/24" {
+		t.Errorf("expected the cidr of pool-b, got %v", cidr)
+	}
+	if count := d.Get("zones.#").(int); count != 1 {
+		t.Fatalf("expected 1 zone, got %d", count)
+	}
+	if name := d.Get("zones.0.name"); name != "example.com" {
+		t.Errorf("expected the zone example.com, got %v", name)
+	}
+}
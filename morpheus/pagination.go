@@ -0,0 +1,48 @@
+package morpheus
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+)
+
+// listPageSize is the number of records requested for each page of a list
+const listPageSize = 100
+
+// listAllPages calls list for every page of results, following offset and
+// max until meta.total records have been returned. The page function
+// extracts the records and meta from each response. The last response is
+// returned so callers can inspect the status code when an error occurs.
+func listAllPages[T any](list func(*morpheus.Request) (*morpheus.Response, error), params map[string]string, page func(*morpheus.Response) (*[]T, *morpheus.MetaResult)) ([]T, *morpheus.Response, error) {
+	var records []T
+	offset := 0
+	for {
+		queryParams := make(map[string]string, len(params)+2)
+		for k, v := range params {
+			queryParams[k] = v
+		}
+		queryParams["max"] = strconv.Itoa(listPageSize)
+		queryParams["offset"] = strconv.Itoa(offset)
+
+		resp, err := list(&morpheus.Request{
+			QueryParams: queryParams,
+		})
+		if err != nil {
+			return records, resp, err
+		}
+		log.Printf("API RESPONSE: %s", resp)
+
+		items, meta := page(resp)
+		if items == nil || len(*items) == 0 {
+			return records, resp, nil
+		}
+		records = append(records, *items...)
+		offset += len(*items)
+
+		// Without meta there is no way to know if there are more pages
+		if meta == nil || int64(offset) >= meta.Total {
+			return records, resp, nil
+		}
+	}
+}
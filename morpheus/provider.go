@@ -2,9 +2,12 @@ package morpheus
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -50,6 +53,94 @@ func Provider() *schema.Provider {
 				DefaultFunc:   schema.EnvDefaultFunc("MORPHEUS_API_PASSWORD", nil),
 				ConflictsWith: []string{"access_token"},
 			},
+
+			"poll_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The time to wait before the status of a long running operation, such as provisioning an instance or cluster, is first checked (e.g. 30s or 2m)",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_POLL_DELAY", defaultPollDelay.String()),
+				ValidateFunc: validateDuration,
+			},
+
+			"poll_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The time to wait between status checks of a long running operation, such as provisioning an instance or cluster (e.g. 30s or 1m). The interval must be less than 3m",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_POLL_INTERVAL", defaultPollInterval.String()),
+				ValidateFunc: validateDuration,
+			},
+
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to skip verification of the Morpheus appliance TLS certificate",
+				DefaultFunc: schema.EnvDefaultFunc("MORPHEUS_INSECURE", false),
+			},
+
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The path to a PEM encoded CA bundle used to verify the Morpheus appliance TLS certificate",
+				DefaultFunc:   schema.EnvDefaultFunc("MORPHEUS_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "A PEM encoded CA bundle used to verify the Morpheus appliance TLS certificate",
+				DefaultFunc:   schema.EnvDefaultFunc("MORPHEUS_CA_CERT_PEM", nil),
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The path to, or the contents of, a PEM encoded client certificate used for mutual TLS authentication",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_CLIENT_CERT", nil),
+				RequiredWith: []string{"client_key"},
+			},
+
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "The path to, or the contents of, the PEM encoded private key for the client certificate",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_CLIENT_KEY", nil),
+				RequiredWith: []string{"client_cert"},
+			},
+
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The number of times a failed API request is retried. GET requests are retried on throttling, gateway and connection errors, other requests only when they were throttled (HTTP 429)",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_wait_min": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The minimum time to wait before retrying a failed API request (e.g. 1s). The wait doubles with each attempt unless the appliance sends a Retry-After header",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_RETRY_WAIT_MIN", defaultRetryWaitMin.String()),
+				ValidateFunc: validateDuration,
+			},
+
+			"retry_wait_max": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The maximum time to wait before retrying a failed API request (e.g. 30s)",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_RETRY_WAIT_MAX", defaultRetryWaitMax.String()),
+				ValidateFunc: validateDuration,
+			},
+
+			"max_requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of requests sent to the Morpheus API each second, across all resources and data sources. Defaults to 0 (unlimited)",
+				DefaultFunc:  schema.EnvDefaultFunc("MORPHEUS_MAX_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -59,6 +150,8 @@ func Provider() *schema.Provider {
 			"morpheus_ansible_tower_integration":             resourceAnsibleTowerIntegration(),
 			"morpheus_ansible_tower_task":                    resourceAnsibleTowerTask(),
 			"morpheus_api_option_list":                       resourceApiOptionList(),
+			"morpheus_app":                                   resourceApp(),
+			"morpheus_app_blueprint":                         resourceAppBlueprint(),
 			"morpheus_app_blueprint_catalog_item":            resourceAppBlueprintCatalogItem(),
 			"morpheus_appliance_setting":                     resourceApplianceSetting(),
 			"morpheus_arm_app_blueprint":                     resourceArmAppBlueprint(),
@@ -68,6 +161,7 @@ func Provider() *schema.Provider {
 			"morpheus_azure_cloud":                           resourceAzureCloud(),
 			"morpheus_backup_creation_policy":                resourceBackupCreationPolicy(),
 			"morpheus_backup_setting":                        resourceBackupSetting(),
+			"morpheus_bluecat_integration":                   resourceBlueCatIntegration(),
 			"morpheus_boot_script":                           resourceBootScript(),
 			"morpheus_budget_policy":                         resourceBudgetPolicy(),
 			"morpheus_checkbox_option_type":                  resourceCheckboxOptionType(),
@@ -76,6 +170,7 @@ func Provider() *schema.Provider {
 			"morpheus_cloud_formation_app_blueprint":         resourceCloudFormationAppBlueprint(),
 			"morpheus_cloud_formation_spec_template":         resourceCloudFormationSpecTemplate(),
 			"morpheus_cluster_layout":                        resourceClusterLayout(),
+			"morpheus_cluster_namespace":                     resourceClusterNamespace(),
 			"morpheus_cluster_package":                       resourceClusterPackage(),
 			"morpheus_cluster_resource_name_policy":          resourceClusterResourceNamePolicy(),
 			"morpheus_contact":                               resourceContact(),
@@ -89,6 +184,7 @@ func Provider() *schema.Provider {
 			"morpheus_email_task":                            resourceEmailTask(),
 			"morpheus_environment":                           resourceEnvironment(),
 			"morpheus_execute_schedule":                      resourceExecuteSchedule(),
+			"morpheus_file_share":                            resourceFileShare(),
 			"morpheus_file_template":                         resourceFileTemplate(),
 			"morpheus_form":                                  resourceForm(),
 			"morpheus_git_integration":                       resourceGitIntegration(),
@@ -99,17 +195,26 @@ func Provider() *schema.Provider {
 			"morpheus_helm_spec_template":                    resourceHelmSpecTemplate(),
 			"morpheus_hidden_option_type":                    resourceHiddenOptionType(),
 			"morpheus_hostname_policy":                       resourceHostNamePolicy(),
+			"morpheus_infoblox_integration":                  resourceInfobloxIntegration(),
+			"morpheus_instance":                              resourceInstance(),
 			"morpheus_instance_catalog_item":                 resourceInstanceCatalogItem(),
 			"morpheus_instance_layout":                       resourceInstanceLayout(),
 			"morpheus_instance_name_policy":                  resourceInstanceNamePolicy(),
+			"morpheus_instance_snapshot":                     resourceInstanceSnapshot(),
 			"morpheus_instance_type":                         resourceInstanceType(),
 			"morpheus_ipv4_ip_pool":                          resourceIPv4IPPool(),
+			"morpheus_ipv4_ip_pool_address":                  resourceIPv4IPPoolAddress(),
 			"morpheus_javascript_task":                       resourceJavaScriptTask(),
 			"morpheus_library_script_task":                   resourceLibraryScriptTask(),
 			"morpheus_library_template_task":                 resourceLibraryTemplateTask(),
 			"morpheus_license":                               resourceLicense(),
+			"morpheus_load_balancer":                         resourceLoadBalancer(),
+			"morpheus_load_balancer_monitor":                 resourceLoadBalancerMonitor(),
+			"morpheus_load_balancer_pool":                    resourceLoadBalancerPool(),
+			"morpheus_load_balancer_virtual_server":          resourceLoadBalancerVirtualServer(),
 			"morpheus_key_pair":                              resourceKeyPair(),
 			"morpheus_kubernetes_app_blueprint":              resourceKubernetesAppBlueprint(),
+			"morpheus_kubernetes_cluster":                    resourceKubernetesCluster(),
 			"morpheus_kubernetes_spec_template":              resourceKubernetesSpecTemplate(),
 			"morpheus_manual_option_list":                    resourceManualOptionList(),
 			"morpheus_max_containers_policy":                 resourceMaxContainersPolicy(),
@@ -118,17 +223,24 @@ func Provider() *schema.Provider {
 			"morpheus_max_memory_policy":                     resourceMaxMemoryPolicy(),
 			"morpheus_max_storage_policy":                    resourceMaxStoragePolicy(),
 			"morpheus_max_vms_policy":                        resourceMaxVmsPolicy(),
+			"morpheus_microsoft_dns_integration":             resourceMicrosoftDnsIntegration(),
 			"morpheus_monitoring_setting":                    resourceMonitoringSetting(),
 			"morpheus_motd_policy":                           resourceMotdPolicy(),
 			"morpheus_mvm_instance":                          resourceMVMInstance(),
 			"morpheus_nested_workflow_task":                  resourceNestedWorkflowTask(),
+			"morpheus_network":                               resourceNetwork(),
 			"morpheus_network_domain":                        resourceNetworkDomain(),
+			"morpheus_network_domain_record":                 resourceNetworkDomainRecord(),
+			"morpheus_network_group":                         resourceNetworkGroup(),
 			"morpheus_network_quota_policy":                  resourceNetworkQuotaPolicy(),
+			"morpheus_network_subnet":                        resourceNetworkSubnet(),
 			"morpheus_node_type":                             resourceNodeType(),
 			"morpheus_number_option_type":                    resourceNumberOptionType(),
 			"morpheus_operational_workflow":                  resourceOperationalWorkflow(),
 			"morpheus_password_option_type":                  resourcePasswordOptionType(),
+			"morpheus_phpipam_integration":                   resourcePhpIpamIntegration(),
 			"morpheus_power_schedule_policy":                 resourcePowerSchedulePolicy(),
+			"morpheus_powerdns_integration":                  resourcePowerDnsIntegration(),
 			"morpheus_powershell_script_task":                resourcePowerShellScriptTask(),
 			"morpheus_preseed_script":                        resourcePreseedScript(),
 			"morpheus_price_set":                             resourcePriceSet(),
@@ -147,12 +259,18 @@ func Provider() *schema.Provider {
 			"morpheus_saml_identity_source":                  resourceSAMLIdentitySource(),
 			"morpheus_scale_threshold":                       resourceScaleThreshold(),
 			"morpheus_script_template":                       resourceScriptTemplate(),
+			"morpheus_security_group":                        resourceSecurityGroup(),
+			"morpheus_security_group_rule":                   resourceSecurityGroupRule(),
 			"morpheus_security_package":                      resourceSecurityPackage(),
 			"morpheus_select_list_option_type":               resourceSelectListOptionType(),
 			"morpheus_service_plan":                          resourceServicePlan(),
 			"morpheus_servicenow_integration":                resourceServiceNowIntegration(),
 			"morpheus_shell_script_task":                     resourceShellScriptTask(),
 			"morpheus_standard_cloud":                        resourceStandardCloud(),
+			"morpheus_storage_bucket":                        resourceStorageBucket(),
+			"morpheus_storage_group":                         resourceStorageGroup(),
+			"morpheus_storage_server":                        resourceStorageServer(),
+			"morpheus_storage_volume":                        resourceStorageVolume(),
 			"morpheus_tag_policy":                            resourceTagPolicy(),
 			"morpheus_task_job":                              resourceTaskJob(),
 			"morpheus_tenant_role":                           resourceTenantRole(),
@@ -191,6 +309,7 @@ func Provider() *schema.Provider {
 			"morpheus_clouds":                     dataSourceMorpheusClouds(),
 			"morpheus_cloud_folder":               dataSourceMorpheusCloudFolder(),
 			"morpheus_cloud_type":                 dataSourceMorpheusCloudType(),
+			"morpheus_cluster_kubeconfig":         dataSourceMorpheusClusterKubeconfig(),
 			"morpheus_cluster_type":               dataSourceMorpheusClusterType(),
 			"morpheus_contact":                    dataSourceMorpheusContact(),
 			"morpheus_credential":                 dataSourceMorpheusCredential(),
@@ -204,6 +323,7 @@ func Provider() *schema.Provider {
 			"morpheus_group":                      dataSourceMorpheusGroup(),
 			"morpheus_groups":                     dataSourceMorpheusGroups(),
 			"morpheus_instance_layout":            dataSourceMorpheusInstanceLayout(),
+			"morpheus_instance_snapshots":         dataSourceMorpheusInstanceSnapshots(),
 			"morpheus_instance_type":              dataSourceMorpheusInstanceType(),
 			"morpheus_integration":                dataSourceMorpheusIntegration(),
 			"morpheus_job":                        dataSourceMorpheusJob(),
@@ -256,7 +376,51 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		TenantSubdomain: d.Get("tenant_subdomain").(string),
 		Username:        d.Get("username").(string),
 		Password:        d.Get("password").(string),
-		//Insecure:                d.Get("insecure").(bool), //.(bool),
+		Insecure:        d.Get("insecure").(bool),
+		CACertFile:      d.Get("ca_cert_file").(string),
+		CACertPEM:       d.Get("ca_cert_pem").(string),
+		ClientCert:      d.Get("client_cert").(string),
+		ClientKey:       d.Get("client_key").(string),
+	}
+
+	// The durations have already been validated by the schema
+	config.PollDelay, _ = time.ParseDuration(d.Get("poll_delay").(string))
+	config.PollInterval, _ = time.ParseDuration(d.Get("poll_interval").(string))
+	if config.PollInterval >= maxPollInterval {
+		return nil, diag.Errorf("poll_interval must be less than %s", maxPollInterval)
+	}
+
+	config.MaxRetries = d.Get("max_retries").(int)
+	config.RetryWaitMin, _ = time.ParseDuration(d.Get("retry_wait_min").(string))
+	config.RetryWaitMax, _ = time.ParseDuration(d.Get("retry_wait_max").(string))
+	if config.RetryWaitMax < config.RetryWaitMin {
+		return nil, diag.Errorf("retry_wait_max must not be less than retry_wait_min")
+	}
+	config.MaxRequestsPerSecond = d.Get("max_requests_per_second").(int)
+
+	client, diags := config.Client()
+	if diags.HasError() {
+		return nil, diags
+	}
+	// The API proxy is closed when Terraform stops the provider, otherwise
+	// it runs until the provider process exits
+	if stopCtx, ok := schema.StopContext(ctx); ok {
+		context.AfterFunc(stopCtx, func() {
+			config.Close()
+		})
+	}
+	return &providerMeta{client: client, config: &config}, diags
+}
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid duration such as 30s or 5m, got %q: %s", k, value, err))
+		return
+	}
+	if duration < 0 {
+		errors = append(errors, fmt.Errorf("%q must not be negative, got %q", k, value))
 	}
-	return config.Client()
+	return
 }
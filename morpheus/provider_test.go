@@ -0,0 +1,91 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccProviderFactories is used by the acceptance tests to instantiate the provider
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"morpheus": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProviderConfigure_meta(t *testing.T) {
+	api := newFakeMorpheus(t)
+	meta, ok := api.Meta().(*providerMeta)
+	if !ok {
+		t.Fatalf("expected the provider meta to be a *providerMeta")
+	}
+	if meta.client == nil {
+		t.Fatalf("expected the provider meta to hold the API client")
+	}
+	if meta.config.PollDelay != 0 || meta.config.PollInterval != 10*time.Millisecond {
+		t.Fatalf("expected the configured poll settings, got delay %s and interval %s", meta.config.PollDelay, meta.config.PollInterval)
+	}
+	if meta.config.MaxRetries != defaultMaxRetries {
+		t.Fatalf("expected max_retries to default to %d, got %d", defaultMaxRetries, meta.config.MaxRetries)
+	}
+}
+
+func TestProviderConfigure_stop(t *testing.T) {
+	api := newFakeMorpheus(t)
+	stopCtx, stop := context.WithCancel(context.Background())
+	t.Cleanup(stop)
+	ctx := context.WithValue(context.Background(), schema.StopContextKey, stopCtx)
+
+	p := Provider()
+	diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":          api.Server.URL,
+		"access_token": fakeMorpheusAccessToken,
+	}))
+	if diags.HasError() {
+		t.Fatalf("unable to configure the provider: %v", diags)
+	}
+	proxyUrl := p.Meta().(*providerMeta).config.proxy.Url
+	if _, err := http.Get(proxyUrl + "/api/whoami"); err != nil {
+		t.Fatalf("expected the API proxy to be running: %s", err)
+	}
+
+	// The API proxy is closed when Terraform stops the provider
+	stop()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := http.Get(proxyUrl + "/api/whoami"); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the API proxy to be closed when the provider is stopped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// testAccPreCheck clears the provider environment variables so that the
+// acceptance tests only ever talk to the fake Morpheus API
+func testAccPreCheck(t *testing.T) {
+	for _, env := range []string{
+		"MORPHEUS_API_URL",
+		"MORPHEUS_API_TOKEN",
+		"MORPHEUS_API_USERNAME",
+		"MORPHEUS_API_PASSWORD",
+		"MORPHEUS_API_TENANT",
+	} {
+		if os.Getenv(env) != "" {
+			t.Setenv(env, "")
+		}
+	}
+}
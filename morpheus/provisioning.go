@@ -0,0 +1,203 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	onCreateFailureTaint  = "taint"
+	onCreateFailureDelete = "delete"
+
+	// provisioningHistoryEvents is the number of events from the most
+	// recent provisioning process that are included in a failure diagnostic
+	provisioningHistoryEvents = 5
+)
+
+// provisioningFailureStatuses are the terminal states that indicate an
+// instance, cluster or app was not successfully provisioned
+var provisioningFailureStatuses = []string{statusFailed, statusDenied, statusCancelled}
+
+// onCreateFailureSchema is shared by the resources that wait on
+// provisioning and controls what happens to a resource that fails
+func onCreateFailureSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:  "The action to take when provisioning fails (taint, delete). A tainted resource is kept in state and replaced on the next apply, a deleted resource is removed from Morpheus before the error is returned. Defaults to taint",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{onCreateFailureTaint, onCreateFailureDelete}, false),
+	}
+}
+
+func isProvisioningFailure(status string) bool {
+	for _, failureStatus := range provisioningFailureStatuses {
+		if status == failureStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// handleProvisioningFailure builds the diagnostic returned when a create
+// wait settles on a failure status. The resource id must already be set so
+// that terraform taints the resource, unless on_create_failure is set to
+// delete in which case the resource is removed via deleteFunc.
+func handleProvisioningFailure(ctx context.Context, d *schema.ResourceData, meta interface{}, objectType string, status string, deleteFunc schema.DeleteContextFunc) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := toInt64(d.Id())
+
+	diags := diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("error creating %s: %s %d is in a %s state", objectType, objectType, id, status),
+			Detail:   provisioningFailureDetail(client, objectType, id),
+		},
+	}
+
+	if d.Get("on_create_failure").(string) == onCreateFailureDelete {
+		log.Printf("[WARN] deleting %s %d after it failed to provision", objectType, id)
+		deleteDiags := deleteFunc(ctx, d, meta)
+		if deleteDiags.HasError() {
+			return append(diags, deleteDiags...)
+		}
+		d.SetId("")
+	}
+	return diags
+}
+
+// provisioningFailureDetail returns the status message of the instance,
+// cluster or app along with the most recent provisioning history events
+func provisioningFailureDetail(client *morpheus.Client, objectType string, id int64) string {
+	var detail []string
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/%ss/%d", objectType, id),
+		Result: &ProvisioningStatusResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+	} else {
+		log.Printf("API RESPONSE: %s", resp)
+		result := resp.Result.(*ProvisioningStatusResult)
+		for _, status := range []*ProvisioningStatus{result.Instance, result.Cluster, result.App} {
+			if status == nil {
+				continue
+			}
+			if status.StatusMessage != "" {
+				detail = append(detail, fmt.Sprintf("Status message: %s", status.StatusMessage))
+			}
+			if status.ErrorMessage != "" {
+				detail = append(detail, fmt.Sprintf("Error message: %s", status.ErrorMessage))
+			}
+		}
+	}
+
+	resp, err = client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/%ss/%d/history", objectType, id),
+		QueryParams: map[string]string{
+			"max":       "1",
+			"sort":      "id",
+			"direction": "desc",
+		},
+		Result: &ProcessHistoryResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+	} else {
+		log.Printf("API RESPONSE: %s", resp)
+		result := resp.Result.(*ProcessHistoryResult)
+		if len(result.Processes) > 0 {
+			process := result.Processes[0]
+			detail = append(detail, fmt.Sprintf("Last process: %s", process.summary()))
+			events := process.Events
+			if len(events) > provisioningHistoryEvents {
+				events = events[len(events)-provisioningHistoryEvents:]
+			}
+			for _, event := range events {
+				detail = append(detail, fmt.Sprintf("  - %s", event.summary()))
+			}
+		}
+	}
+
+	if len(detail) == 0 {
+		return "No status message or provisioning history was returned by Morpheus"
+	}
+	return strings.Join(detail, "\n")
+}
+
+type ProvisioningStatusResult struct {
+	Instance *ProvisioningStatus `json:"instance"`
+	Cluster  *ProvisioningStatus `json:"cluster"`
+	App      *ProvisioningStatus `json:"app"`
+}
+
+type ProvisioningStatus struct {
+	ID            int64  `json:"id"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage"`
+	ErrorMessage  string `json:"errorMessage"`
+}
+
+type ProcessHistoryResult struct {
+	Processes []ProcessHistory `json:"processes"`
+}
+
+type ProcessHistory struct {
+	ID          int64  `json:"id"`
+	DisplayName string `json:"displayName"`
+	ProcessType struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	} `json:"processType"`
+	InstanceId int64                 `json:"instanceId"`
+	Status     string                `json:"status"`
+	Reason     string                `json:"reason"`
+	Message    string                `json:"message"`
+	Error      string                `json:"error"`
+	Events     []ProcessHistoryEvent `json:"events"`
+}
+
+type ProcessHistoryEvent struct {
+	ID          int64  `json:"id"`
+	DisplayName string `json:"displayName"`
+	ProcessType struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	} `json:"processType"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	Error   string `json:"error"`
+}
+
+func (p ProcessHistory) summary() string {
+	return processSummary(p.DisplayName, p.ProcessType.Name, p.Status, p.Reason, p.Message, p.Error)
+}
+
+func (e ProcessHistoryEvent) summary() string {
+	return processSummary(e.DisplayName, e.ProcessType.Name, e.Status, e.Reason, e.Message, e.Error)
+}
+
+func processSummary(displayName string, processType string, status string, reason string, message string, errorMessage string) string {
+	name := processType
+	if name == "" {
+		name = displayName
+	}
+	summary := fmt.Sprintf("%s (%s)", name, status)
+	for _, text := range []string{errorMessage, reason, message} {
+		if text != "" {
+			summary = fmt.Sprintf("%s: %s", summary, text)
+			break
+		}
+	}
+	return summary
+}
@@ -0,0 +1,122 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testProvisioningFailureAPI seeds an instance that failed to provision along
+// with the history of its provisioning process
+func testProvisioningFailureAPI(t *testing.T) (*fakeMorpheus, int64) {
+	t.Helper()
+	api := newFakeMorpheus(t)
+	instanceId := api.Seed("/api/instances", map[string]interface{}{
+		"name":          "tfinstance",
+		"status":        statusFailed,
+		"statusMessage": "Failed to provision the virtual machine",
+	})
+	api.Handle(http.MethodGet, "/api/instances/"+int64ToString(instanceId)+"/history", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"processes": []interface{}{
+				map[string]interface{}{
+					"id":          10,
+					"processType": map[string]interface{}{"name": "provision"},
+					"status":      "failed",
+					"error":       "the template ubuntu-22 was not found",
+					"events": []interface{}{
+						map[string]interface{}{
+							"processType": map[string]interface{}{"name": "prepare"},
+							"status":      "complete",
+						},
+						map[string]interface{}{
+							"processType": map[string]interface{}{"name": "cloneVm"},
+							"status":      "failed",
+							"error":       "template not found",
+						},
+					},
+				},
+			},
+		}
+	})
+	return api, instanceId
+}
+
+func TestHandleProvisioningFailure(t *testing.T) {
+	cases := []struct {
+		onCreateFailure string
+		deleted         bool
+	}{
+		{"", false},
+		{onCreateFailureTaint, false},
+		{onCreateFailureDelete, true},
+	}
+	for _, c := range cases {
+		api, instanceId := testProvisioningFailureAPI(t)
+		d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{
+			"on_create_failure": c.onCreateFailure,
+		})
+		d.SetId(int64ToString(instanceId))
+
+		diags := handleProvisioningFailure(context.Background(), d, api.Meta(), "instance", statusFailed, resourceInstanceDelete)
+		if len(diags) != 1 || !diags.HasError() {
+			t.Fatalf("%q: expected a single error, got %v", c.onCreateFailure, diags)
+		}
+		expected := "error creating instance: instance " + int64ToString(instanceId) + " is in a failed state"
+		if diags[0].Summary != expected {
+			t.Errorf("%q: expected the summary %q, got %q", c.onCreateFailure, expected, diags[0].Summary)
+		}
+		for _, text := range []string{
+			"Status message: Failed to provision the virtual machine",
+			"Last process: provision (failed): the template ubuntu-22 was not found",
+			"  - cloneVm (failed): template not found",
+		} {
+			if !strings.Contains(diags[0].Detail, text) {
+				t.Errorf("%q: expected the detail to contain %q, got %q", c.onCreateFailure, text, diags[0].Detail)
+			}
+		}
+
+		_, exists := api.Get("/api/instances", instanceId)
+		if c.deleted {
+			if exists || d.Id() != "" {
+				t.Errorf("%q: expected the instance to be deleted and removed from the state", c.onCreateFailure)
+			}
+		} else if !exists || d.Id() == "" {
+			t.Errorf("%q: expected the instance to be kept in the state so it is tainted", c.onCreateFailure)
+		}
+
+		queries := api.Queries(http.MethodGet, "/api/instances/"+int64ToString(instanceId)+"/history")
+		if len(queries) != 1 || queries[0].Get("max") != "1" || queries[0].Get("direction") != "desc" {
+			t.Errorf("%q: expected the most recent process to be requested, got %v", c.onCreateFailure, queries)
+		}
+	}
+}
+
+func TestProvisioningFailureDetail_noDetails(t *testing.T) {
+	api := newFakeMorpheus(t)
+	client := api.Meta().(*providerMeta).client
+
+	detail := provisioningFailureDetail(client, "instance", 42)
+	if detail != "No status message or provisioning history was returned by Morpheus" {
+		t.Fatalf("unexpected detail %q", detail)
+	}
+}
+
+func TestProcessSummary(t *testing.T) {
+	cases := []struct {
+		event    ProcessHistoryEvent
+		expected string
+	}{
+		{ProcessHistoryEvent{DisplayName: "Provision", Status: "complete"}, "Provision (complete)"},
+		{ProcessHistoryEvent{DisplayName: "Provision", Status: "failed", Error: "quota exceeded", Message: "retrying"}, "Provision (failed): quota exceeded"},
+		{ProcessHistoryEvent{DisplayName: "Provision", Status: "failed", Message: "no capacity"}, "Provision (failed): no capacity"},
+	}
+	for _, c := range cases {
+		if summary := c.event.summary(); summary != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, summary)
+		}
+	}
+}
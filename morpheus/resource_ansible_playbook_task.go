@@ -112,7 +112,7 @@ func resourceAnsiblePlaybookTask() *schema.Resource {
 }
 
 func resourceAnsiblePlaybookTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -170,7 +170,7 @@ func resourceAnsiblePlaybookTaskCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceAnsiblePlaybookTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -225,7 +225,7 @@ func resourceAnsiblePlaybookTaskRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceAnsiblePlaybookTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -278,7 +278,7 @@ func resourceAnsiblePlaybookTaskUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceAnsiblePlaybookTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
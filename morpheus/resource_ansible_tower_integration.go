@@ -77,7 +77,7 @@ func resourceAnsibleTowerIntegration() *schema.Resource {
 }
 
 func resourceAnsibleTowerIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -127,7 +127,7 @@ func resourceAnsibleTowerIntegrationCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceAnsibleTowerIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -176,7 +176,7 @@ func resourceAnsibleTowerIntegrationRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceAnsibleTowerIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -226,7 +226,7 @@ func resourceAnsibleTowerIntegrationUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceAnsibleTowerIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
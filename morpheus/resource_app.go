@@ -0,0 +1,863 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceApp() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus app resource, which deploys an app from an app blueprint",
+		CreateContext: resourceAppCreate,
+		ReadContext:   resourceAppRead,
+		UpdateContext: resourceAppUpdate,
+		DeleteContext: resourceAppDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the app",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the app",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the app",
+				Optional:    true,
+				Computed:    true,
+			},
+			"blueprint_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the app blueprint to deploy the app from",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the group to deploy the app to",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"cloud_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the default cloud for the instances of the app",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Description: "The code of the environment to deploy the app to",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"custom_options": {
+				Type:        schema.TypeMap,
+				Description: "Values for the option types (inputs) of the app blueprint keyed by the option type field name",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tier": {
+				Type:        schema.TypeList,
+				Description: "Overrides for the tiers of the app blueprint, every tier of the app is read back when no tier is configured",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the tier in the app blueprint",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"instance_count": {
+							Type:         schema.TypeInt,
+							Description:  "The number of instances in the tier, changing the count clones or removes instances of the tier",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"config": {
+							Type:             schema.TypeString,
+							Description:      "The instance config (JSON) merged into every instance of the tier",
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: suppressEquivalentJsonDiffs,
+							ValidateFunc:     validation.StringIsJSON,
+						},
+						"instance": {
+							Type:        schema.TypeList,
+							Description: "Overrides for the instances of the tier, matched to the instances of the blueprint tier by position",
+							Optional:    true,
+							ForceNew:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"config": {
+										Type:             schema.TypeString,
+										Description:      "The instance config (JSON) merged into the instance",
+										Required:         true,
+										ForceNew:         true,
+										DiffSuppressFunc: suppressEquivalentJsonDiffs,
+										ValidateFunc:     validation.StringIsJSON,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Description: "Tags to assign to the app",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "The status of the app",
+				Computed:    true,
+			},
+			"on_create_failure": onCreateFailureSchema(),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	blueprintId := int64(d.Get("blueprint_id").(int))
+
+	// The app is deployed from the config of the blueprint with the
+	// overrides of the tiers and instances merged in
+	resp, err := client.GetBlueprint(blueprintId, &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var blueprint AppBlueprintConfigPayload
+	if err := json.Unmarshal(resp.Body, &blueprint); err != nil {
+		return diag.FromErr(err)
+	}
+	appPayload := blueprint.Blueprint.Config
+	if appPayload == nil {
+		appPayload = make(map[string]interface{})
+	}
+
+	if err := applyAppTierOverrides(appPayload, d.Get("tier").([]interface{})); err != nil {
+		return diag.Errorf("error applying the tier overrides to blueprint %d: %s", blueprintId, err)
+	}
+
+	appPayload["blueprintId"] = blueprintId
+	appPayload["name"] = d.Get("name").(string)
+	appPayload["description"] = d.Get("description").(string)
+	appPayload["group"] = map[string]interface{}{
+		"id": d.Get("group_id").(int),
+	}
+	if d.Get("cloud_id").(int) != 0 {
+		appPayload["defaultCloud"] = map[string]interface{}{
+			"id": d.Get("cloud_id").(int),
+		}
+	}
+	if d.Get("environment").(string) != "" {
+		appPayload["environment"] = d.Get("environment").(string)
+	}
+
+	// Custom Options
+	customOptionsInput := d.Get("custom_options").(map[string]interface{})
+	if len(customOptionsInput) > 0 {
+		customOptions := make(map[string]interface{})
+		for key, value := range customOptionsInput {
+			customOptions[key] = value.(string)
+		}
+		appPayload["customOptions"] = customOptions
+	}
+
+	// Tags
+	if tags := d.Get("tags").(map[string]interface{}); len(tags) > 0 {
+		appPayload["tags"] = parseTags(tags)
+	}
+
+	resp, err = client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/apps",
+		Body:   appPayload,
+		Result: &GetAppResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetAppResult)
+	if result.App == nil {
+		return diag.Errorf("create operation: app not found in response data") // should not happen
+	}
+	app := result.App
+
+	appStatus, err := waitForAppProvisioning(ctx, meta, app.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.Errorf("error creating app: %s", err)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(app.ID))
+
+	// Fail the app deployment if any of the tiers failed to provision
+	if isProvisioningFailure(appStatus) {
+		return handleProvisioningFailure(ctx, d, meta, "app", appStatus, resourceAppDelete)
+	}
+
+	resourceAppRead(ctx, d, meta)
+	return diags
+}
+
+func resourceAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/apps/%s", id),
+		Result: &GetAppResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetAppResult)
+	app := result.App
+	if app == nil {
+		return diag.Errorf("read operation: app not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(app.ID))
+	d.Set("name", app.Name)
+	d.Set("description", app.Description)
+	d.Set("group_id", app.Group.ID)
+	if app.Blueprint.ID != 0 {
+		d.Set("blueprint_id", app.Blueprint.ID)
+	}
+	d.Set("status", app.Status)
+
+	tags := make(map[string]interface{}, len(app.Tags))
+	for _, tag := range app.Tags {
+		tags[tag.Name] = tag.Value
+	}
+	d.Set("tags", tags)
+
+	d.Set("tier", flattenAppTiers(app, d.Get("tier").([]interface{})))
+
+	return diags
+}
+
+func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	if d.HasChanges("name", "description", "tags") {
+		appPayload := map[string]interface{}{
+			"name":        d.Get("name").(string),
+			"description": d.Get("description").(string),
+			"tags":        parseTags(d.Get("tags").(map[string]interface{})),
+		}
+		resp, err := client.Execute(&morpheus.Request{
+			Method: "PUT",
+			Path:   fmt.Sprintf("/api/apps/%s", id),
+			Body: map[string]interface{}{
+				"app": appPayload,
+			},
+			Result: &GetAppResult{},
+		})
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+		log.Printf("API RESPONSE: %s", resp)
+	}
+
+	if d.HasChange("tier") {
+		for i, tier := range d.Get("tier").([]interface{}) {
+			tierKey := fmt.Sprintf("tier.%d.instance_count", i)
+			if !d.HasChange(tierKey) {
+				continue
+			}
+			tierName := tier.(map[string]interface{})["name"].(string)
+			instanceCount := d.Get(tierKey).(int)
+			err := scaleAppTier(ctx, meta, toInt64(id), tierName, instanceCount, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.Errorf("error scaling tier %s of app %s: %s", tierName, id, err)
+			}
+		}
+	}
+
+	return resourceAppRead(ctx, d, meta)
+}
+
+func resourceAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/apps/%s", id),
+		QueryParams: map[string]string{
+			"removeInstances": "on",
+		},
+		Result: &morpheus.StandardResult{},
+	}
+	if USE_FORCE {
+		req.QueryParams["force"] = "true"
+	}
+	resp, err := client.Execute(req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{statusRemoving, statusPendingRemoval, statusStopping, statusPending, statusRunning, statusWarning, statusFailed},
+		Target:  []string{statusRemoved},
+		Refresh: func() (interface{}, string, error) {
+			appDetails, err := client.Execute(&morpheus.Request{
+				Method: "GET",
+				Path:   fmt.Sprintf("/api/apps/%s", id),
+				Result: &GetAppResult{},
+			})
+			if appDetails != nil && appDetails.StatusCode == 404 {
+				return "", statusRemoved, nil
+			}
+			if err != nil {
+				return "", "", err
+			}
+			result := appDetails.Result.(*GetAppResult)
+			return result, result.App.Status, nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		MinTimeout:   1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error deleting app: %s", err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// applyAppTierOverrides merges the tier and instance overrides into the
+// tiers of the blueprint config and sets the number of instances per tier.
+// Instances added to a tier are copies of the last instance of the tier.
+func applyAppTierOverrides(config map[string]interface{}, tiers []interface{}) error {
+	if len(tiers) == 0 {
+		return nil
+	}
+	blueprintTiers, ok := config["tiers"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("the blueprint does not have any tiers")
+	}
+
+	for _, t := range tiers {
+		tier := t.(map[string]interface{})
+		tierName := tier["name"].(string)
+		blueprintTier, ok := blueprintTiers[tierName].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("tier %s is not defined in the blueprint", tierName)
+		}
+		instances, _ := blueprintTier["instances"].([]interface{})
+
+		if count := tier["instance_count"].(int); count > 0 && len(instances) > 0 {
+			for len(instances) < count {
+				instance, err := copyAppInstanceConfig(instances[len(instances)-1])
+				if err != nil {
+					return err
+				}
+				instances = append(instances, instance)
+			}
+			instances = instances[:count]
+		}
+
+		if tierConfig := tier["config"].(string); tierConfig != "" {
+			var override map[string]interface{}
+			if err := json.Unmarshal([]byte(tierConfig), &override); err != nil {
+				return fmt.Errorf("invalid config for tier %s: %s", tierName, err)
+			}
+			for _, instance := range instances {
+				mergeAppConfig(instance.(map[string]interface{}), override)
+			}
+		}
+
+		for i, o := range tier["instance"].([]interface{}) {
+			if i >= len(instances) {
+				return fmt.Errorf("tier %s has %d instance(s) but %d instance overrides were given", tierName, len(instances), len(tier["instance"].([]interface{})))
+			}
+			var override map[string]interface{}
+			if err := json.Unmarshal([]byte(o.(map[string]interface{})["config"].(string)), &override); err != nil {
+				return fmt.Errorf("invalid config for instance %d of tier %s: %s", i, tierName, err)
+			}
+			mergeAppConfig(instances[i].(map[string]interface{}), override)
+		}
+
+		blueprintTier["instances"] = instances
+	}
+	return nil
+}
+
+// mergeAppConfig deep merges an override into an instance config, nested
+// objects are merged while any other value replaces the existing value
+func mergeAppConfig(config map[string]interface{}, override map[string]interface{}) {
+	for key, value := range override {
+		overrideObject, isObject := value.(map[string]interface{})
+		configObject, hasObject := config[key].(map[string]interface{})
+		if isObject && hasObject {
+			mergeAppConfig(configObject, overrideObject)
+		} else {
+			config[key] = value
+		}
+	}
+}
+
+func copyAppInstanceConfig(instance interface{}) (interface{}, error) {
+	data, err := json.Marshal(instance)
+	if err != nil {
+		return nil, err
+	}
+	var instanceCopy map[string]interface{}
+	if err := json.Unmarshal(data, &instanceCopy); err != nil {
+		return nil, err
+	}
+	return instanceCopy, nil
+}
+
+// appProvisioningStatus returns the status of an app based on the instances
+// of its tiers. The app is failed, denied or cancelled as soon as any instance
+// is, and once every instance has settled it is running, or in a warning
+// state when any instance finished with a warning. Stopped and suspended
+// instances have finished provisioning.
+func appProvisioningStatus(app *App) string {
+	var instanceCount, settledCount, warningCount int
+	for _, tier := range app.AppTiers {
+		for _, appInstance := range tier.AppInstances {
+			instanceCount++
+			switch appInstance.Instance.Status {
+			case statusFailed, statusDenied, statusCancelled:
+				return appInstance.Instance.Status
+			case statusWarning:
+				settledCount++
+				warningCount++
+			case statusRunning, statusStopped, statusSuspended:
+				settledCount++
+			}
+		}
+	}
+	// Apps without instances, such as terraform apps, report their own status
+	if instanceCount == 0 {
+		return app.Status
+	}
+	if settledCount < instanceCount {
+		return statusProvisioning
+	}
+	if warningCount > 0 {
+		return statusWarning
+	}
+	return statusRunning
+}
+
+// waitForAppProvisioning waits for every tier of an app to finish
+// provisioning and returns the status it settled on
+func waitForAppProvisioning(ctx context.Context, meta interface{}, appId int64, timeout time.Duration) (string, error) {
+	client := meta.(*providerMeta).client
+	appStatus := statusProvisioning
+	pollConfig := meta.(*providerMeta).config
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{statusProvisioning, statusPending, statusStarting},
+		Target:  []string{statusRunning, statusWarning, statusStopped, statusSuspended, statusFailed, statusDenied, statusCancelled},
+		Refresh: func() (interface{}, string, error) {
+			appDetails, err := client.Execute(&morpheus.Request{
+				Method: "GET",
+				Path:   fmt.Sprintf("/api/apps/%d", appId),
+				Result: &GetAppResult{},
+			})
+			if err != nil {
+				return "", "", err
+			}
+			log.Printf("API RESPONSE: %s", appDetails)
+			result := appDetails.Result.(*GetAppResult)
+			appStatus = appProvisioningStatus(result.App)
+			return result, appStatus, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err := stateConf.WaitForStateContext(ctx)
+	return appStatus, err
+}
+
+// scaleAppTier clones the last instance of a tier or removes the most
+// recently created instances of the tier until it has the given number of
+// instances
+func scaleAppTier(ctx context.Context, meta interface{}, appId int64, tierName string, instanceCount int, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/apps/%d", appId),
+		Result: &GetAppResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	instances := resp.Result.(*GetAppResult).App.tierInstances(tierName)
+	if len(instances) == 0 {
+		return fmt.Errorf("tier %s has no instances to scale", tierName)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].ID < instances[j].ID
+	})
+
+	for i := len(instances); i < instanceCount; i++ {
+		source := instances[len(instances)-1]
+		name := fmt.Sprintf("%s-%d", instances[0].Name, i+1)
+		if err := cloneAppInstance(ctx, meta, appId, tierName, source.ID, name, timeout); err != nil {
+			return err
+		}
+	}
+
+	for i := len(instances) - 1; i >= instanceCount; i-- {
+		if err := deleteAppInstance(ctx, meta, instances[i].ID, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloneAppInstance clones an instance of a tier, waits for the clone to be
+// running and adds it to the tier
+func cloneAppInstance(ctx context.Context, meta interface{}, appId int64, tierName string, sourceId int64, name string, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/instances/%d/clone", sourceId),
+		Body: map[string]interface{}{
+			"name": name,
+		},
+		Result: &CloneInstanceResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	cloneId, err := waitForCloneInstanceId(ctx, meta, resp.Result.(*CloneInstanceResult), timeout)
+	if err != nil {
+		return fmt.Errorf("error waiting for clone %s: %s", name, err)
+	}
+
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{statusProvisioning, statusPending, statusStarting, statusResizing},
+		Target:  []string{statusRunning, statusWarning, statusFailed, statusDenied, statusCancelled},
+		Refresh: func() (interface{}, string, error) {
+			instanceDetails, err := client.GetInstance(cloneId, &morpheus.Request{})
+			if err != nil {
+				return "", "", err
+			}
+			instance := instanceDetails.Result.(*morpheus.GetInstanceResult).Instance
+			return instance, instance.Status, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	cloneResult, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for clone %s: %s", name, err)
+	}
+	clone := cloneResult.(*morpheus.Instance)
+	if isProvisioningFailure(clone.Status) {
+		return fmt.Errorf("clone %s (%d) failed to provision, it is in a %s state", name, clone.ID, clone.Status)
+	}
+
+	resp, err = client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/apps/%d/add-instance", appId),
+		Body: map[string]interface{}{
+			"instanceId": clone.ID,
+			"tierName":   tierName,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	return nil
+}
+
+// waitForCloneInstanceId returns the ID of the instance created by a clone
+// request. The instance is returned by the request on recent versions of
+// Morpheus, otherwise the clone process is polled until it references the
+// new instance.
+func waitForCloneInstanceId(ctx context.Context, meta interface{}, result *CloneInstanceResult, timeout time.Duration) (int64, error) {
+	client := meta.(*providerMeta).client
+	if result.Instance != nil && result.Instance.ID != 0 {
+		return result.Instance.ID, nil
+	}
+	if result.ProcessId == 0 {
+		return 0, fmt.Errorf("the clone request did not return the new instance or the clone process")
+	}
+
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{statusPending},
+		Target:  []string{"created", statusFailed},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.Execute(&morpheus.Request{
+				Method: "GET",
+				Path:   fmt.Sprintf("/api/processes/%d", result.ProcessId),
+				Result: &ProcessResult{},
+			})
+			if err != nil {
+				return "", "", err
+			}
+			process := resp.Result.(*ProcessResult).Process
+			if process == nil {
+				return "", statusPending, nil
+			}
+			if process.InstanceId != 0 {
+				return process, "created", nil
+			}
+			if process.Status == statusFailed {
+				return process, statusFailed, nil
+			}
+			return process, statusPending, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   30 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	processResult, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	process := processResult.(*ProcessHistory)
+	if process.InstanceId == 0 {
+		return 0, fmt.Errorf("clone process %d failed: %s", process.ID, process.summary())
+	}
+	return process.InstanceId, nil
+}
+
+// deleteAppInstance removes an instance of a tier and waits for it to be
+// deleted
+func deleteAppInstance(ctx context.Context, meta interface{}, instanceId int64, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
+	req := &morpheus.Request{}
+	if USE_FORCE {
+		req.QueryParams = map[string]string{"force": "true"}
+	}
+	resp, err := client.DeleteInstance(instanceId, req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			return nil
+		}
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{statusRemoving, statusPendingRemoval, statusStopping, statusPending, statusRunning, statusStopped, statusWarning, statusFailed},
+		Target:  []string{statusRemoved},
+		Refresh: func() (interface{}, string, error) {
+			instanceDetails, err := client.GetInstance(instanceId, &morpheus.Request{})
+			if instanceDetails != nil && instanceDetails.StatusCode == 404 {
+				return "", statusRemoved, nil
+			}
+			if err != nil {
+				return "", "", err
+			}
+			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
+			return result, result.Instance.Status, nil
+		},
+		Timeout:      timeout,
+		MinTimeout:   1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error deleting instance %d: %s", instanceId, err)
+	}
+	return nil
+}
+
+type AppBlueprintConfigPayload struct {
+	Blueprint struct {
+		ID     int64                  `json:"id"`
+		Name   string                 `json:"name"`
+		Type   string                 `json:"type"`
+		Config map[string]interface{} `json:"config"`
+	} `json:"blueprint"`
+}
+
+type App struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Group       struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"group"`
+	Blueprint struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"blueprint"`
+	Tags []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"tags"`
+	AppTiers []struct {
+		Tier struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"tier"`
+		AppInstances []struct {
+			Instance AppInstance `json:"instance"`
+		} `json:"appInstances"`
+	} `json:"appTiers"`
+}
+
+type AppInstance struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type GetAppResult struct {
+	App *App `json:"app"`
+}
+
+type CloneInstanceResult struct {
+	Success  bool   `json:"success"`
+	Msg      string `json:"msg"`
+	Instance *struct {
+		ID int64 `json:"id"`
+	} `json:"instance"`
+	ProcessId int64 `json:"processId"`
+}
+
+type ProcessResult struct {
+	Process *ProcessHistory `json:"process"`
+}
+
+// tierInstances returns the instances of the tier with the given name
+func (app *App) tierInstances(tierName string) []AppInstance {
+	var instances []AppInstance
+	for _, tier := range app.AppTiers {
+		if tier.Tier.Name != tierName {
+			continue
+		}
+		for _, appInstance := range tier.AppInstances {
+			instances = append(instances, appInstance.Instance)
+		}
+	}
+	return instances
+}
+
+// flattenAppTiers builds the tier blocks of an app. The overrides of the
+// configured tiers are not returned by the API and are kept as configured,
+// while the instance counts are read from the app so that a tier that was
+// scaled or removed outside of Terraform shows up as a change. Every tier of
+// the app is returned when no tier is configured, such as after an import.
+func flattenAppTiers(app *App, configuredTiers []interface{}) []interface{} {
+	var tiers []interface{}
+	if len(configuredTiers) == 0 {
+		for _, appTier := range app.AppTiers {
+			tiers = append(tiers, map[string]interface{}{
+				"name":           appTier.Tier.Name,
+				"instance_count": len(app.tierInstances(appTier.Tier.Name)),
+				"config":         "",
+				"instance":       []interface{}{},
+			})
+		}
+		return tiers
+	}
+
+	for _, t := range configuredTiers {
+		configuredTier := t.(map[string]interface{})
+		tier := make(map[string]interface{}, len(configuredTier))
+		for k, v := range configuredTier {
+			tier[k] = v
+		}
+		tier["instance_count"] = len(app.tierInstances(tier["name"].(string)))
+		tiers = append(tiers, tier)
+	}
+	return tiers
+}
@@ -0,0 +1,634 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAppBlueprint() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus app blueprint resource",
+		CreateContext: resourceAppBlueprintCreate,
+		ReadContext:   resourceAppBlueprintRead,
+		UpdateContext: resourceAppBlueprintUpdate,
+		DeleteContext: resourceAppBlueprintDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the app blueprint",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the app blueprint",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the app blueprint",
+				Optional:    true,
+			},
+			"category": {
+				Type:        schema.TypeString,
+				Description: "The category of the app blueprint",
+				Optional:    true,
+			},
+			"tier": {
+				Type:        schema.TypeList,
+				Description: "The tiers of the app blueprint",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the tier",
+							Required:    true,
+						},
+						"tier_index": {
+							Type:        schema.TypeInt,
+							Description: "The position of the tier in the app blueprint, tiers are listed in the order of their index",
+							Optional:    true,
+						},
+						"boot_order": {
+							Type:        schema.TypeInt,
+							Description: "The boot order of the tier, tiers with a lower boot order are provisioned first",
+							Optional:    true,
+						},
+						"linked_tiers": {
+							Type:        schema.TypeList,
+							Description: "The names of the tiers that the tier is linked to",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"instance": {
+							Type:        schema.TypeList,
+							Description: "The instances of the tier",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"instance_type_code": {
+										Type:        schema.TypeString,
+										Description: "The code of the instance type of the instance",
+										Required:    true,
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The name of the instance, which can include variables such as ${sequence}",
+										Optional:    true,
+									},
+									"layout_id": {
+										Type:        schema.TypeInt,
+										Description: "The ID of the instance layout of the instance",
+										Optional:    true,
+									},
+									"plan_id": {
+										Type:        schema.TypeInt,
+										Description: "The ID of the service plan of the instance",
+										Optional:    true,
+									},
+									"config": {
+										Type:             schema.TypeString,
+										Description:      "Additional instance config (JSON) used for every cloud and environment, the instance type, name, layout, plan and cloud configs are set with their own attributes",
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentJsonDiffs,
+										ValidateFunc:     validation.StringIsJSON,
+									},
+									"cloud_config": {
+										Type:        schema.TypeList,
+										Description: "Instance config for a specific cloud, group and optionally environment",
+										Optional:    true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"environment": {
+													Type:        schema.TypeString,
+													Description: "The name of the environment the config applies to, the config applies to every environment when not set",
+													Optional:    true,
+												},
+												"group": {
+													Type:        schema.TypeString,
+													Description: "The name of the group the config applies to",
+													Required:    true,
+												},
+												"cloud": {
+													Type:        schema.TypeString,
+													Description: "The name of the cloud the config applies to",
+													Required:    true,
+												},
+												"config": {
+													Type:             schema.TypeString,
+													Description:      "The instance config (JSON) used when the app is deployed to the cloud",
+													Required:         true,
+													DiffSuppressFunc: suppressEquivalentJsonDiffs,
+													ValidateFunc:     validation.StringIsJSON,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceAppBlueprintCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	blueprintPayload, err := appBlueprintPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"blueprint": blueprintPayload,
+		},
+	}
+
+	resp, err := client.CreateBlueprint(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*morpheus.CreateBlueprintResult)
+	blueprint := result.Blueprint
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(blueprint.ID))
+
+	resourceAppBlueprintRead(ctx, d, meta)
+	return diags
+}
+
+func resourceAppBlueprintRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	name := d.Get("name").(string)
+
+	// lookup by name if we do not have an id yet
+	var resp *morpheus.Response
+	var err error
+	if id == "" && name != "" {
+		resp, err = client.FindBlueprintByName(name)
+	} else if id != "" {
+		resp, err = client.GetBlueprint(toInt64(id), &morpheus.Request{})
+	} else {
+		return diag.Errorf("Blueprint cannot be read without name or id")
+	}
+
+	if err != nil {
+		// 404 is ok?
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	var appBlueprint MorpheusAppBlueprint
+	if err := json.Unmarshal(resp.Body, &appBlueprint); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(int64ToString(appBlueprint.Blueprint.ID))
+	d.Set("name", appBlueprint.Blueprint.Name)
+	d.Set("description", appBlueprint.Blueprint.Description)
+	d.Set("category", appBlueprint.Blueprint.Category)
+	tiers, err := flattenAppBlueprintTiers(appBlueprint.Blueprint.Config.Tiers, d.Get("tier").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("tier", tiers)
+
+	return diags
+}
+
+func resourceAppBlueprintUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	blueprintPayload, err := appBlueprintPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"blueprint": blueprintPayload,
+		},
+	}
+
+	resp, err := client.UpdateBlueprint(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateBlueprintResult)
+	blueprint := result.Blueprint
+	// Successfully updated resource, now set id
+	// err, it should not have changed though..
+	d.SetId(int64ToString(blueprint.ID))
+	return resourceAppBlueprintRead(ctx, d, meta)
+}
+
+func resourceAppBlueprintDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteBlueprint(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// appBlueprintPayload builds the blueprint payload, the tiers are keyed by
+// name in the blueprint config
+func appBlueprintPayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	name := d.Get("name").(string)
+	description := d.Get("description").(string)
+	category := d.Get("category").(string)
+
+	tiers := make(map[string]interface{})
+	for _, t := range d.Get("tier").([]interface{}) {
+		tier := t.(map[string]interface{})
+		tierName := tier["name"].(string)
+		if _, ok := tiers[tierName]; ok {
+			return nil, fmt.Errorf("tier %s is defined more than once", tierName)
+		}
+
+		var instances []map[string]interface{}
+		for _, i := range tier["instance"].([]interface{}) {
+			instance, err := parseAppBlueprintInstance(i.(map[string]interface{}))
+			if err != nil {
+				return nil, fmt.Errorf("invalid instance in tier %s: %s", tierName, err)
+			}
+			instances = append(instances, instance)
+		}
+
+		linkedTiers := make([]string, 0)
+		for _, linkedTier := range tier["linked_tiers"].([]interface{}) {
+			linkedTiers = append(linkedTiers, linkedTier.(string))
+		}
+
+		tiers[tierName] = map[string]interface{}{
+			"tierIndex":   tier["tier_index"].(int),
+			"bootOrder":   tier["boot_order"].(int),
+			"linkedTiers": linkedTiers,
+			"instances":   instances,
+		}
+	}
+
+	config := make(map[string]interface{})
+	config["name"] = name
+	config["description"] = description
+	config["category"] = category
+	config["type"] = "morpheus"
+	config["tiers"] = tiers
+
+	return map[string]interface{}{
+		"name":        name,
+		"type":        "morpheus",
+		"description": description,
+		"category":    category,
+		"config":      config,
+	}, nil
+}
+
+// parseAppBlueprintInstance builds the config of an instance in a tier. The
+// instance config is the default for every cloud, while the cloud configs
+// are nested under the environment, group and cloud names they apply to.
+func parseAppBlueprintInstance(item map[string]interface{}) (map[string]interface{}, error) {
+	instance := make(map[string]interface{})
+	if config := item["config"].(string); config != "" {
+		if err := json.Unmarshal([]byte(config), &instance); err != nil {
+			return nil, err
+		}
+	}
+
+	instanceConfig := map[string]interface{}{
+		"type": item["instance_type_code"].(string),
+	}
+	if item["name"].(string) != "" {
+		instanceConfig["name"] = item["name"].(string)
+	}
+	if item["layout_id"].(int) != 0 {
+		instanceConfig["layout"] = map[string]interface{}{
+			"id": item["layout_id"].(int),
+		}
+	}
+	mergeAppConfig(instance, map[string]interface{}{
+		"instance": instanceConfig,
+	})
+	if item["plan_id"].(int) != 0 {
+		instance["plan"] = map[string]interface{}{
+			"id": item["plan_id"].(int),
+		}
+	}
+
+	for _, c := range item["cloud_config"].([]interface{}) {
+		cloudConfig := c.(map[string]interface{})
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(cloudConfig["config"].(string)), &config); err != nil {
+			return nil, err
+		}
+		override := map[string]interface{}{
+			"groups": map[string]interface{}{
+				cloudConfig["group"].(string): map[string]interface{}{
+					"clouds": map[string]interface{}{
+						cloudConfig["cloud"].(string): config,
+					},
+				},
+			},
+		}
+		if environment := cloudConfig["environment"].(string); environment != "" {
+			override = map[string]interface{}{
+				"environments": map[string]interface{}{
+					environment: override,
+				},
+			}
+		}
+		mergeAppConfig(instance, override)
+	}
+	return instance, nil
+}
+
+// flattenAppBlueprintTiers builds the tier blocks from the tiers of the
+// blueprint config ordered by tier index. The current state is only used to
+// keep the order of the cloud configs of an instance.
+func flattenAppBlueprintTiers(tiers map[string]MorpheusAppBlueprintTier, current []interface{}) ([]interface{}, error) {
+	currentTiers := make(map[string]map[string]interface{})
+	for _, t := range current {
+		tier := t.(map[string]interface{})
+		currentTiers[tier["name"].(string)] = tier
+	}
+
+	names := make([]string, 0, len(tiers))
+	for name := range tiers {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if tiers[names[i]].TierIndex != tiers[names[j]].TierIndex {
+			return tiers[names[i]].TierIndex < tiers[names[j]].TierIndex
+		}
+		return names[i] < names[j]
+	})
+
+	var tierList []interface{}
+	for _, name := range names {
+		tier := tiers[name]
+		var currentInstances []interface{}
+		if currentTier, ok := currentTiers[name]; ok {
+			currentInstances = currentTier["instance"].([]interface{})
+		}
+
+		var instances []interface{}
+		for i, instance := range tier.Instances {
+			instanceData, err := flattenAppBlueprintInstance(instance)
+			if err != nil {
+				return nil, fmt.Errorf("invalid instance in tier %s: %s", name, err)
+			}
+			if i < len(currentInstances) {
+				currentInstance := currentInstances[i].(map[string]interface{})
+				if currentInstance["instance_type_code"] == instanceData["instance_type_code"] {
+					currentCloudConfigs, _ := currentInstance["cloud_config"].([]interface{})
+					instanceData["cloud_config"] = sortAppBlueprintCloudConfigs(instanceData["cloud_config"].([]interface{}), currentCloudConfigs)
+				}
+			}
+			instances = append(instances, instanceData)
+		}
+
+		tierList = append(tierList, map[string]interface{}{
+			"name":         name,
+			"tier_index":   tier.TierIndex,
+			"boot_order":   tier.BootOrder,
+			"linked_tiers": tier.LinkedTiers,
+			"instance":     instances,
+		})
+	}
+	return tierList, nil
+}
+
+// flattenAppBlueprintInstance builds the instance block from the config of
+// an instance in a tier, the reverse of parseAppBlueprintInstance. The
+// settings with their own attribute and the cloud configs are removed from
+// the instance config and the rest is returned as config.
+func flattenAppBlueprintInstance(instance map[string]interface{}) (map[string]interface{}, error) {
+	copied, err := copyAppInstanceConfig(instance)
+	if err != nil {
+		return nil, err
+	}
+	config := copied.(map[string]interface{})
+
+	instanceData := map[string]interface{}{
+		"instance_type_code": "",
+		"name":               "",
+		"layout_id":          0,
+		"plan_id":            0,
+	}
+	if instanceConfig, ok := config["instance"].(map[string]interface{}); ok {
+		instanceData["instance_type_code"], _ = instanceConfig["type"].(string)
+		instanceData["name"], _ = instanceConfig["name"].(string)
+		if layout, ok := instanceConfig["layout"].(map[string]interface{}); ok {
+			instanceData["layout_id"] = appBlueprintConfigId(layout["id"])
+			delete(layout, "id")
+			if len(layout) == 0 {
+				delete(instanceConfig, "layout")
+			}
+		}
+		delete(instanceConfig, "type")
+		delete(instanceConfig, "name")
+		if len(instanceConfig) == 0 {
+			delete(config, "instance")
+		}
+	}
+	if plan, ok := config["plan"].(map[string]interface{}); ok {
+		instanceData["plan_id"] = appBlueprintConfigId(plan["id"])
+		delete(plan, "id")
+		if len(plan) == 0 {
+			delete(config, "plan")
+		}
+	}
+
+	cloudConfigs := []interface{}{}
+	flattenGroups := func(environment string, groups map[string]interface{}) error {
+		for group, g := range groups {
+			groupConfig, ok := g.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			clouds, _ := groupConfig["clouds"].(map[string]interface{})
+			for cloud, c := range clouds {
+				data, err := json.Marshal(c)
+				if err != nil {
+					return err
+				}
+				cloudConfigs = append(cloudConfigs, map[string]interface{}{
+					"environment": environment,
+					"group":       group,
+					"cloud":       cloud,
+					"config":      string(data),
+				})
+			}
+			delete(groupConfig, "clouds")
+			if len(groupConfig) == 0 {
+				delete(groups, group)
+			}
+		}
+		return nil
+	}
+	if groups, ok := config["groups"].(map[string]interface{}); ok {
+		if err := flattenGroups("", groups); err != nil {
+			return nil, err
+		}
+		if len(groups) == 0 {
+			delete(config, "groups")
+		}
+	}
+	if environments, ok := config["environments"].(map[string]interface{}); ok {
+		for environment, e := range environments {
+			environmentConfig, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if groups, ok := environmentConfig["groups"].(map[string]interface{}); ok {
+				if err := flattenGroups(environment, groups); err != nil {
+					return nil, err
+				}
+				if len(groups) == 0 {
+					delete(environmentConfig, "groups")
+				}
+			}
+			if len(environmentConfig) == 0 {
+				delete(environments, environment)
+			}
+		}
+		if len(environments) == 0 {
+			delete(config, "environments")
+		}
+	}
+	instanceData["cloud_config"] = sortAppBlueprintCloudConfigs(cloudConfigs, nil)
+
+	instanceData["config"] = ""
+	if len(config) > 0 {
+		data, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		instanceData["config"] = string(data)
+	}
+	return instanceData, nil
+}
+
+// sortAppBlueprintCloudConfigs orders cloud configs by their position in
+// current, the cloud configs that are not in current follow ordered by
+// environment, group and cloud
+func sortAppBlueprintCloudConfigs(cloudConfigs []interface{}, current []interface{}) []interface{} {
+	key := func(c interface{}) string {
+		cloudConfig := c.(map[string]interface{})
+		return fmt.Sprintf("%s/%s/%s", cloudConfig["environment"], cloudConfig["group"], cloudConfig["cloud"])
+	}
+	positions := make(map[string]int, len(current))
+	for i, c := range current {
+		positions[key(c)] = i
+	}
+	sorted := append([]interface{}{}, cloudConfigs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, iok := positions[key(sorted[i])]
+		pj, jok := positions[key(sorted[j])]
+		if iok && jok {
+			return pi < pj
+		}
+		if iok != jok {
+			return iok
+		}
+		return key(sorted[i]) < key(sorted[j])
+	})
+	return sorted
+}
+
+// appBlueprintConfigId returns an ID of the blueprint config, which is a
+// number or a numeric string
+func appBlueprintConfigId(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		return int(stringToInt64(v))
+	}
+	return 0
+}
+
+type MorpheusAppBlueprint struct {
+	Blueprint struct {
+		ID          int64  `json:"id"`
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Description string `json:"description"`
+		Category    string `json:"category"`
+		Config      struct {
+			Name        string                              `json:"name"`
+			Description string                              `json:"description"`
+			Type        string                              `json:"type"`
+			Category    string                              `json:"category"`
+			Tiers       map[string]MorpheusAppBlueprintTier `json:"tiers"`
+		} `json:"config"`
+		Visibility string `json:"visibility"`
+		Owner      struct {
+			ID       int64  `json:"id"`
+			Username string `json:"username"`
+		} `json:"owner"`
+		Tenant struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"tenant"`
+	} `json:"blueprint"`
+}
+
+type MorpheusAppBlueprintTier struct {
+	TierIndex   int64                    `json:"tierIndex"`
+	BootOrder   int64                    `json:"bootOrder"`
+	LinkedTiers []string                 `json:"linkedTiers"`
+	Instances   []map[string]interface{} `json:"instances"`
+}
@@ -0,0 +1,200 @@
+package morpheus
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testAppBlueprintConfig is the configuration of an app blueprint with a
+// tier that uses every instance setting
+func testAppBlueprintConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":     "tfblueprint",
+		"category": "web",
+		"tier": []interface{}{
+			map[string]interface{}{
+				"name":         "web",
+				"tier_index":   1,
+				"boot_order":   2,
+				"linked_tiers": []interface{}{"db"},
+				"instance": []interface{}{
+					map[string]interface{}{
+						"instance_type_code": "nginx",
+						"name":               "web-${sequence}",
+						"layout_id":          10,
+						"plan_id":            20,
+						"config":             `{"instance":{"hostName":"web"},"volumes":[{"size":20}]}`,
+						"cloud_config": []interface{}{
+							map[string]interface{}{
+								"group":  "dev",
+								"cloud":  "vsphere",
+								"config": `{"config":{"resourcePoolId":1}}`,
+							},
+							map[string]interface{}{
+								"environment": "prod",
+								"group":       "prod",
+								"cloud":       "aws",
+								"config":      `{"config":{"securityGroup":"sg-1"}}`,
+							},
+						},
+					},
+				},
+			},
+			map[string]interface{}{
+				"name":     "db",
+				"instance": []interface{}{map[string]interface{}{"instance_type_code": "mysql"}},
+			},
+		},
+	}
+}
+
+func TestAppBlueprintPayload(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAppBlueprint().Schema, testAppBlueprintConfig())
+	payload, err := appBlueprintPayload(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var expected map[string]interface{}
+	json.Unmarshal([]byte(`{
+		"name": "tfblueprint",
+		"type": "morpheus",
+		"description": "",
+		"category": "web",
+		"config": {
+			"name": "tfblueprint",
+			"type": "morpheus",
+			"description": "",
+			"category": "web",
+			"tiers": {
+				"web": {
+					"tierIndex": 1,
+					"bootOrder": 2,
+					"linkedTiers": ["db"],
+					"instances": [{
+						"instance": {"type": "nginx", "name": "web-${sequence}", "hostName": "web", "layout": {"id": 10}},
+						"plan": {"id": 20},
+						"volumes": [{"size": 20}],
+						"groups": {"dev": {"clouds": {"vsphere": {"config": {"resourcePoolId": 1}}}}},
+						"environments": {"prod": {"groups": {"prod": {"clouds": {"aws": {"config": {"securityGroup": "sg-1"}}}}}}}
+					}]
+				},
+				"db": {
+					"tierIndex": 0,
+					"bootOrder": 0,
+					"linkedTiers": [],
+					"instances": [{"instance": {"type": "mysql"}}]
+				}
+			}
+		}
+	}`), &expected)
+	data, _ := json.Marshal(payload)
+	var actual map[string]interface{}
+	json.Unmarshal(data, &actual)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the payload %s, got %s", mustMarshal(expected), data)
+	}
+
+	config := testAppBlueprintConfig()
+	config["tier"] = append(config["tier"].([]interface{}), map[string]interface{}{"name": "web"})
+	d = schema.TestResourceDataRaw(t, resourceAppBlueprint().Schema, config)
+	if _, err := appBlueprintPayload(d); err == nil || !strings.Contains(err.Error(), "tier web is defined more than once") {
+		t.Fatalf("expected an error for a duplicate tier, got %v", err)
+	}
+}
+
+// testAppBlueprintTiers returns the tiers of the blueprint config sent for
+// the configuration as they are returned by the API
+func testAppBlueprintTiers(t *testing.T, config map[string]interface{}) map[string]MorpheusAppBlueprintTier {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, resourceAppBlueprint().Schema, config)
+	payload, err := appBlueprintPayload(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data, _ := json.Marshal(payload["config"])
+	var blueprintConfig struct {
+		Tiers map[string]MorpheusAppBlueprintTier `json:"tiers"`
+	}
+	if err := json.Unmarshal(data, &blueprintConfig); err != nil {
+		t.Fatalf("unable to decode the tiers: %s", err)
+	}
+	return blueprintConfig.Tiers
+}
+
+func TestFlattenAppBlueprintTiers(t *testing.T) {
+	config := testAppBlueprintConfig()
+	tiers := testAppBlueprintTiers(t, config)
+
+	// The tiers are ordered by tier index and the configs are read from the
+	// blueprint, so they match the configuration without a current state
+	flattened, err := flattenAppBlueprintTiers(tiers, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(flattened) != 2 || flattened[0].(map[string]interface{})["name"] != "db" {
+		t.Fatalf("expected the tiers ordered by tier index, got %v", flattened)
+	}
+	web := flattened[1].(map[string]interface{})["instance"].([]interface{})[0].(map[string]interface{})
+	expectedWeb := config["tier"].([]interface{})[0].(map[string]interface{})["instance"].([]interface{})[0].(map[string]interface{})
+	for _, key := range []string{"instance_type_code", "name", "layout_id", "plan_id"} {
+		if web[key] != expectedWeb[key] {
+			t.Errorf("expected %s %v, got %v", key, expectedWeb[key], web[key])
+		}
+	}
+	if !suppressEquivalentJsonDiffs("", web["config"].(string), expectedWeb["config"].(string), nil) {
+		t.Errorf("expected the config %s, got %s", expectedWeb["config"], web["config"])
+	}
+	cloudConfigs := web["cloud_config"].([]interface{})
+	expectedCloudConfigs := expectedWeb["cloud_config"].([]interface{})
+	if len(cloudConfigs) != len(expectedCloudConfigs) {
+		t.Fatalf("expected %d cloud configs, got %v", len(expectedCloudConfigs), cloudConfigs)
+	}
+	// Without a current state the cloud configs are ordered by environment,
+	// group and cloud, so the config without an environment comes first
+	for i, c := range cloudConfigs {
+		cloudConfig := c.(map[string]interface{})
+		expected := expectedCloudConfigs[i].(map[string]interface{})
+		if cloudConfig["group"] != expected["group"] || cloudConfig["cloud"] != expected["cloud"] ||
+			!suppressEquivalentJsonDiffs("", cloudConfig["config"].(string), expected["config"].(string), nil) {
+			t.Errorf("expected the cloud config %v, got %v", expected, cloudConfig)
+		}
+	}
+	db := flattened[0].(map[string]interface{})["instance"].([]interface{})[0].(map[string]interface{})
+	if db["config"] != "" || len(db["cloud_config"].([]interface{})) != 0 {
+		t.Errorf("expected an instance without config, got %v", db)
+	}
+
+	// The cloud configs keep the order of the current state
+	webTier := config["tier"].([]interface{})[0].(map[string]interface{})
+	instance := webTier["instance"].([]interface{})[0].(map[string]interface{})
+	instance["cloud_config"] = []interface{}{expectedCloudConfigs[1], expectedCloudConfigs[0]}
+	flattened, err = flattenAppBlueprintTiers(tiers, config["tier"].([]interface{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	web = flattened[1].(map[string]interface{})["instance"].([]interface{})[0].(map[string]interface{})
+	if cloud := web["cloud_config"].([]interface{})[0].(map[string]interface{})["cloud"]; cloud != "aws" {
+		t.Errorf("expected the cloud configs in the order of the current state, got %v", web["cloud_config"])
+	}
+
+	// A config changed outside of Terraform is read back
+	tiers["web"].Instances[0]["volumes"] = []interface{}{map[string]interface{}{"size": 40}}
+	flattened, err = flattenAppBlueprintTiers(tiers, config["tier"].([]interface{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	web = flattened[1].(map[string]interface{})["instance"].([]interface{})[0].(map[string]interface{})
+	if suppressEquivalentJsonDiffs("", web["config"].(string), expectedWeb["config"].(string), nil) {
+		t.Errorf("expected the changed config to be read back, got %s", web["config"])
+	}
+}
+
+func mustMarshal(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,183 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testApp decodes an app from its JSON representation
+func testApp(t *testing.T, data string) *App {
+	t.Helper()
+	var app App
+	if err := json.Unmarshal([]byte(data), &app); err != nil {
+		t.Fatalf("unable to decode the app: %s", err)
+	}
+	return &app
+}
+
+func TestAppProvisioningStatus(t *testing.T) {
+	cases := []struct {
+		statuses []string
+		expected string
+	}{
+		{[]string{statusRunning, statusRunning}, statusRunning},
+		{[]string{statusRunning, statusProvisioning}, statusProvisioning},
+		{[]string{statusRunning, statusStopped, statusSuspended}, statusRunning},
+		{[]string{statusRunning, statusWarning}, statusWarning},
+		{[]string{statusWarning, statusProvisioning}, statusProvisioning},
+		{[]string{statusProvisioning, statusFailed}, statusFailed},
+		{[]string{statusRunning, statusDenied}, statusDenied},
+		{[]string{statusPending, statusCancelled}, statusCancelled},
+	}
+	for _, c := range cases {
+		var appInstances []interface{}
+		for _, status := range c.statuses {
+			appInstances = append(appInstances, map[string]interface{}{
+				"instance": map[string]interface{}{"status": status},
+			})
+		}
+		data, _ := json.Marshal(map[string]interface{}{
+			"appTiers": []interface{}{
+				map[string]interface{}{"tier": map[string]interface{}{"name": "web"}, "appInstances": appInstances},
+			},
+		})
+		if status := appProvisioningStatus(testApp(t, string(data))); status != c.expected {
+			t.Errorf("%v: expected %s, got %s", c.statuses, c.expected, status)
+		}
+	}
+
+	if status := appProvisioningStatus(&App{Status: statusRunning}); status != statusRunning {
+		t.Errorf("expected an app without instances to report its own status, got %s", status)
+	}
+}
+
+func TestFlattenAppTiers(t *testing.T) {
+	app := testApp(t, `{
+		"id": 1,
+		"appTiers": [
+			{"tier": {"name": "web"}, "appInstances": [{"instance": {"id": 1}}, {"instance": {"id": 2}}]},
+			{"tier": {"name": "db"}, "appInstances": [{"instance": {"id": 3}}]}
+		]
+	}`)
+
+	tiers := flattenAppTiers(app, nil)
+	expected := []interface{}{
+		map[string]interface{}{"name": "web", "instance_count": 2, "config": "", "instance": []interface{}{}},
+		map[string]interface{}{"name": "db", "instance_count": 1, "config": "", "instance": []interface{}{}},
+	}
+	if !reflect.DeepEqual(tiers, expected) {
+		t.Fatalf("expected every tier of the app %v, got %v", expected, tiers)
+	}
+
+	configured := []interface{}{
+		map[string]interface{}{"name": "web", "instance_count": 3, "config": `{"plan":{"id":1}}`, "instance": []interface{}{}},
+		map[string]interface{}{"name": "cache", "instance_count": 1, "config": "", "instance": []interface{}{}},
+	}
+	tiers = flattenAppTiers(app, configured)
+	expected = []interface{}{
+		map[string]interface{}{"name": "web", "instance_count": 2, "config": `{"plan":{"id":1}}`, "instance": []interface{}{}},
+		map[string]interface{}{"name": "cache", "instance_count": 0, "config": "", "instance": []interface{}{}},
+	}
+	if !reflect.DeepEqual(tiers, expected) {
+		t.Fatalf("expected the configured tiers with the instance counts of the app %v, got %v", expected, tiers)
+	}
+	if configured[0].(map[string]interface{})["instance_count"] != 3 {
+		t.Fatalf("expected the configured tiers not to be modified")
+	}
+}
+
+// testAppCloneAPI emulates the clone of an instance that is answered with
+// cloneResponse, the clone is created with the status running
+func testAppCloneAPI(api *fakeMorpheus, sourceId int64, cloneResponse func(cloneId int64) map[string]interface{}) {
+	api.Handle(http.MethodPut, "/api/instances/"+int64ToString(sourceId)+"/clone", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		cloneId := s.Seed("/api/instances", map[string]interface{}{
+			"name":   body["name"],
+			"status": statusRunning,
+		})
+		return http.StatusOK, cloneResponse(cloneId)
+	})
+	api.Handle(http.MethodPut, "/api/apps/1/add-instance", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		return http.StatusOK, map[string]interface{}{"success": true}
+	})
+}
+
+func TestCloneAppInstance(t *testing.T) {
+	api := newFakeMorpheus(t)
+	meta := api.Meta()
+	sourceId := api.Seed("/api/instances", map[string]interface{}{"name": "web", "status": statusRunning})
+	// An instance with the name of the clone must not be mistaken for it
+	api.Seed("/api/instances", map[string]interface{}{"name": "web-2", "status": statusRunning})
+
+	var cloneId int64
+	testAppCloneAPI(api, sourceId, func(id int64) map[string]interface{} {
+		cloneId = id
+		return map[string]interface{}{
+			"success":  true,
+			"instance": map[string]interface{}{"id": id},
+		}
+	})
+
+	if err := cloneAppInstance(context.Background(), meta, 1, "web", sourceId, "web-2", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	requests := api.Requests(http.MethodPut, "/api/apps/1/add-instance")
+	if len(requests) != 1 || requests[0]["instanceId"] != float64(cloneId) || requests[0]["tierName"] != "web" {
+		t.Fatalf("expected the clone %d to be added to the tier, got %v", cloneId, requests)
+	}
+}
+
+func TestCloneAppInstance_process(t *testing.T) {
+	api := newFakeMorpheus(t)
+	meta := api.Meta()
+	sourceId := api.Seed("/api/instances", map[string]interface{}{"name": "web", "status": statusRunning})
+
+	var mu sync.Mutex
+	var cloneId int64
+	polls := 0
+	testAppCloneAPI(api, sourceId, func(id int64) map[string]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		cloneId = id
+		return map[string]interface{}{"success": true, "processId": 99}
+	})
+	// The process references the new instance once the clone has started
+	api.Handle(http.MethodGet, "/api/processes/99", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		process := map[string]interface{}{"id": 99, "status": "running"}
+		if polls > 2 {
+			process["instanceId"] = cloneId
+		}
+		return http.StatusOK, map[string]interface{}{"process": process}
+	})
+
+	if err := cloneAppInstance(context.Background(), meta, 1, "web", sourceId, "web-2", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	requests := api.Requests(http.MethodPut, "/api/apps/1/add-instance")
+	if len(requests) != 1 || requests[0]["instanceId"] != float64(cloneId) {
+		t.Fatalf("expected the clone %d to be added to the tier, got %v", cloneId, requests)
+	}
+}
+
+func TestCloneAppInstance_noInstance(t *testing.T) {
+	api := newFakeMorpheus(t)
+	meta := api.Meta()
+	sourceId := api.Seed("/api/instances", map[string]interface{}{"name": "web", "status": statusRunning})
+	testAppCloneAPI(api, sourceId, func(id int64) map[string]interface{} {
+		return map[string]interface{}{"success": true}
+	})
+
+	if err := cloneAppInstance(context.Background(), meta, 1, "web", sourceId, "web-2", time.Minute); err == nil {
+		t.Fatalf("expected an error when the clone request does not identify the clone")
+	}
+	if requests := api.Requests(http.MethodPut, "/api/apps/1/add-instance"); len(requests) != 0 {
+		t.Fatalf("expected no instance to be added to the tier, got %v", requests)
+	}
+}
@@ -266,7 +266,7 @@ func resourceApplianceSetting() *schema.Resource {
 }
 
 func resourceApplianceSettingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -405,7 +405,7 @@ func resourceApplianceSettingCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceApplianceSettingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -465,7 +465,7 @@ func resourceApplianceSettingRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceApplianceSettingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	applianceSettings := make(map[string]interface{})
 
@@ -102,7 +102,7 @@ func resourceArmAppBlueprint() *schema.Resource {
 }
 
 func resourceArmAppBlueprintCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -168,7 +168,7 @@ func resourceArmAppBlueprintCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceArmAppBlueprintRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -226,7 +226,7 @@ func resourceArmAppBlueprintRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceArmAppBlueprintUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	name := d.Get("name").(string)
@@ -288,7 +288,7 @@ func resourceArmAppBlueprintUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceArmAppBlueprintDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -70,7 +70,7 @@ func resourceArmSpecTemplate() *schema.Resource {
 }
 
 func resourceArmSpecTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -122,7 +122,7 @@ func resourceArmSpecTemplateCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceArmSpecTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -180,7 +180,7 @@ func resourceArmSpecTemplateRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceArmSpecTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -227,7 +227,7 @@ func resourceArmSpecTemplateUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceArmSpecTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -197,7 +197,7 @@ func resourceAWSCloud() *schema.Resource {
 }
 
 func resourceAWSCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -316,7 +316,7 @@ func resourceAWSCloudCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceAWSCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -394,7 +394,7 @@ func resourceAWSCloudRead(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceAWSCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	cloud := make(map[string]interface{})
 	if d.HasChange("name") {
@@ -516,7 +516,7 @@ func resourceAWSCloudUpdate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceAWSCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -68,9 +68,8 @@ func resourceAwsInstance() *schema.Resource {
 				Required:    true,
 			},
 			"plan_id": {
-				Description: "The service plan associated with the instance",
+				Description: "The service plan associated with the instance, changing the plan resizes the instance in place",
 				Type:        schema.TypeInt,
-				ForceNew:    true,
 				Required:    true,
 			},
 			"resource_pool_id": {
@@ -193,7 +192,7 @@ func resourceAwsInstance() *schema.Resource {
 				},
 			},
 			"volumes": {
-				Description: "The instance volumes to create",
+				Description: "The instance volumes to create, volumes can be added or grown in place while removing or shrinking a volume forces a new instance",
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem: &schema.Resource{
@@ -230,7 +229,7 @@ func resourceAwsInstance() *schema.Resource {
 				},
 			},
 			"interfaces": {
-				Description: "The instance network interfaces to create",
+				Description: "The instance network interfaces to create, interfaces can be added in place while removing an interface forces a new instance",
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem: &schema.Resource{
@@ -248,13 +247,13 @@ func resourceAwsInstance() *schema.Resource {
 							Computed:    true,
 						},
 						"ip_address": {
-							Description: "",
+							Description: "The static IP address to assign to the network interface",
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
 						},
 						"ip_mode": {
-							Description: "",
+							Description: "The IP address assignment mode of the network interface (e.g. static or dhcp)",
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
@@ -294,7 +293,10 @@ func resourceAwsInstance() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
+			"power_state":       powerStateSchema(),
+			"on_create_failure": onCreateFailureSchema(),
 		},
+		CustomizeDiff: instanceResizeForceNew,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -302,7 +304,7 @@ func resourceAwsInstance() *schema.Resource {
 }
 
 func resourceAwsInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -490,6 +492,8 @@ func resourceAwsInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 	log.Printf("API RESPONSE: %s", resp)
 	result := resp.Result.(*morpheus.CreateInstanceResult)
 	instance := result.Instance
+	instanceStatus := "provisioning"
+	pollConfig := meta.(*providerMeta).config
 
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"provisioning", "starting", "stopping", "pending"},
@@ -501,12 +505,13 @@ func resourceAwsInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 			}
 			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
 			instance := result.Instance
+			instanceStatus = instance.Status
 			return result, instance.Status, nil
 		},
-		Timeout:      3 * time.Hour,
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 		MinTimeout:   1 * time.Minute,
-		Delay:        3 * time.Minute,
-		PollInterval: 1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
@@ -517,12 +522,23 @@ func resourceAwsInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	// Successfully created resource, now set id
 	d.SetId(int64ToString(instance.ID))
+
+	// Fail the instance deployment if the
+	// instance status is in a failed state
+	if isProvisioningFailure(instanceStatus) {
+		return handleProvisioningFailure(ctx, d, meta, "instance", instanceStatus, resourceAwsInstanceDelete)
+	}
+
+	// Power off or suspend the instance if requested once it has been provisioned
+	if powerDiags := setInstancePowerState(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); powerDiags.HasError() {
+		return powerDiags
+	}
 	resourceAwsInstanceRead(ctx, d, meta)
 	return diags
 }
 
 func resourceAwsInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -563,6 +579,11 @@ func resourceAwsInstanceRead(ctx context.Context, d *schema.ResourceData, meta i
 	d.SetId(int64ToString(instance.ID))
 	d.Set("name", instance.Name)
 	d.Set("description", instance.Description)
+	// Only report settled power states so that an instance that is
+	// starting or stopping is not seen as drift
+	if powerState := instancePowerState(instance.Status); powerState != "" {
+		d.Set("power_state", powerState)
+	}
 	d.Set("cloud_id", instance.Cloud.ID)
 	d.Set("group_id", instance.Group.ID)
 	d.Set("instance_type_id", instance.InstanceType.ID)
@@ -607,7 +628,7 @@ func resourceAwsInstanceRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceAwsInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -655,13 +676,28 @@ func resourceAwsInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 	log.Printf("API RESPONSE: %s", resp)
 	result := resp.Result.(*morpheus.UpdateInstanceResult)
 	instance := result.Instance
+
+	// Plan, volume and interface changes are applied with a resize
+	if diags := resizeInstance(ctx, d, meta,
+		parseAwsStorageVolumes(d.Get("volumes").([]interface{})),
+		parseAwsNetworkInterfaces(d.Get("interfaces").([]interface{})),
+	); diags.HasError() {
+		return diags
+	}
+
+	if d.HasChange("power_state") {
+		if diags := setInstancePowerState(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
 	// Successfully updated resource, now set id
 	d.SetId(int64ToString(instance.ID))
 	return resourceAwsInstanceRead(ctx, d, meta)
 }
 
 func resourceAwsInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -199,7 +199,7 @@ func resourceAzureCloud() *schema.Resource {
 }
 
 func resourceAzureCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -320,7 +320,7 @@ func resourceAzureCloudCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceAzureCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -401,7 +401,7 @@ func resourceAzureCloudRead(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceAzureCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	cloud := make(map[string]interface{})
 	cloud["name"] = d.Get("name").(string)
@@ -494,7 +494,7 @@ func resourceAzureCloudUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceAzureCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -68,7 +68,7 @@ func resourceBackupSetting() *schema.Resource {
 }
 
 func resourceBackupSettingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -115,7 +115,7 @@ func resourceBackupSettingCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceBackupSettingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -153,7 +153,7 @@ func resourceBackupSettingRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceBackupSettingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	req := &morpheus.Request{
 		Body: map[string]interface{}{
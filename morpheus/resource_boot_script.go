@@ -48,7 +48,7 @@ func resourceBootScript() *schema.Resource {
 }
 
 func resourceBootScriptCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -79,7 +79,7 @@ func resourceBootScriptCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceBootScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -122,7 +122,7 @@ func resourceBootScriptRead(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceBootScriptUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	req := &morpheus.Request{
@@ -149,7 +149,7 @@ func resourceBootScriptUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceBootScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
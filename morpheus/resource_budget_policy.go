@@ -112,7 +112,7 @@ func resourceBudgetPolicy() *schema.Resource {
 }
 
 func resourceBudgetPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -184,7 +184,7 @@ func resourceBudgetPolicyCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceBudgetPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -257,7 +257,7 @@ func resourceBudgetPolicyRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceBudgetPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -327,7 +327,7 @@ func resourceBudgetPolicyUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceBudgetPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -108,7 +108,7 @@ func resourceCheckboxOptionType() *schema.Resource {
 }
 
 func resourceCheckboxOptionTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -164,7 +164,7 @@ func resourceCheckboxOptionTypeCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCheckboxOptionTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -227,7 +227,7 @@ func resourceCheckboxOptionTypeRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCheckboxOptionTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -278,7 +278,7 @@ func resourceCheckboxOptionTypeUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCheckboxOptionTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
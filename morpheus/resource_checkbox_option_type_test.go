@@ -0,0 +1,54 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMorpheusCheckboxOptionType_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	resourceName := "morpheus_checkbox_option_type.tf_example_checkbox_option_type"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFakeCollectionEmpty(api, "/api/library/option-types"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testAccMorpheusCheckboxOptionTypeConfig("Enable Backups", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tfcheckboxdemo"),
+					resource.TestCheckResourceAttr(resourceName, "field_name", "enableBackups"),
+					resource.TestCheckResourceAttr(resourceName, "field_label", "Enable Backups"),
+					resource.TestCheckResourceAttr(resourceName, "default_checked", "false"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testAccMorpheusCheckboxOptionTypeConfig("Backups", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "field_label", "Backups"),
+					resource.TestCheckResourceAttr(resourceName, "default_checked", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMorpheusCheckboxOptionTypeConfig(fieldLabel string, defaultChecked bool) string {
+	return fmt.Sprintf(`
+resource "morpheus_checkbox_option_type" "tf_example_checkbox_option_type" {
+  name            = "tfcheckboxdemo"
+  description     = "Terraform checkbox option type example"
+  field_name      = "enableBackups"
+  field_label     = %q
+  default_checked = %t
+}
+`, fieldLabel, defaultChecked)
+}
@@ -124,7 +124,7 @@ func resourceChefIntegration() *schema.Resource {
 }
 
 func resourceChefIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -196,7 +196,7 @@ func resourceChefIntegrationCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceChefIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -266,7 +266,7 @@ func resourceChefIntegrationRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceChefIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -328,7 +328,7 @@ func resourceChefIntegrationUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceChefIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -0,0 +1,397 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceClusterNamespace() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus Kubernetes cluster namespace resource",
+		CreateContext: resourceClusterNamespaceCreate,
+		ReadContext:   resourceClusterNamespaceRead,
+		UpdateContext: resourceClusterNamespaceUpdate,
+		DeleteContext: resourceClusterNamespaceDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the cluster namespace",
+				Computed:    true,
+			},
+			"cluster_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the cluster to create the namespace in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the cluster namespace",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the cluster namespace",
+				Optional:    true,
+				Computed:    true,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Description: "Whether the cluster namespace is active",
+				Optional:    true,
+				Default:     true,
+			},
+			"all_group_access": {
+				Type:        schema.TypeBool,
+				Description: "Whether all groups will be granted access to the cluster namespace",
+				Optional:    true,
+			},
+			"group_access": {
+				Type:        schema.TypeList,
+				Description: "A list of Morpheus group configuration to enable group access to the cluster namespace",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the Morpheus group to grant access to the cluster namespace",
+							Required:    true,
+						},
+						"default": {
+							Type:        schema.TypeBool,
+							Description: "Whether the cluster namespace will be a default for the associated group",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"tenant_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of tenant ids granted access to the cluster namespace",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"quota": {
+				Type:        schema.TypeList,
+				Description: "The resource quota of the cluster namespace, the values use the Kubernetes quantity format (e.g. 500m or 4Gi)",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"requests_cpu": {
+							Type:        schema.TypeString,
+							Description: "The total CPU that can be requested by pods in the namespace",
+							Optional:    true,
+						},
+						"requests_memory": {
+							Type:        schema.TypeString,
+							Description: "The total memory that can be requested by pods in the namespace",
+							Optional:    true,
+						},
+						"limits_cpu": {
+							Type:        schema.TypeString,
+							Description: "The total CPU limit of pods in the namespace",
+							Optional:    true,
+						},
+						"limits_memory": {
+							Type:        schema.TypeString,
+							Description: "The total memory limit of pods in the namespace",
+							Optional:    true,
+						},
+						"pods": {
+							Type:        schema.TypeInt,
+							Description: "The maximum number of pods in the namespace",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "The status of the cluster namespace",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceClusterNamespaceImport,
+		},
+	}
+}
+
+func resourceClusterNamespaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	clusterId := int64(d.Get("cluster_id").(int))
+
+	namespace := clusterNamespacePayload(d)
+	namespace["name"] = d.Get("name").(string)
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/clusters/%d/namespaces", clusterId),
+		Body: map[string]interface{}{
+			"namespace": namespace,
+		},
+		Result: &GetClusterNamespaceResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetClusterNamespaceResult)
+	if result.Namespace == nil {
+		return diag.Errorf("create operation: cluster namespace not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.Namespace.ID))
+
+	resourceClusterNamespaceRead(ctx, d, meta)
+	return diags
+}
+
+func resourceClusterNamespaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	clusterId := int64(d.Get("cluster_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/clusters/%d/namespaces/%s", clusterId, id),
+		Result: &GetClusterNamespaceResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetClusterNamespaceResult)
+	namespace := result.Namespace
+	if namespace == nil {
+		return diag.Errorf("read operation: cluster namespace not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(namespace.ID))
+	d.Set("name", namespace.Name)
+	d.Set("description", namespace.Description)
+	d.Set("active", namespace.Active)
+	d.Set("status", namespace.Status)
+	d.Set("all_group_access", namespace.ResourcePermission.All)
+	// Group Access
+	var groupAccess []map[string]interface{}
+	for _, group := range namespace.ResourcePermission.Sites {
+		groupAccess = append(groupAccess, map[string]interface{}{
+			"group_id": group.ID,
+			"default":  group.Default,
+		})
+	}
+	d.Set("group_access", groupAccess)
+	// Tenant Access
+	var tenantIds []int64
+	for _, tenant := range namespace.Tenants {
+		tenantIds = append(tenantIds, tenant.ID)
+	}
+	d.Set("tenant_ids", tenantIds)
+	// Quota
+	if len(namespace.Config.Quota) > 0 {
+		quota := map[string]interface{}{
+			"requests_cpu":    clusterNamespaceQuotaString(namespace.Config.Quota["requests.cpu"]),
+			"requests_memory": clusterNamespaceQuotaString(namespace.Config.Quota["requests.memory"]),
+			"limits_cpu":      clusterNamespaceQuotaString(namespace.Config.Quota["limits.cpu"]),
+			"limits_memory":   clusterNamespaceQuotaString(namespace.Config.Quota["limits.memory"]),
+			"pods":            clusterNamespaceQuotaInt(namespace.Config.Quota["pods"]),
+		}
+		d.Set("quota", []interface{}{quota})
+	} else {
+		d.Set("quota", nil)
+	}
+
+	return diags
+}
+
+func resourceClusterNamespaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+	clusterId := int64(d.Get("cluster_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/clusters/%d/namespaces/%s", clusterId, id),
+		Body: map[string]interface{}{
+			"namespace": clusterNamespacePayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceClusterNamespaceRead(ctx, d, meta)
+}
+
+func resourceClusterNamespaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	clusterId := int64(d.Get("cluster_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/clusters/%d/namespaces/%s", clusterId, id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceClusterNamespaceImport imports a namespace using an id in the
+// format cluster_id/namespace_id
+func resourceClusterNamespaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected cluster_id/namespace_id", d.Id())
+	}
+	d.Set("cluster_id", toInt64(parts[0]))
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// clusterNamespaceQuotaString returns a quota value, which the API returns as
+// a string or a number
+func clusterNamespaceQuotaString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// clusterNamespaceQuotaInt returns a quota value that is a count, which the
+// API returns as a number or a numeric string
+func clusterNamespaceQuotaInt(value interface{}) int64 {
+	switch v := value.(type) {
+	case float64:
+		return int64(v)
+	case string:
+		return stringToInt64(v)
+	}
+	return 0
+}
+
+// clusterNamespacePayload builds the settings of a namespace that can be
+// changed after it has been created. As with the other resources that grant
+// group access, the API accepts the group access as resourcePermissions and
+// returns it as resourcePermission.
+func clusterNamespacePayload(d *schema.ResourceData) map[string]interface{} {
+	tenantsPayload := make([]int, 0)
+	if attr, ok := d.GetOk("tenant_ids"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			tenantsPayload = append(tenantsPayload, s.(int))
+		}
+	}
+
+	resourcePermissions := map[string]interface{}{
+		"all":   d.Get("all_group_access").(bool),
+		"sites": parseGroupAccess(d.Get("group_access").([]interface{})),
+	}
+
+	quota := make(map[string]interface{})
+	if quotas := d.Get("quota").([]interface{}); len(quotas) > 0 && quotas[0] != nil {
+		quotaConfig := quotas[0].(map[string]interface{})
+		for key, field := range map[string]string{
+			"requests_cpu":    "requests.cpu",
+			"requests_memory": "requests.memory",
+			"limits_cpu":      "limits.cpu",
+			"limits_memory":   "limits.memory",
+		} {
+			if quotaConfig[key].(string) != "" {
+				quota[field] = quotaConfig[key].(string)
+			}
+		}
+		if quotaConfig["pods"].(int) != 0 {
+			quota["pods"] = quotaConfig["pods"].(int)
+		}
+	}
+
+	return map[string]interface{}{
+		"description": d.Get("description").(string),
+		"active":      d.Get("active").(bool),
+		"config": map[string]interface{}{
+			"quota": quota,
+		},
+		"resourcePermissions": resourcePermissions,
+		"tenantPermissions": map[string]interface{}{
+			"accounts": tenantsPayload,
+		},
+	}
+}
+
+type ClusterNamespace struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	Status             string `json:"status"`
+	Active             bool   `json:"active"`
+	ResourcePermission struct {
+		All   bool `json:"all"`
+		Sites []struct {
+			ID      int64  `json:"id"`
+			Name    string `json:"name"`
+			Default bool   `json:"default"`
+		} `json:"sites"`
+	} `json:"resourcePermission"`
+	Tenants []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"tenants"`
+	Config struct {
+		Quota map[string]interface{} `json:"quota"`
+	} `json:"config"`
+}
+
+type GetClusterNamespaceResult struct {
+	Namespace *ClusterNamespace `json:"namespace"`
+}
@@ -0,0 +1,302 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// fakeClusterNamespace converts a namespace payload to the namespace returned
+// by the API, which accepts the group access as resourcePermissions and the
+// tenants as tenantPermissions but returns them as resourcePermission and
+// tenants
+func fakeClusterNamespace(payload map[string]interface{}) map[string]interface{} {
+	namespace := make(map[string]interface{})
+	for k, v := range payload {
+		switch k {
+		case "resourcePermissions":
+			namespace["resourcePermission"] = v
+		case "tenantPermissions":
+			var tenants []interface{}
+			for _, id := range v.(map[string]interface{})["accounts"].([]interface{}) {
+				tenants = append(tenants, map[string]interface{}{"id": id})
+			}
+			namespace["tenants"] = tenants
+		default:
+			namespace[k] = v
+		}
+	}
+	return namespace
+}
+
+// handleFakeClusterNamespaces emulates the creation and update of the
+// namespaces of a cluster
+func handleFakeClusterNamespaces(api *fakeMorpheus, clusterId int64) {
+	path := "/api/clusters/" + int64ToString(clusterId) + "/namespaces"
+	api.Handle(http.MethodPost, path, func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		namespace := fakeClusterNamespace(body["namespace"].(map[string]interface{}))
+		namespace["status"] = "active"
+		id := s.Seed(path, namespace)
+		s.Handle(http.MethodPut, path+"/"+int64ToString(id), fakeClusterNamespaceUpdate(path, id))
+		object, _ := s.Get(path, id)
+		return http.StatusOK, map[string]interface{}{"success": true, "namespace": object}
+	})
+}
+
+func fakeClusterNamespaceUpdate(path string, id int64) fakeHandler {
+	return func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		s.Update(path, id, fakeClusterNamespace(body["namespace"].(map[string]interface{})))
+		return http.StatusOK, map[string]interface{}{"success": true}
+	}
+}
+
+func testClusterNamespaceConfig(values map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"cluster_id":       1,
+		"name":             "tfnamespace",
+		"description":      "terraform namespace",
+		"all_group_access": true,
+		"group_access": []interface{}{
+			map[string]interface{}{"group_id": 2, "default": true},
+			map[string]interface{}{"group_id": 3, "default": false},
+		},
+		"tenant_ids": []interface{}{4, 5},
+		"quota": []interface{}{
+			map[string]interface{}{
+				"requests_cpu":    "500m",
+				"requests_memory": "1Gi",
+				"limits_cpu":      "2",
+				"limits_memory":   "4Gi",
+				"pods":            20,
+			},
+		},
+	}
+	for k, v := range values {
+		config[k] = v
+	}
+	return config
+}
+
+func TestResourceClusterNamespaceCreate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	handleFakeClusterNamespaces(api, 1)
+	r := resourceClusterNamespace()
+	config := testClusterNamespaceConfig(nil)
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+	if diags := resourceClusterNamespaceCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests(http.MethodPost, "/api/clusters/1/namespaces")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(requests))
+	}
+	namespace := requests[0]["namespace"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"name":        "tfnamespace",
+		"description": "terraform namespace",
+		"active":      true,
+		"config": map[string]interface{}{
+			"quota": map[string]interface{}{
+				"requests.cpu":    "500m",
+				"requests.memory": "1Gi",
+				"limits.cpu":      "2",
+				"limits.memory":   "4Gi",
+				"pods":            float64(20),
+			},
+		},
+		"resourcePermissions": map[string]interface{}{
+			"all": true,
+			"sites": []interface{}{
+				map[string]interface{}{"id": float64(2), "default": true},
+				map[string]interface{}{"id": float64(3), "default": false},
+			},
+		},
+	}
+	for k, v := range expected {
+		if !reflect.DeepEqual(namespace[k], v) {
+			t.Errorf("expected %s to be %v, got %v", k, v, namespace[k])
+		}
+	}
+	accounts := namespace["tenantPermissions"].(map[string]interface{})["accounts"].([]interface{})
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].(float64) < accounts[j].(float64) })
+	if !reflect.DeepEqual(accounts, []interface{}{float64(4), float64(5)}) {
+		t.Errorf("expected the tenants 4 and 5, got %v", accounts)
+	}
+
+	// The group access, tenants and quota are read back from the names the
+	// API returns them under, so planning the same configuration shows no
+	// changes
+	if d.Get("status") != "active" {
+		t.Errorf("expected the status to be read back, got %v", d.Get("status"))
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		for k, attr := range diff.Attributes {
+			t.Errorf("expected no changes, %s changes from %q to %q", k, attr.Old, attr.New)
+		}
+	}
+}
+
+func TestResourceClusterNamespaceUpdate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	handleFakeClusterNamespaces(api, 1)
+	r := resourceClusterNamespace()
+	d := schema.TestResourceDataRaw(t, r.Schema, testClusterNamespaceConfig(nil))
+	if diags := resourceClusterNamespaceCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	config := testClusterNamespaceConfig(map[string]interface{}{
+		"description":      "updated namespace",
+		"all_group_access": false,
+		"group_access": []interface{}{
+			map[string]interface{}{"group_id": 3, "default": true},
+		},
+		"quota": []interface{}{},
+	})
+	updated := schema.TestResourceDataRaw(t, r.Schema, config)
+	updated.SetId(d.Id())
+	if diags := resourceClusterNamespaceUpdate(context.Background(), updated, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests(http.MethodPut, "/api/clusters/1/namespaces/"+d.Id())
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 update request, got %d", len(requests))
+	}
+	if _, ok := requests[0]["namespace"].(map[string]interface{})["name"]; ok {
+		t.Errorf("expected the name not to be sent on update")
+	}
+
+	expected := map[string]interface{}{
+		"description":      "updated namespace",
+		"all_group_access": false,
+		"group_access.#":   1,
+		"quota.#":          0,
+	}
+	for k, v := range expected {
+		if value := updated.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+	if groupId := updated.Get("group_access.0.group_id"); groupId != 3 {
+		t.Errorf("expected the group access to be for group 3, got %v", groupId)
+	}
+	if !updated.Get("group_access.0.default").(bool) {
+		t.Errorf("expected the namespace to be the default for group 3")
+	}
+}
+
+func TestResourceClusterNamespaceRead_quota(t *testing.T) {
+	cases := []struct {
+		name     string
+		quota    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:  "strings",
+			quota: map[string]interface{}{"requests.cpu": "500m", "limits.memory": "4Gi", "pods": "20"},
+			expected: map[string]interface{}{
+				"requests_cpu":    "500m",
+				"requests_memory": "",
+				"limits_cpu":      "",
+				"limits_memory":   "4Gi",
+				"pods":            20,
+			},
+		},
+		{
+			name:  "large numbers",
+			quota: map[string]interface{}{"limits.cpu": 4, "limits.memory": 4294967296, "pods": 1000000},
+			expected: map[string]interface{}{
+				"requests_cpu":    "",
+				"requests_memory": "",
+				"limits_cpu":      "4",
+				"limits_memory":   "4294967296",
+				"pods":            1000000,
+			},
+		},
+	}
+	for _, c := range cases {
+		api := newFakeMorpheus(t)
+		id := api.Seed("/api/clusters/1/namespaces", map[string]interface{}{
+			"name":   "tfnamespace",
+			"config": map[string]interface{}{"quota": c.quota},
+		})
+		d := schema.TestResourceDataRaw(t, resourceClusterNamespace().Schema, map[string]interface{}{"cluster_id": 1})
+		d.SetId(int64ToString(id))
+		if diags := resourceClusterNamespaceRead(context.Background(), d, api.Meta()); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", c.name, diags)
+		}
+		for k, v := range c.expected {
+			if value := d.Get("quota.0." + k); value != v {
+				t.Errorf("%s: expected %s to be %v, got %v", c.name, k, v, value)
+			}
+		}
+	}
+}
+
+func TestResourceClusterNamespaceImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	id := api.Seed("/api/clusters/7/namespaces", map[string]interface{}{
+		"name":               "tfnamespace",
+		"description":        "discovered namespace",
+		"active":             true,
+		"status":             "active",
+		"resourcePermission": map[string]interface{}{"all": false, "sites": []interface{}{map[string]interface{}{"id": 2, "name": "All Clouds", "default": true}}},
+		"tenants":            []interface{}{map[string]interface{}{"id": 4, "name": "tenant"}},
+	})
+
+	r := resourceClusterNamespace()
+	d := r.Data(nil)
+	d.SetId("7/" + int64ToString(id))
+	imported, err := resourceClusterNamespaceImport(context.Background(), d, api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+	d = imported[0]
+	if diags := resourceClusterNamespaceRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != int64ToString(id) {
+		t.Errorf("expected the id %d, got %s", id, d.Id())
+	}
+	expected := map[string]interface{}{
+		"cluster_id":              7,
+		"name":                    "tfnamespace",
+		"description":             "discovered namespace",
+		"group_access.#":          1,
+		"group_access.0.group_id": 2,
+		"tenant_ids.#":            1,
+		"quota.#":                 0,
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+	if requests := api.Requests(http.MethodGet, "/api/clusters/7/namespaces/"+int64ToString(id)); len(requests) != 1 {
+		t.Errorf("expected the namespace to be read from its cluster, got %d requests", len(requests))
+	}
+
+	for _, importId := range []string{"1", "1/", "/2", "1/2/3", ""} {
+		d := r.Data(nil)
+		d.SetId(importId)
+		if _, err := resourceClusterNamespaceImport(context.Background(), d, api.Meta()); err == nil {
+			t.Errorf("expected an error importing %q", importId)
+		}
+	}
+}
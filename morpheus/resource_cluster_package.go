@@ -80,7 +80,7 @@ func resourceClusterPackage() *schema.Resource {
 }
 
 func resourceClusterPackageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -120,7 +120,7 @@ func resourceClusterPackageCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceClusterPackageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -182,7 +182,7 @@ func resourceClusterPackageRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceClusterPackageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	req := &morpheus.Request{
@@ -212,7 +212,7 @@ func resourceClusterPackageUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceClusterPackageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -113,7 +113,7 @@ func resourceClusterResourceNamePolicy() *schema.Resource {
 }
 
 func resourceClusterResourceNamePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -191,7 +191,7 @@ func resourceClusterResourceNamePolicyCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceClusterResourceNamePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -264,7 +264,7 @@ func resourceClusterResourceNamePolicyRead(ctx context.Context, d *schema.Resour
 }
 
 func resourceClusterResourceNamePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -339,7 +339,7 @@ func resourceClusterResourceNamePolicyUpdate(ctx context.Context, d *schema.Reso
 }
 
 func resourceClusterResourceNamePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
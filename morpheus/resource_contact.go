@@ -49,7 +49,7 @@ func resourceContact() *schema.Resource {
 }
 
 func resourceContactCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -82,7 +82,7 @@ func resourceContactCreate(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceContactRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -129,7 +129,7 @@ func resourceContactRead(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourceContactUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	req := &morpheus.Request{
@@ -156,7 +156,7 @@ func resourceContactUpdate(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceContactDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
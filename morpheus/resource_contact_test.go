@@ -0,0 +1,62 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMorpheusContact_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	resourceName := "morpheus_contact.tf_example_contact"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFakeCollectionEmpty(api, "/api/monitoring/contacts"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testAccMorpheusContactConfig("tfcontactdemo", "123-456-7890"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tfcontactdemo"),
+					resource.TestCheckResourceAttr(resourceName, "email_address", "tfcontact@demo.com"),
+					resource.TestCheckResourceAttr(resourceName, "mobile_number", "123-456-7890"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testAccMorpheusContactConfig("tfcontactdemo", "098-765-4321"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mobile_number", "098-765-4321"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMorpheusContactConfig(name string, mobileNumber string) string {
+	return fmt.Sprintf(`
+resource "morpheus_contact" "tf_example_contact" {
+  name          = %q
+  email_address = "tfcontact@demo.com"
+  mobile_number = %q
+}
+`, name, mobileNumber)
+}
+
+// testAccCheckFakeCollectionEmpty verifies that every object in a collection
+// of the fake Morpheus API was removed during the destroy step
+func testAccCheckFakeCollectionEmpty(api *fakeMorpheus, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if count := api.Count(path); count != 0 {
+			return fmt.Errorf("expected all objects in %s to be destroyed, %d remain", path, count)
+		}
+		return nil
+	}
+}
@@ -143,7 +143,7 @@ func resourceCredential() *schema.Resource {
 }
 
 func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -228,7 +228,7 @@ func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -308,7 +308,7 @@ func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	credential := make(map[string]interface{})
 	credential["name"] = d.Get("name").(string)
@@ -383,7 +383,7 @@ func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -119,7 +119,7 @@ func resourceCypherAccessPolicy() *schema.Resource {
 }
 
 func resourceCypherAccessPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -182,7 +182,7 @@ func resourceCypherAccessPolicyCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCypherAccessPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -253,7 +253,7 @@ func resourceCypherAccessPolicyRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCypherAccessPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -313,7 +313,7 @@ func resourceCypherAccessPolicyUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCypherAccessPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -54,7 +54,7 @@ func resourceCypherTFVars() *schema.Resource {
 }
 
 func resourceCypherTFVarsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -87,7 +87,7 @@ func resourceCypherTFVarsCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceCypherTFVarsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -132,7 +132,7 @@ func resourceCypherTFVarsRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceCypherTFVarsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -71,7 +71,7 @@ func resourceDockerRegistryIntegration() *schema.Resource {
 }
 
 func resourceDockerRegistryIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -108,7 +108,7 @@ func resourceDockerRegistryIntegrationCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceDockerRegistryIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -153,7 +153,7 @@ func resourceDockerRegistryIntegrationRead(ctx context.Context, d *schema.Resour
 }
 
 func resourceDockerRegistryIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -187,7 +187,7 @@ func resourceDockerRegistryIntegrationUpdate(ctx context.Context, d *schema.Reso
 }
 
 func resourceDockerRegistryIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
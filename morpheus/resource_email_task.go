@@ -129,7 +129,7 @@ func resourceEmailTask() *schema.Resource {
 }
 
 func resourceEmailTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -203,7 +203,7 @@ func resourceEmailTaskCreate(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceEmailTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -271,7 +271,7 @@ func resourceEmailTaskRead(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceEmailTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	contentConfig := make(map[string]interface{})
@@ -338,7 +338,7 @@ func resourceEmailTaskUpdate(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceEmailTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
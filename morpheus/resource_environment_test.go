@@ -0,0 +1,111 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMorpheusEnvironment_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	resourceName := "morpheus_environment.tf_example_environment"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFakeCollectionEmpty(api, "/api/environments"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testAccMorpheusEnvironmentConfig("Terraform example environment"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-example"),
+					resource.TestCheckResourceAttr(resourceName, "code", "tf-example"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Terraform example environment"),
+					resource.TestCheckResourceAttr(resourceName, "visibility", "private"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testAccMorpheusEnvironmentConfig("Updated environment"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Updated environment"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMorpheusEnvironmentDataSource_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	id := api.Seed("/api/environments", map[string]interface{}{
+		"name":        "production",
+		"code":        "prod",
+		"description": "Production environment",
+		"visibility":  "public",
+		"active":      true,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + `
+data "morpheus_environment" "production" {
+  name = "production"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.morpheus_environment.production", "id", fmt.Sprintf("%d", id)),
+					resource.TestCheckResourceAttr("data.morpheus_environment.production", "code", "prod"),
+					resource.TestCheckResourceAttr("data.morpheus_environment.production", "visibility", "public"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMorpheusEnvironmentsDataSource_pagination(t *testing.T) {
+	api := newFakeMorpheus(t)
+	// Seed more environments than fit on a single page of results
+	count := listPageSize*2 + 5
+	for i := 0; i < count; i++ {
+		api.Seed("/api/environments", map[string]interface{}{
+			"name": fmt.Sprintf("environment-%d", i),
+			"code": fmt.Sprintf("env-%d", i),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + `
+data "morpheus_environments" "all" {
+  sort_ascending = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.morpheus_environments.all", "ids.#", fmt.Sprintf("%d", count)),
+				),
+			},
+		},
+	})
+}
+
+func testAccMorpheusEnvironmentConfig(description string) string {
+	return fmt.Sprintf(`
+resource "morpheus_environment" "tf_example_environment" {
+  name        = "tf-example"
+  code        = "tf-example"
+  description = %q
+  active      = true
+}
+`, description)
+}
@@ -0,0 +1,54 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMorpheusExecuteSchedule_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	resourceName := "morpheus_execute_schedule.tf_example_execute_schedule"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFakeCollectionEmpty(api, "/api/execute-schedules"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testAccMorpheusExecuteScheduleConfig("0 23 * * *", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tfscheduledemo"),
+					resource.TestCheckResourceAttr(resourceName, "time_zone", "America/Denver"),
+					resource.TestCheckResourceAttr(resourceName, "schedule", "0 23 * * *"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testAccMorpheusExecuteScheduleConfig("0 1 * * *", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule", "0 1 * * *"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMorpheusExecuteScheduleConfig(schedule string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "morpheus_execute_schedule" "tf_example_execute_schedule" {
+  name        = "tfscheduledemo"
+  description = "Terraform execute schedule example"
+  enabled     = %t
+  time_zone   = "America/Denver"
+  schedule    = %q
+}
+`, enabled, schedule)
+}
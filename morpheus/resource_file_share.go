@@ -0,0 +1,247 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceFileShare() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus file share resource, the file share is an NFS export or CIFS share that is used as a storage target",
+		CreateContext: resourceFileShareCreate,
+		ReadContext:   resourceFileShareRead,
+		UpdateContext: resourceFileShareUpdate,
+		DeleteContext: resourceFileShareDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the file share",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the file share",
+				Required:    true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the file share (nfs, cifs)",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"nfs", "cifs"}, false),
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Description: "The hostname or IP address of the file server",
+				Required:    true,
+			},
+			"export_folder": {
+				Type:        schema.TypeString,
+				Description: "The exported folder of an NFS file share or the share name of a CIFS file share",
+				Required:    true,
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Description: "The username of the account used to connect to a CIFS file share",
+				Optional:    true,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "The password of the account used to connect to a CIFS file share",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"default_backup_target": {
+				Type:        schema.TypeBool,
+				Description: "Whether the file share is the default target for backups",
+				Optional:    true,
+				Default:     false,
+			},
+			"default_deployment_target": {
+				Type:        schema.TypeBool,
+				Description: "Whether the file share is the default target for deployment archives",
+				Optional:    true,
+				Default:     false,
+			},
+			"default_virtual_image_target": {
+				Type:        schema.TypeBool,
+				Description: "Whether the file share is the default target for virtual images",
+				Optional:    true,
+				Default:     false,
+			},
+			"archive_snapshots": {
+				Type:        schema.TypeBool,
+				Description: "Whether snapshots are archived to the file share",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceFileShareCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/storage-buckets",
+		Body: map[string]interface{}{
+			"storageBucket": fileSharePayload(d),
+		},
+		Result: &GetMorpheusStorageBucketResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusStorageBucketResult)
+	if result.StorageBucket == nil {
+		return diag.Errorf("create operation: file share not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.StorageBucket.ID))
+
+	resourceFileShareRead(ctx, d, meta)
+	return diags
+}
+
+func resourceFileShareRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/storage-buckets/%s", id),
+		Result: &GetMorpheusStorageBucketResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusStorageBucketResult)
+	fileShare := result.StorageBucket
+	if fileShare == nil {
+		return diag.Errorf("read operation: file share not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(fileShare.ID))
+	d.Set("name", fileShare.Name)
+	d.Set("type", fileShare.ProviderType)
+	if host, ok := fileShare.Config["host"].(string); ok {
+		d.Set("host", host)
+	}
+	if exportFolder, ok := fileShare.Config["exportFolder"].(string); ok {
+		d.Set("export_folder", exportFolder)
+	}
+	if username, ok := fileShare.Config["username"].(string); ok {
+		d.Set("username", username)
+	}
+	// The password is masked in the response, so it is kept from the
+	// configuration
+	d.Set("default_backup_target", fileShare.DefaultBackupTarget)
+	d.Set("default_deployment_target", fileShare.DefaultDeploymentTarget)
+	d.Set("default_virtual_image_target", fileShare.DefaultVirtualImageTarget)
+	d.Set("archive_snapshots", fileShare.CopyToStore)
+
+	return diags
+}
+
+func resourceFileShareUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/storage-buckets/%s", id),
+		Body: map[string]interface{}{
+			"storageBucket": fileSharePayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceFileShareRead(ctx, d, meta)
+}
+
+func resourceFileShareDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/storage-buckets/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// fileSharePayload builds the settings of a file share, which Morpheus
+// stores as a storage bucket with the nfs or cifs provider type
+func fileSharePayload(d *schema.ResourceData) map[string]interface{} {
+	config := map[string]interface{}{
+		"host":         d.Get("host").(string),
+		"exportFolder": d.Get("export_folder").(string),
+	}
+	if d.Get("type").(string) == "cifs" {
+		config["username"] = d.Get("username").(string)
+		config["password"] = d.Get("password").(string)
+	}
+
+	return map[string]interface{}{
+		"name":                      d.Get("name").(string),
+		"providerType":              d.Get("type").(string),
+		"defaultBackupTarget":       d.Get("default_backup_target").(bool),
+		"defaultDeploymentTarget":   d.Get("default_deployment_target").(bool),
+		"defaultVirtualImageTarget": d.Get("default_virtual_image_target").(bool),
+		"copyToStore":               d.Get("archive_snapshots").(bool),
+		"config":                    config,
+	}
+}
@@ -541,7 +541,7 @@ func resourceForm() *schema.Resource {
 }
 
 func resourceFormCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -812,7 +812,7 @@ func resourceFormCreate(ctx context.Context, d *schema.ResourceData, meta interf
 }
 
 func resourceFormRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -1001,7 +1001,7 @@ func resourceFormRead(ctx context.Context, d *schema.ResourceData, meta interfac
 }
 
 func resourceFormUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	// create the payload for option types not in a field group
@@ -1264,7 +1264,7 @@ func resourceFormUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 }
 
 func resourceFormDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -1274,10 +1274,9 @@ func resourceFormDelete(ctx context.Context, d *schema.ResourceData, meta interf
 
 	// Check if the form is already in use
 	var inUseCatalogItems []string
-	catalogItemsResp, err := client.ListCatalogItems(&morpheus.Request{
-		QueryParams: map[string]string{
-			"max": "500",
-		},
+	catalogItems, catalogItemsResp, err := listAllPages(client.ListCatalogItems, map[string]string{}, func(resp *morpheus.Response) (*[]morpheus.CatalogItem, *morpheus.MetaResult) {
+		result := resp.Result.(*morpheus.ListCatalogItemsResult)
+		return result.CatalogItems, result.Meta
 	})
 	if err != nil {
 		if catalogItemsResp != nil && catalogItemsResp.StatusCode == 404 {
@@ -1288,9 +1287,7 @@ func resourceFormDelete(ctx context.Context, d *schema.ResourceData, meta interf
 			return diag.FromErr(err)
 		}
 	}
-	result := catalogItemsResp.Result.(*morpheus.ListCatalogItemsResult)
-	catalogItems := result.CatalogItems
-	for _, catalogItem := range *catalogItems {
+	for _, catalogItem := range catalogItems {
 		if catalogItem.Form.ID == toInt64(id) {
 			inUseCatalogItems = append(inUseCatalogItems, catalogItem.Name)
 		}
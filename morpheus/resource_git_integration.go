@@ -110,7 +110,7 @@ func resourceGitIntegration() *schema.Resource {
 }
 
 func resourceGitIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -155,7 +155,7 @@ func resourceGitIntegrationCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGitIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -222,7 +222,7 @@ func resourceGitIntegrationRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceGitIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -264,7 +264,7 @@ func resourceGitIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGitIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -122,7 +122,7 @@ func resourceGroovyScriptTask() *schema.Resource {
 }
 
 func resourceGroovyScriptTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -187,7 +187,7 @@ func resourceGroovyScriptTaskCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGroovyScriptTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -240,7 +240,7 @@ func resourceGroovyScriptTaskRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGroovyScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -299,7 +299,7 @@ func resourceGroovyScriptTaskUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGroovyScriptTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -54,7 +54,7 @@ func resourceMorpheusGroup() *schema.Resource {
 }
 
 func resourceMorpheusGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -126,7 +126,7 @@ func resourceMorpheusGroupCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceMorpheusGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -180,7 +180,7 @@ func resourceMorpheusGroupRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceMorpheusGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	code := d.Get("code").(string)
@@ -244,7 +244,7 @@ func resourceMorpheusGroupUpdate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceMorpheusGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
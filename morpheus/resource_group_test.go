@@ -0,0 +1,55 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMorpheusGroup_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	cloudId := api.Seed("/api/zones", map[string]interface{}{
+		"name": "tfcloud",
+	})
+	resourceName := "morpheus_group.tf_example_group"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFakeCollectionEmpty(api, "/api/groups"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testAccMorpheusGroupConfig("denver", cloudId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tfgroupdemo"),
+					resource.TestCheckResourceAttr(resourceName, "location", "denver"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "cloud_ids.*", fmt.Sprint(cloudId)),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testAccMorpheusGroupConfig("boulder", cloudId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "location", "boulder"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMorpheusGroupConfig(location string, cloudId int64) string {
+	return fmt.Sprintf(`
+resource "morpheus_group" "tf_example_group" {
+  name      = "tfgroupdemo"
+  code      = "tfgroupdemo"
+  location  = %q
+  cloud_ids = [%d]
+}
+`, location, cloudId)
+}
@@ -80,7 +80,7 @@ func resourceGuidanceSetting() *schema.Resource {
 }
 
 func resourceGuidanceSettingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -150,7 +150,7 @@ func resourceGuidanceSettingCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGuidanceSettingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -190,7 +190,7 @@ func resourceGuidanceSettingRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceGuidanceSettingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	guidanceSettings := make(map[string]interface{})
 
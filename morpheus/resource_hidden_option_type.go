@@ -104,7 +104,7 @@ func resourceHiddenOptionType() *schema.Resource {
 }
 
 func resourceHiddenOptionTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -153,7 +153,7 @@ func resourceHiddenOptionTypeCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceHiddenOptionTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -212,7 +212,7 @@ func resourceHiddenOptionTypeRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceHiddenOptionTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -256,7 +256,7 @@ func resourceHiddenOptionTypeUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceHiddenOptionTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
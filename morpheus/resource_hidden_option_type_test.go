@@ -0,0 +1,51 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMorpheusHiddenOptionType_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	resourceName := "morpheus_hidden_option_type.tf_example_hidden_option_type"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFakeCollectionEmpty(api, "/api/library/option-types"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testAccMorpheusHiddenOptionTypeConfig("production"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tfhiddendemo"),
+					resource.TestCheckResourceAttr(resourceName, "field_name", "environment"),
+					resource.TestCheckResourceAttr(resourceName, "default_value", "production"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testAccMorpheusHiddenOptionTypeConfig("staging"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_value", "staging"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMorpheusHiddenOptionTypeConfig(defaultValue string) string {
+	return fmt.Sprintf(`
+resource "morpheus_hidden_option_type" "tf_example_hidden_option_type" {
+  name          = "tfhiddendemo"
+  description   = "Terraform hidden option type example"
+  field_name    = "environment"
+  default_value = %q
+}
+`, defaultValue)
+}
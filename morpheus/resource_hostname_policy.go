@@ -107,7 +107,7 @@ func resourceHostNamePolicy() *schema.Resource {
 }
 
 func resourceHostNamePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -178,7 +178,7 @@ func resourceHostNamePolicyCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceHostNamePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -250,7 +250,7 @@ func resourceHostNamePolicyRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceHostNamePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -318,7 +318,7 @@ func resourceHostNamePolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceHostNamePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
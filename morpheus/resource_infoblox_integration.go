@@ -0,0 +1,319 @@
+package morpheus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceInfobloxIntegration() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides an Infoblox IPAM integration resource",
+		CreateContext: resourceInfobloxIntegrationCreate,
+		ReadContext:   resourceInfobloxIntegrationRead,
+		UpdateContext: resourceInfobloxIntegrationUpdate,
+		DeleteContext: resourceInfobloxIntegrationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The id of the Infoblox integration",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the Infoblox integration",
+				Required:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the Infoblox integration is enabled",
+				Optional:    true,
+				Default:     true,
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Description: "The url of the Infoblox WAPI (e.g. https://infoblox.example.local/wapi/v2.2.1)",
+				Required:    true,
+			},
+			"credential_id": {
+				Description:   "The id of the credential store entry used for authentication",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"username", "password"},
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Description: "The username of the account used to connect to Infoblox",
+				Optional:    true,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "The password of the account used to connect to Infoblox",
+				Optional:    true,
+				Sensitive:   true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					h := sha256.New()
+					h.Write([]byte(new))
+					sha256_hash := hex.EncodeToString(h.Sum(nil))
+					return strings.EqualFold(old, sha256_hash)
+				},
+				DiffSuppressOnRefresh: true,
+			},
+			"network_filter": {
+				Type:        schema.TypeString,
+				Description: "A filter that limits the networks discovered by the integration (e.g. 10.0.0.0/8)",
+				Optional:    true,
+			},
+			"zone_filter": {
+				Type:        schema.TypeString,
+				Description: "A filter that limits the DNS zones discovered by the integration (e.g. example.local)",
+				Optional:    true,
+			},
+			"tenant_match": {
+				Type:        schema.TypeString,
+				Description: "The Infoblox extensible attribute used to match networks to Morpheus tenants",
+				Optional:    true,
+			},
+			"extra_attributes": {
+				Type:        schema.TypeString,
+				Description: "The extensible attributes (JSON) added to the host records created by the integration",
+				Optional:    true,
+			},
+			"inventory_existing": {
+				Type:        schema.TypeBool,
+				Description: "Whether the existing host records in Infoblox are inventoried",
+				Optional:    true,
+				Default:     false,
+			},
+			"pools": {
+				Type:        schema.TypeList,
+				Description: "The IP pools discovered by the Infoblox integration",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the IP pool",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the IP pool",
+							Computed:    true,
+						},
+						"cidr": {
+							Type:        schema.TypeString,
+							Description: "The CIDR of the IP pool",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"zones": {
+				Type:        schema.TypeList,
+				Description: "The DNS zones discovered by the Infoblox integration",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the network domain of the DNS zone",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the DNS zone",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceInfobloxIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integration := make(map[string]interface{})
+
+	integration["type"] = "infoblox"
+	integration["name"] = d.Get("name").(string)
+	integration["enabled"] = d.Get("enabled").(bool)
+	integration["serviceUrl"] = d.Get("url").(string)
+	integration["networkFilter"] = d.Get("network_filter").(string)
+	integration["zoneFilter"] = d.Get("zone_filter").(string)
+	integration["tenantMatch"] = d.Get("tenant_match").(string)
+
+	if d.Get("credential_id").(int) != 0 {
+		credential := make(map[string]interface{})
+		credential["type"] = "username-password"
+		credential["id"] = d.Get("credential_id").(int)
+		integration["credential"] = credential
+	} else {
+		integration["serviceUsername"] = d.Get("username").(string)
+		integration["servicePassword"] = d.Get("password").(string)
+	}
+
+	config := make(map[string]interface{})
+	config["extraAttributes"] = d.Get("extra_attributes").(string)
+	config["inventoryExisting"] = d.Get("inventory_existing").(bool)
+	integration["config"] = config
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"integration": integration,
+		},
+	}
+
+	resp, err := client.CreateIntegration(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*morpheus.CreateIntegrationResult)
+	integrationResult := result.Integration
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(integrationResult.ID))
+
+	resourceInfobloxIntegrationRead(ctx, d, meta)
+	return diags
+}
+
+func resourceInfobloxIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integration, resp, err := getNetworkIntegration(client, d.Id())
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+
+	// store resource data
+	d.SetId(int64ToString(integration.ID))
+	d.Set("name", integration.Name)
+	d.Set("enabled", integration.Enabled)
+	d.Set("url", integration.ServiceUrl)
+	if integration.Credential.ID == 0 {
+		d.Set("username", integration.ServiceUsername)
+		d.Set("password", integration.ServicePasswordHash)
+	} else {
+		d.Set("credential_id", integration.Credential.ID)
+	}
+	d.Set("network_filter", integration.NetworkFilter)
+	d.Set("zone_filter", integration.ZoneFilter)
+	d.Set("tenant_match", integration.TenantMatch)
+	d.Set("extra_attributes", integration.Config.ExtraAttributes)
+	d.Set("inventory_existing", integration.Config.InventoryExisting)
+
+	pools, err := networkIntegrationPools(client, integration.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("pools", pools)
+	zones, err := networkIntegrationZones(client, integration.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("zones", zones)
+
+	return diags
+}
+
+func resourceInfobloxIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	integration := make(map[string]interface{})
+
+	integration["type"] = "infoblox"
+	integration["name"] = d.Get("name").(string)
+	integration["enabled"] = d.Get("enabled").(bool)
+	integration["serviceUrl"] = d.Get("url").(string)
+	integration["networkFilter"] = d.Get("network_filter").(string)
+	integration["zoneFilter"] = d.Get("zone_filter").(string)
+	integration["tenantMatch"] = d.Get("tenant_match").(string)
+
+	if d.Get("credential_id").(int) != 0 {
+		credential := make(map[string]interface{})
+		credential["type"] = "username-password"
+		credential["id"] = d.Get("credential_id").(int)
+		integration["credential"] = credential
+	} else {
+		if d.HasChange("username") {
+			integration["serviceUsername"] = d.Get("username").(string)
+		}
+		if d.HasChange("password") {
+			integration["servicePassword"] = d.Get("password").(string)
+		}
+	}
+
+	config := make(map[string]interface{})
+	config["extraAttributes"] = d.Get("extra_attributes").(string)
+	config["inventoryExisting"] = d.Get("inventory_existing").(bool)
+	integration["config"] = config
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"integration": integration,
+		},
+	}
+
+	resp, err := client.UpdateIntegration(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceInfobloxIntegrationRead(ctx, d, meta)
+}
+
+func resourceInfobloxIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteIntegration(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
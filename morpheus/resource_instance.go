@@ -0,0 +1,904 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceInstance() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus instance resource that can provision any instance type and layout, regardless of the cloud it targets.",
+		CreateContext: resourceInstanceCreate,
+		ReadContext:   resourceInstanceRead,
+		UpdateContext: resourceInstanceUpdate,
+		DeleteContext: resourceInstanceDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the instance",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The user friendly description of the instance",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"cloud_id": {
+				Description: "The ID of the cloud associated with the instance",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"group_id": {
+				Description: "The ID of the group associated with the instance",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"instance_type_id": {
+				Description:  "The id of type of instance to provision, specify this or 'instance_type_code'",
+				Type:         schema.TypeInt,
+				ForceNew:     true,
+				Optional:     true,
+				ExactlyOneOf: []string{"instance_type_id", "instance_type_code"},
+			},
+			"instance_type_code": {
+				Description:  "The code of type of instance to provision, specify this or 'instance_type_id'",
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				ExactlyOneOf: []string{"instance_type_id", "instance_type_code"},
+			},
+			"instance_layout_id": {
+				Description: "The layout to provision the instance from",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"plan_id": {
+				Description: "The service plan associated with the instance",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"resource_pool_id": {
+				Description: "The ID of the resource pool to provision the instance to",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Optional:    true,
+			},
+			"domain_id": {
+				Description: "The ID of the network domain to provision the instance to",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"environment": {
+				Description: "The environment to assign the instance to",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"labels": {
+				Type:        schema.TypeList,
+				Description: "The list of labels to add to the instance",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+			"tags": {
+				Description: "Tags to assign to the instance",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"custom_options": {
+				Description: "Custom options to pass to the instance",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"config": {
+				Description: "Additional provisioning settings passed in the config section of the request, the keys depend on the provision type of the layout (e.g. vmwareFolderId, availabilityZone or securityGroup)",
+				Type:        schema.TypeMap,
+				ForceNew:    true,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"layout_options": {
+				Description: "Values for the option types of the instance type and layout keyed by the option type field name. Required option types without a default value must be set here or in config",
+				Type:        schema.TypeMap,
+				ForceNew:    true,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"workflow_id": {
+				Description:   "The ID of the provisioning workflow to execute (`workflow_name` can be used alternatively, only one is needed)",
+				Type:          schema.TypeInt,
+				ForceNew:      true,
+				Optional:      true,
+				ConflictsWith: []string{"workflow_name"},
+			},
+			"workflow_name": {
+				Description:   "The name of the provisioning workflow to execute (`workflow_id` can be used alternatively, only one is needed)",
+				Type:          schema.TypeString,
+				ForceNew:      true,
+				Optional:      true,
+				ConflictsWith: []string{"workflow_id"},
+			},
+			"create_user": {
+				Description: "Whether to create a user account on the instance that is associated with the provisioning user account",
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+			},
+			"user_group_id": {
+				Description: "The id of the user group associated with the instance",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Optional:    true,
+			},
+			"skip_agent_install": {
+				Description: "Whether to skip installation of the Morpheus agent",
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+			},
+			"evar": {
+				Type:        schema.TypeList,
+				Description: "The environment variables to create",
+				ForceNew:    true,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the environment variable",
+							Optional:    true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "The value of the environment variable",
+							Optional:    true,
+						},
+						"export": {
+							Type:        schema.TypeBool,
+							Description: "Whether the environment variable is exported as an instance tag",
+							Optional:    true,
+						},
+						"masked": {
+							Type:        schema.TypeBool,
+							Description: "Whether the environment variable is masked for security purposes",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"volumes": {
+				Description: "The instance volumes to create",
+				Type:        schema.TypeList,
+				ForceNew:    true,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"root": {
+							Description: "Whether the volume is the root volume of the instance",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"name": {
+							Description: "The name/type of the LV being created",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"size": {
+							Description: "The size of the LV being created",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"size_id": {
+							Description: "The ID of an existing LV to assign to the instance",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"storage_type": {
+							Description: "The ID of the LV type",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"datastore_id": {
+							Description: "The ID of the datastore",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"interfaces": {
+				Description: "The instance network interfaces to create",
+				Type:        schema.TypeList,
+				ForceNew:    true,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_id": {
+							Description: "The network to assign the network interface to",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"network_group": {
+							Description: "Whether the network id provided is for a network group or not",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"ip_address": {
+							Description: "The static IP address to assign to the network interface",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"ip_mode": {
+							Description: "The IP address assignment mode of the network interface (e.g. static or dhcp)",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"network_interface_type_id": {
+							Description: "The network interface type",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"status": {
+				Description: "The status of the instance",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"connection_info": {
+				Description: "Connection information for the instance, a list - this is returned by the API",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Description: "The IP address to connect to",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"port": {
+							Description: "The port to connect to",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the connection protocol",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"power_state":       powerStateSchema(),
+			"on_create_failure": onCreateFailureSchema(),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// Instance Type
+	// The Schema validation will ensure that only one of "instance_type_code" or "instance_type_id" is set
+	// We need the code to create the instance and the id to look up its option types
+	instanceTypeCode := d.Get("instance_type_code").(string)
+	instanceTypeId := int64(d.Get("instance_type_id").(int))
+	if instanceTypeId != 0 {
+		instanceTypeResp, err := client.GetInstanceType(instanceTypeId, &morpheus.Request{})
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", instanceTypeResp, err)
+			return diag.FromErr(err)
+		}
+		instanceTypeResult, ok := instanceTypeResp.Result.(*morpheus.GetInstanceTypeResult)
+		if !ok {
+			return diag.Errorf("Instance Type response is not of type *morpheus.GetInstanceTypeResult")
+		}
+		instanceTypeCode = instanceTypeResult.InstanceType.Code
+	} else {
+		var err error
+		instanceTypeId, err = findInstanceTypeIdByCode(client, instanceTypeCode)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	instanceLayoutId := int64(d.Get("instance_layout_id").(int))
+	optionTypes, err := getInstanceOptionTypes(client, instanceTypeId, instanceLayoutId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Config
+	config := make(map[string]interface{})
+	for key, value := range d.Get("config").(map[string]interface{}) {
+		config[key] = value.(string)
+	}
+
+	// Resource Pool, check for non-zero value
+	if d.Get("resource_pool_id").(int) != 0 {
+		config["resourcePoolId"] = d.Get("resource_pool_id").(int)
+	}
+
+	// Custom Options
+	customOptionsInput := d.Get("custom_options").(map[string]interface{})
+	if len(customOptionsInput) > 0 {
+		customOptions := make(map[string]interface{})
+		for key, value := range customOptionsInput {
+			customOptions[key] = value.(string)
+		}
+		config["customOptions"] = customOptions
+	}
+
+	config["createUser"] = d.Get("create_user").(bool)
+	config["noAgent"] = d.Get("skip_agent_install").(bool)
+
+	instancePayload := map[string]interface{}{
+		"name": d.Get("name").(string),
+		"type": instanceTypeCode,
+		"site": map[string]interface{}{
+			"id": d.Get("group_id").(int),
+		},
+		"plan": map[string]interface{}{
+			"id": d.Get("plan_id").(int),
+		},
+		"layout": map[string]interface{}{
+			"id": instanceLayoutId,
+		},
+	}
+
+	// Description
+	if d.Get("description").(string) != "" {
+		instancePayload["description"] = d.Get("description").(string)
+	}
+
+	// Environment
+	if d.Get("environment").(string) != "" {
+		instancePayload["instanceContext"] = d.Get("environment").(string)
+	}
+
+	// User Group ID
+	if d.Get("user_group_id").(int) != 0 {
+		instancePayload["userGroup"] = map[string]interface{}{
+			"id": d.Get("user_group_id").(int),
+		}
+	}
+
+	// Network Domain
+	if d.Get("domain_id").(int) != 0 {
+		instancePayload["networkDomain"] = map[string]interface{}{
+			"id": d.Get("domain_id").(int),
+		}
+	}
+
+	payload := map[string]interface{}{
+		"zoneId":   d.Get("cloud_id").(int),
+		"instance": instancePayload,
+		"config":   config,
+	}
+
+	// Layout option types
+	err = applyLayoutOptions(payload, optionTypes, d.Get("layout_options").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Tags
+	tagsInput := d.Get("tags").(map[string]interface{})
+	if len(tagsInput) > 0 {
+		var tags []map[string]interface{}
+		for key, value := range tagsInput {
+			tags = append(tags, map[string]interface{}{
+				"name":  key,
+				"value": value.(string),
+			})
+		}
+		payload["tags"] = tags
+	}
+
+	// Labels
+	if len(d.Get("labels").([]interface{})) > 0 {
+		payload["labels"] = d.Get("labels")
+	}
+
+	// Provisioning Workflow
+	if d.Get("workflow_id").(int) != 0 {
+		payload["taskSetId"] = d.Get("workflow_id").(int)
+	}
+	if d.Get("workflow_name").(string) != "" {
+		payload["taskSetName"] = d.Get("workflow_name").(string)
+	}
+
+	// Environment Variables
+	if len(d.Get("evar").([]interface{})) > 0 {
+		payload["evars"] = parseEnvironmentVariables(d.Get("evar").([]interface{}))
+	}
+
+	// Network Interfaces
+	if len(d.Get("interfaces").([]interface{})) > 0 {
+		payload["networkInterfaces"] = parseNetworkInterfaces(d.Get("interfaces").([]interface{}))
+	}
+
+	// Volumes
+	if len(d.Get("volumes").([]interface{})) > 0 {
+		payload["volumes"] = parseStorageVolumes(d.Get("volumes").([]interface{}))
+	}
+
+	req := &morpheus.Request{Body: payload}
+	resp, err := client.CreateInstance(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateInstanceResult)
+	instance := result.Instance
+	instanceStatus := "provisioning"
+	pollConfig := meta.(*providerMeta).config
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"provisioning", "starting", "stopping", "pending"},
+		Target:  []string{"running", "failed", "warning", "denied", "cancelled", "suspended"},
+		Refresh: func() (interface{}, string, error) {
+			instanceDetails, err := client.GetInstance(instance.ID, &morpheus.Request{})
+			if err != nil {
+				return "", "", err
+			}
+			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
+			instance := result.Instance
+			instanceStatus = instance.Status
+			return result, instance.Status, nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		MinTimeout:   1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error creating instance: %s", err)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(instance.ID))
+
+	// Fail the instance deployment if the
+	// instance status is in a failed state
+	if isProvisioningFailure(instanceStatus) {
+		return handleProvisioningFailure(ctx, d, meta, "instance", instanceStatus, resourceInstanceDelete)
+	}
+
+	// Power off or suspend the instance if requested once it has been provisioned
+	if powerDiags := setInstancePowerState(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); powerDiags.HasError() {
+		return powerDiags
+	}
+	resourceInstanceRead(ctx, d, meta)
+	return diags
+}
+
+func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	name := d.Get("name").(string)
+
+	// lookup by name if we do not have an id yet
+	var resp *morpheus.Response
+	var err error
+	if id == "" && name != "" {
+		resp, err = client.FindInstanceByName(name)
+	} else if id != "" {
+		resp, err = client.GetInstance(toInt64(id), &morpheus.Request{})
+	} else {
+		return diag.Errorf("Instance cannot be read without name or id")
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*morpheus.GetInstanceResult)
+	instance := result.Instance
+	if instance == nil {
+		return diag.Errorf("Instance not found in response data.") // should not happen
+	}
+
+	d.SetId(int64ToString(instance.ID))
+	d.Set("name", instance.Name)
+	d.Set("description", instance.Description)
+	// Only report settled power states so that an instance that is
+	// starting or stopping is not seen as drift
+	if powerState := instancePowerState(instance.Status); powerState != "" {
+		d.Set("power_state", powerState)
+	}
+	d.Set("cloud_id", instance.Cloud.ID)
+	d.Set("group_id", instance.Group.ID)
+	d.Set("instance_layout_id", instance.Layout.ID)
+	d.Set("plan_id", instance.Plan.ID)
+	d.Set("environment", instance.Environment)
+	d.Set("labels", instance.Labels)
+	d.Set("status", instance.Status)
+	d.Set("domain_id", instance.NetworkDomain.Id)
+	// Only one of instance_type_id or instance_type_code is set, the code is
+	// stored unless the instance type was specified by id
+	if _, ok := d.GetOk("instance_type_id"); !ok {
+		d.Set("instance_type_code", instance.InstanceType.Code)
+	}
+
+	// Tags
+	tags := make(map[string]interface{})
+	for _, tag := range instance.Tags {
+		tags[tag.Name] = tag.Value
+	}
+	d.Set("tags", tags)
+
+	if _, ok := d.GetOk("custom_options"); ok {
+		d.Set("custom_options", instance.Config["customOptions"])
+	}
+
+	var connectionInfo []map[string]interface{}
+	// Iterate over the array of connection info
+	for i := 0; i < len(instance.ConnectionInfo); i++ {
+		row := make(map[string]interface{})
+		connection := instance.ConnectionInfo[i]
+		row["ip"] = connection.Ip
+		row["port"] = connection.Port
+		row["name"] = connection.Name
+		connectionInfo = append(connectionInfo, row)
+	}
+	d.Set("connection_info", connectionInfo)
+
+	return diags
+}
+
+func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	instancePayload := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"labels":          d.Get("labels"),
+		"instanceContext": d.Get("environment"),
+	}
+
+	// Tags
+	if d.HasChange("tags") {
+		var tags []map[string]interface{}
+		for key, value := range d.Get("tags").(map[string]interface{}) {
+			tags = append(tags, map[string]interface{}{
+				"name":  key,
+				"value": value.(string),
+			})
+		}
+		instancePayload["tags"] = tags
+	}
+
+	// Custom Options
+	if d.HasChange("custom_options") {
+		customOptions := make(map[string]interface{})
+		for key, value := range d.Get("custom_options").(map[string]interface{}) {
+			customOptions[key] = value.(string)
+		}
+		instancePayload["config"] = map[string]interface{}{
+			"customOptions": customOptions,
+		}
+	}
+
+	payload := map[string]interface{}{
+		"instance": instancePayload,
+	}
+	req := &morpheus.Request{Body: payload}
+	resp, err := client.UpdateInstance(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.UpdateInstanceResult)
+	instance := result.Instance
+	if d.HasChange("power_state") {
+		if diags := setInstancePowerState(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
+	// Successfully updated resource, now set id
+	d.SetId(int64ToString(instance.ID))
+	return resourceInstanceRead(ctx, d, meta)
+}
+
+func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{
+		QueryParams: map[string]string{},
+	}
+	if USE_FORCE {
+		req.QueryParams["force"] = "true"
+	}
+	resp, err := client.DeleteInstance(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// Wait for the instance to be removed so that a replacement with the
+	// same name can be created straight away
+	stateConf := retry.StateChangeConf{
+		Delay:        deletePollInterval,
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		PollInterval: deletePollInterval,
+		MinTimeout:   1 * time.Second,
+		Pending:      []string{"200"},
+		Target:       []string{"404"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err = client.GetInstance(toInt64(id), &morpheus.Request{})
+			if err != nil {
+				if resp != nil {
+					return resp, strconv.Itoa(resp.StatusCode), nil
+				}
+				return "", "", err
+			}
+
+			return resp, strconv.Itoa(resp.StatusCode), nil
+		},
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// getInstanceOptionTypes returns the option types (inputs) of an instance
+// type followed by those of the instance layout, an option type attached to
+// both is only returned once
+func getInstanceOptionTypes(client *morpheus.Client, instanceTypeId int64, layoutId int64) ([]LayoutOptionType, error) {
+	instanceTypeOptionTypes, err := getInstanceTypeOptionTypes(client, instanceTypeId)
+	if err != nil {
+		return nil, err
+	}
+	layoutOptionTypes, err := getInstanceLayoutOptionTypes(client, layoutId)
+	if err != nil {
+		return nil, err
+	}
+
+	var optionTypes []LayoutOptionType
+	seen := make(map[string]bool)
+	for _, optionType := range append(instanceTypeOptionTypes, layoutOptionTypes...) {
+		if seen[optionType.FieldName] {
+			continue
+		}
+		seen[optionType.FieldName] = true
+		optionTypes = append(optionTypes, optionType)
+	}
+	return optionTypes, nil
+}
+
+// findInstanceTypeIdByCode returns the id of the instance type with the code
+func findInstanceTypeIdByCode(client *morpheus.Client, code string) (int64, error) {
+	listInstanceTypes := func(req *morpheus.Request) (*morpheus.Response, error) {
+		req.Method = "GET"
+		req.Path = "/api/library/instance-types"
+		req.Result = &ListInstanceTypeCodesResult{}
+		return client.Execute(req)
+	}
+	instanceTypes, resp, err := listAllPages(listInstanceTypes, map[string]string{
+		"code": code,
+	}, func(resp *morpheus.Response) (*[]InstanceTypeCode, *morpheus.MetaResult) {
+		result := resp.Result.(*ListInstanceTypeCodesResult)
+		return result.InstanceTypes, result.Meta
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return 0, err
+	}
+
+	for _, instanceType := range instanceTypes {
+		if instanceType.Code == code {
+			return instanceType.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("instance type with code %s not found", code)
+}
+
+// getInstanceTypeOptionTypes returns the option types (inputs) attached to
+// an instance type
+func getInstanceTypeOptionTypes(client *morpheus.Client, instanceTypeId int64) ([]LayoutOptionType, error) {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/library/instance-types/%d", instanceTypeId),
+		Result: &InstanceTypeOptionTypesPayload{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resp.Result.(*InstanceTypeOptionTypesPayload).InstanceType.OptionTypes, nil
+}
+
+// getInstanceLayoutOptionTypes returns the option types (inputs) attached to
+// an instance layout
+func getInstanceLayoutOptionTypes(client *morpheus.Client, layoutId int64) ([]LayoutOptionType, error) {
+	resp, err := client.GetInstanceLayout(layoutId, &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var layout LayoutOptionTypesPayload
+	if err := json.Unmarshal(resp.Body, &layout); err != nil {
+		return nil, err
+	}
+	return layout.InstanceLayout.OptionTypes, nil
+}
+
+// applyLayoutOptions adds the layout option values to the provisioning
+// payload at the location given by the field context of each option type.
+// Option types that are not set fall back to their default value and an
+// error is returned for any required option type that has no value.
+func applyLayoutOptions(payload map[string]interface{}, optionTypes []LayoutOptionType, values map[string]interface{}) error {
+	config := payload["config"].(map[string]interface{})
+	known := make(map[string]bool)
+	var missing []string
+
+	for _, optionType := range optionTypes {
+		known[optionType.FieldName] = true
+		value, ok := values[optionType.FieldName]
+		if !ok {
+			if _, inConfig := config[optionType.FieldName]; inConfig {
+				continue
+			}
+			if optionType.DefaultValue != nil && fmt.Sprintf("%v", optionType.DefaultValue) != "" {
+				value = optionType.DefaultValue
+			} else if optionType.Required {
+				missing = append(missing, fmt.Sprintf("%s (%s)", optionType.FieldName, optionType.FieldLabel))
+				continue
+			} else {
+				continue
+			}
+		}
+		setPayloadValue(payload, optionType.FieldContext, optionType.FieldName, value)
+	}
+
+	var unknown []string
+	for key := range values {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	if len(unknown) > 0 {
+		return fmt.Errorf("layout_options contains field names that are not option types of the layout: %s", strings.Join(unknown, ", "))
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the layout requires values for the following option types, set them in layout_options or config: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// setPayloadValue sets a value in a nested payload, creating any missing
+// intermediate objects from the dot separated field context and name
+func setPayloadValue(payload map[string]interface{}, fieldContext string, fieldName string, value interface{}) {
+	path := strings.Split(fieldName, ".")
+	if fieldContext != "" {
+		path = append(strings.Split(fieldContext, "."), path...)
+	}
+	current := payload
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[key] = next
+		}
+		current = next
+	}
+	current[path[len(path)-1]] = value
+}
+
+type LayoutOptionTypesPayload struct {
+	InstanceLayout struct {
+		ID          int64              `json:"id"`
+		OptionTypes []LayoutOptionType `json:"optionTypes"`
+	} `json:"instanceTypeLayout"`
+}
+
+type InstanceTypeOptionTypesPayload struct {
+	InstanceType struct {
+		ID          int64              `json:"id"`
+		OptionTypes []LayoutOptionType `json:"optionTypes"`
+	} `json:"instanceType"`
+}
+
+type InstanceTypeCode struct {
+	ID   int64  `json:"id"`
+	Code string `json:"code"`
+}
+
+type ListInstanceTypeCodesResult struct {
+	InstanceTypes *[]InstanceTypeCode  `json:"instanceTypes"`
+	Meta          *morpheus.MetaResult `json:"meta"`
+}
+
+type LayoutOptionType struct {
+	ID           int64       `json:"id"`
+	Name         string      `json:"name"`
+	FieldName    string      `json:"fieldName"`
+	FieldContext string      `json:"fieldContext"`
+	FieldLabel   string      `json:"fieldLabel"`
+	Required     bool        `json:"required"`
+	DefaultValue interface{} `json:"defaultValue"`
+}
@@ -0,0 +1,393 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	snapshotStatusComplete = "complete"
+	snapshotStatusCreating = "creating"
+	snapshotStatusFailed   = "failed"
+)
+
+func resourceInstanceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus instance snapshot resource.",
+		CreateContext: resourceInstanceSnapshotCreate,
+		ReadContext:   resourceInstanceSnapshotRead,
+		UpdateContext: resourceInstanceSnapshotUpdate,
+		DeleteContext: resourceInstanceSnapshotDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the instance snapshot",
+				Computed:    true,
+			},
+			"instance_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the instance to snapshot",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the instance snapshot",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the instance snapshot",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"revert_trigger": {
+				Type:        schema.TypeString,
+				Description: "An arbitrary value that reverts the instance to the snapshot whenever it is changed, for example a timestamp or a change ticket number",
+				Optional:    true,
+			},
+			"snapshot_type": {
+				Type:        schema.TypeString,
+				Description: "The type of the instance snapshot",
+				Computed:    true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "The status of the instance snapshot",
+				Computed:    true,
+			},
+			"date_created": {
+				Type:        schema.TypeString,
+				Description: "The date the instance snapshot was created",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceInstanceSnapshotImport,
+		},
+	}
+}
+
+func resourceInstanceSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	instanceId := int64(d.Get("instance_id").(int))
+	name := d.Get("name").(string)
+
+	// Snapshot names are not unique, so the snapshots that already exist are
+	// recorded to tell them apart from the one being created
+	existingSnapshots, resp, err := listInstanceSnapshots(client, instanceId)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	existingIds := make(map[int64]bool)
+	for _, snapshot := range existingSnapshots {
+		existingIds[snapshot.ID] = true
+	}
+
+	resp, err = client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/instances/%d/snapshot", instanceId),
+		Body: map[string]interface{}{
+			"snapshot": map[string]interface{}{
+				"name":        name,
+				"description": d.Get("description").(string),
+			},
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// The snapshot is taken by a background process, so wait for a new
+	// snapshot with the requested name to be listed on the instance and
+	// complete
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{snapshotStatusCreating, statusPending},
+		Target:  []string{snapshotStatusComplete, snapshotStatusFailed},
+		Refresh: func() (interface{}, string, error) {
+			snapshots, resp, err := listInstanceSnapshots(client, instanceId)
+			if err != nil {
+				log.Printf("API FAILURE: %s - %s", resp, err)
+				return "", "", err
+			}
+			snapshot := findNewInstanceSnapshot(snapshots, name, existingIds)
+			if snapshot == nil {
+				return "", statusPending, nil
+			}
+			return snapshot, snapshot.Status, nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		MinTimeout:   30 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	snapshotResult, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error creating instance snapshot: %s", err)
+	}
+	snapshot := snapshotResult.(*InstanceSnapshot)
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(snapshot.ID))
+
+	if snapshot.Status == snapshotStatusFailed {
+		return diag.Errorf("error creating instance snapshot: snapshot %d of instance %d is in a %s state", snapshot.ID, instanceId, snapshot.Status)
+	}
+
+	resourceInstanceSnapshotRead(ctx, d, meta)
+	return diags
+}
+
+func resourceInstanceSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/snapshots/%s", id),
+		Result: &GetInstanceSnapshotResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetInstanceSnapshotResult)
+	snapshot := result.Snapshot
+	if snapshot == nil {
+		return diag.Errorf("read operation: instance snapshot not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(snapshot.ID))
+	d.Set("name", snapshot.Name)
+	d.Set("description", snapshot.Description)
+	d.Set("snapshot_type", snapshot.SnapshotType)
+	d.Set("status", snapshot.Status)
+	d.Set("date_created", snapshot.DateCreated)
+
+	return diags
+}
+
+func resourceInstanceSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+	instanceId := int64(d.Get("instance_id").(int))
+
+	if d.HasChange("revert_trigger") {
+		resp, err := client.Execute(&morpheus.Request{
+			Method: "PUT",
+			Path:   fmt.Sprintf("/api/instances/%d/revert-snapshot/%s", instanceId, id),
+			Result: &morpheus.StandardResult{},
+		})
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+		log.Printf("API RESPONSE: %s", resp)
+
+		// Wait for the instance to settle after being reverted
+		pollConfig := meta.(*providerMeta).config
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{"reverting", statusPending, statusStarting, statusStopping},
+			Target:  instancePowerStates,
+			Refresh: func() (interface{}, string, error) {
+				instanceDetails, err := client.GetInstance(instanceId, &morpheus.Request{})
+				if err != nil {
+					return "", "", err
+				}
+				result := instanceDetails.Result.(*morpheus.GetInstanceResult)
+				instance := result.Instance
+				return result, instance.Status, nil
+			},
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+			MinTimeout:   30 * time.Second,
+			Delay:        pollConfig.PollDelay,
+			PollInterval: pollConfig.PollInterval,
+		}
+
+		_, err = stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return diag.Errorf("error reverting instance %d to snapshot %s: %s", instanceId, id, err)
+		}
+	}
+
+	return resourceInstanceSnapshotRead(ctx, d, meta)
+}
+
+func resourceInstanceSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/snapshots/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// The snapshot is removed by a background process
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"200"},
+		Target:  []string{"404"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.Execute(&morpheus.Request{
+				Method: "GET",
+				Path:   fmt.Sprintf("/api/snapshots/%s", id),
+				Result: &GetInstanceSnapshotResult{},
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == 404 {
+					return resp, "404", nil
+				}
+				return "", "", err
+			}
+			return resp, "200", nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		MinTimeout:   10 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error deleting instance snapshot: %s", err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// resourceInstanceSnapshotImport imports a snapshot using an id in the
+// format instance_id/snapshot_id. The snapshot does not reference its
+// instance, so the instance_id is taken from the import id once the snapshot
+// is found on the instance.
+func resourceInstanceSnapshotImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*providerMeta).client
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected instance_id/snapshot_id", d.Id())
+	}
+	instanceId := toInt64(parts[0])
+
+	snapshots, resp, err := listInstanceSnapshots(client, instanceId)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	found := false
+	for _, snapshot := range snapshots {
+		if int64ToString(snapshot.ID) == parts[1] {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("snapshot %s was not found on instance %d", parts[1], instanceId)
+	}
+
+	d.Set("instance_id", instanceId)
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// listInstanceSnapshots returns every snapshot of an instance
+func listInstanceSnapshots(client *morpheus.Client, instanceId int64) ([]InstanceSnapshot, *morpheus.Response, error) {
+	return listAllPages(func(req *morpheus.Request) (*morpheus.Response, error) {
+		req.Method = "GET"
+		req.Path = fmt.Sprintf("/api/instances/%d/snapshots", instanceId)
+		req.Result = &ListInstanceSnapshotsResult{}
+		return client.Execute(req)
+	}, map[string]string{}, func(resp *morpheus.Response) (*[]InstanceSnapshot, *morpheus.MetaResult) {
+		result := resp.Result.(*ListInstanceSnapshotsResult)
+		return result.Snapshots, result.Meta
+	})
+}
+
+// findNewInstanceSnapshot returns the most recent snapshot with a name that
+// is not one of the existing snapshots
+func findNewInstanceSnapshot(snapshots []InstanceSnapshot, name string, existingIds map[int64]bool) *InstanceSnapshot {
+	var match *InstanceSnapshot
+	for i := range snapshots {
+		if existingIds[snapshots[i].ID] {
+			continue
+		}
+		if snapshots[i].Name == name && (match == nil || snapshots[i].ID > match.ID) {
+			match = &snapshots[i]
+		}
+	}
+	return match
+}
+
+type InstanceSnapshot struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	ExternalId      string `json:"externalId"`
+	Status          string `json:"status"`
+	State           string `json:"state"`
+	SnapshotType    string `json:"snapshotType"`
+	SnapshotCreated string `json:"snapshotCreated"`
+	CurrentlyActive bool   `json:"currentlyActive"`
+	DateCreated     string `json:"dateCreated"`
+}
+
+type GetInstanceSnapshotResult struct {
+	Snapshot *InstanceSnapshot `json:"snapshot"`
+}
+
+type ListInstanceSnapshotsResult struct {
+	Snapshots *[]InstanceSnapshot  `json:"snapshots"`
+	Meta      *morpheus.MetaResult `json:"meta"`
+}
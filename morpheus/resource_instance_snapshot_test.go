@@ -0,0 +1,131 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testInstanceSnapshotAPI emulates the snapshot endpoints of an instance, a
+// snapshot request stores a complete snapshot that is listed on the instance
+func testInstanceSnapshotAPI(api *fakeMorpheus, instanceId int64) {
+	instancePath := "/api/instances/" + int64ToString(instanceId)
+	api.Handle(http.MethodGet, instancePath+"/snapshots", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		snapshots := []interface{}{}
+		for _, snapshot := range s.Objects("/api/snapshots") {
+			if snapshot["instanceId"] == float64(instanceId) {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+		return http.StatusOK, map[string]interface{}{
+			"snapshots": snapshots,
+			"meta":      map[string]interface{}{"size": len(snapshots), "total": len(snapshots)},
+		}
+	})
+	api.Handle(http.MethodPut, instancePath+"/snapshot", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		payload := body["snapshot"].(map[string]interface{})
+		s.Seed("/api/snapshots", map[string]interface{}{
+			"name":       payload["name"],
+			"status":     snapshotStatusComplete,
+			"instanceId": instanceId,
+		})
+		return http.StatusOK, map[string]interface{}{"success": true}
+	})
+}
+
+func TestFindNewInstanceSnapshot(t *testing.T) {
+	snapshots := []InstanceSnapshot{
+		{ID: 1, Name: "tfsnapshot"},
+		{ID: 3, Name: "other"},
+		{ID: 2, Name: "tfsnapshot"},
+	}
+	if snapshot := findNewInstanceSnapshot(snapshots, "tfsnapshot", map[int64]bool{}); snapshot == nil || snapshot.ID != 2 {
+		t.Fatalf("expected the most recent snapshot with the name, got %v", snapshot)
+	}
+	if snapshot := findNewInstanceSnapshot(snapshots, "tfsnapshot", map[int64]bool{1: true, 2: true}); snapshot != nil {
+		t.Fatalf("expected the existing snapshots to be ignored, got %v", snapshot)
+	}
+}
+
+func TestResourceInstanceSnapshotCreate_existingName(t *testing.T) {
+	api := newFakeMorpheus(t)
+	instanceId := api.Seed("/api/instances", map[string]interface{}{"name": "tfinstance"})
+	testInstanceSnapshotAPI(api, instanceId)
+	// A later snapshot with the same name would be picked if the snapshots
+	// that existed before the request were not ignored
+	api.Seed("/api/snapshots", map[string]interface{}{
+		"name":       "tfsnapshot",
+		"status":     snapshotStatusComplete,
+		"instanceId": instanceId,
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceInstanceSnapshot().Schema, map[string]interface{}{
+		"instance_id": int(instanceId),
+		"name":        "tfsnapshot",
+	})
+	if diags := resourceInstanceSnapshotCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	snapshots := api.Objects("/api/snapshots")
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(snapshots))
+	}
+	expectedId := int64(snapshots[1]["id"].(float64))
+	if d.Id() != int64ToString(expectedId) {
+		t.Fatalf("expected the new snapshot %d, got %s", expectedId, d.Id())
+	}
+}
+
+func TestResourceInstanceSnapshotImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	meta := api.Meta()
+	instanceId := api.Seed("/api/instances", map[string]interface{}{"name": "tfinstance"})
+	otherInstanceId := api.Seed("/api/instances", map[string]interface{}{"name": "other"})
+	testInstanceSnapshotAPI(api, instanceId)
+	testInstanceSnapshotAPI(api, otherInstanceId)
+	snapshotId := api.Seed("/api/snapshots", map[string]interface{}{
+		"name":       "tfsnapshot",
+		"status":     snapshotStatusComplete,
+		"instanceId": instanceId,
+	})
+
+	r := resourceInstanceSnapshot()
+	d := r.Data(nil)
+	d.SetId(int64ToString(instanceId) + "/" + int64ToString(snapshotId))
+	imported, err := resourceInstanceSnapshotImport(context.Background(), d, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d = imported[0]
+	if diags := resourceInstanceSnapshotRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != int64ToString(snapshotId) {
+		t.Fatalf("expected the id %d, got %s", snapshotId, d.Id())
+	}
+	if d.Get("instance_id").(int) != int(instanceId) {
+		t.Fatalf("expected the instance_id %d, got %d", instanceId, d.Get("instance_id").(int))
+	}
+	if d.Get("name").(string) != "tfsnapshot" {
+		t.Fatalf("expected the name tfsnapshot, got %s", d.Get("name").(string))
+	}
+
+	d = r.Data(nil)
+	d.SetId(int64ToString(otherInstanceId) + "/" + int64ToString(snapshotId))
+	if _, err := resourceInstanceSnapshotImport(context.Background(), d, meta); err == nil {
+		t.Fatalf("expected an error for a snapshot of another instance")
+	}
+}
+
+func TestResourceInstanceSnapshotDelete_notFound(t *testing.T) {
+	api := newFakeMorpheus(t)
+	d := schema.TestResourceDataRaw(t, resourceInstanceSnapshot().Schema, map[string]interface{}{})
+	d.SetId("42")
+
+	if diags := resourceInstanceSnapshotDelete(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("expected a snapshot that no longer exists to be treated as deleted: %v", diags)
+	}
+}
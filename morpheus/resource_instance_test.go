@@ -0,0 +1,184 @@
+package morpheus
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSetPayloadValue(t *testing.T) {
+	payload := map[string]interface{}{
+		"config": map[string]interface{}{
+			"existing": "value",
+		},
+	}
+	setPayloadValue(payload, "config", "customOptions.size", "large")
+	setPayloadValue(payload, "", "instance.name", "tfinstance")
+	setPayloadValue(payload, "", "zoneId", 1)
+
+	expected := map[string]interface{}{
+		"config": map[string]interface{}{
+			"existing": "value",
+			"customOptions": map[string]interface{}{
+				"size": "large",
+			},
+		},
+		"instance": map[string]interface{}{
+			"name": "tfinstance",
+		},
+		"zoneId": 1,
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Fatalf("expected %v, got %v", expected, payload)
+	}
+}
+
+func TestApplyLayoutOptions(t *testing.T) {
+	optionTypes := []LayoutOptionType{
+		{FieldName: "template", FieldContext: "config", FieldLabel: "Template", Required: true},
+		{FieldName: "hostName", FieldContext: "instance", FieldLabel: "Hostname", DefaultValue: "tfhost"},
+		{FieldName: "folder", FieldContext: "config", FieldLabel: "Folder"},
+		{FieldName: "resourcePoolId", FieldContext: "config", FieldLabel: "Resource Pool", Required: true},
+	}
+
+	t.Run("values", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"config": map[string]interface{}{
+				"resourcePoolId": 1,
+			},
+			"instance": map[string]interface{}{},
+		}
+		err := applyLayoutOptions(payload, optionTypes, map[string]interface{}{
+			"template": "ubuntu-22",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := map[string]interface{}{
+			"config": map[string]interface{}{
+				"resourcePoolId": 1,
+				"template":       "ubuntu-22",
+			},
+			"instance": map[string]interface{}{
+				"hostName": "tfhost",
+			},
+		}
+		if !reflect.DeepEqual(payload, expected) {
+			t.Fatalf("expected %v, got %v", expected, payload)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"config": map[string]interface{}{},
+		}
+		err := applyLayoutOptions(payload, optionTypes, map[string]interface{}{})
+		if err == nil {
+			t.Fatalf("expected an error for the required option types without a value")
+		}
+		expected := "the layout requires values for the following option types, set them in layout_options or config: template (Template), resourcePoolId (Resource Pool)"
+		if err.Error() != expected {
+			t.Fatalf("expected %q, got %q", expected, err.Error())
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"config": map[string]interface{}{},
+		}
+		err := applyLayoutOptions(payload, optionTypes, map[string]interface{}{
+			"template":       "ubuntu-22",
+			"resourcePoolId": "1",
+			"datastore":      "ds1",
+		})
+		if err == nil || !strings.Contains(err.Error(), "not option types of the layout: datastore") {
+			t.Fatalf("expected an error for the unknown field name, got %v", err)
+		}
+	})
+}
+
+func TestGetInstanceOptionTypes(t *testing.T) {
+	api := newFakeMorpheus(t)
+	client := api.Meta().(*providerMeta).client
+	instanceTypeId := api.Seed("/api/library/instance-types", map[string]interface{}{
+		"code": "tfinstancetype",
+		"optionTypes": []interface{}{
+			map[string]interface{}{"fieldName": "application", "fieldContext": "config"},
+			map[string]interface{}{"fieldName": "template", "fieldContext": "config"},
+		},
+	})
+	layoutId := api.Seed("/api/library/layouts", map[string]interface{}{
+		"optionTypes": []interface{}{
+			map[string]interface{}{"fieldName": "template", "fieldContext": "config"},
+			map[string]interface{}{"fieldName": "folder", "fieldContext": "config"},
+		},
+	})
+
+	foundId, err := findInstanceTypeIdByCode(client, "tfinstancetype")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if foundId != instanceTypeId {
+		t.Fatalf("expected instance type %d, got %d", instanceTypeId, foundId)
+	}
+
+	optionTypes, err := getInstanceOptionTypes(client, instanceTypeId, layoutId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var fieldNames []string
+	for _, optionType := range optionTypes {
+		fieldNames = append(fieldNames, optionType.FieldName)
+	}
+	expected := []string{"application", "template", "folder"}
+	if !reflect.DeepEqual(fieldNames, expected) {
+		t.Fatalf("expected option types %v, got %v", expected, fieldNames)
+	}
+}
+
+func TestResourceInstanceDelete_notFound(t *testing.T) {
+	api := newFakeMorpheus(t)
+	d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{})
+	d.SetId("42")
+
+	if diags := resourceInstanceDelete(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("expected an instance that no longer exists to be treated as deleted: %v", diags)
+	}
+}
+
+func TestResourceInstanceDelete_pollsQuickly(t *testing.T) {
+	api := newFakeMorpheus(t)
+	instanceId := api.Seed("/api/instances", map[string]interface{}{"name": "tfinstance"})
+
+	// The removal of the instance is not checked at the provider poll
+	// settings, which would hold up every destroy
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":           api.Server.URL,
+		"access_token":  fakeMorpheusAccessToken,
+		"poll_delay":    "1m",
+		"poll_interval": "2m",
+	}))
+	if diags.HasError() {
+		t.Fatalf("unable to configure the provider: %v", diags)
+	}
+	t.Cleanup(func() { p.Meta().(*providerMeta).config.Close() })
+
+	d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{})
+	d.SetId(int64ToString(instanceId))
+	start := time.Now()
+	if diags := resourceInstanceDelete(context.Background(), d, p.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("expected the deleted instance to be checked every second, the delete took %s", elapsed)
+	}
+	if count := api.Count("/api/instances"); count != 0 {
+		t.Fatalf("expected the instance to be deleted, got %d instances", count)
+	}
+}
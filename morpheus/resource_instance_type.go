@@ -176,7 +176,7 @@ func resourceInstanceType() *schema.Resource {
 }
 
 func resourceInstanceTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -262,7 +262,7 @@ func resourceInstanceTypeCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceInstanceTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -359,7 +359,7 @@ func resourceInstanceTypeRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceInstanceTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	name := d.Get("name").(string)
@@ -436,7 +436,7 @@ func resourceInstanceTypeUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceInstanceTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
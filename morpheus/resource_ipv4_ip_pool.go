@@ -2,6 +2,7 @@ package morpheus
 
 import (
 	"context"
+	"encoding/json"
 	"sort"
 
 	"log"
@@ -9,6 +10,7 @@ import (
 	"github.com/gomorpheus/morpheus-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceIPv4IPPool() *schema.Resource {
@@ -46,9 +48,48 @@ func resourceIPv4IPPool() *schema.Resource {
 							Description: "The ending address of the IPv4 IP address pool IP range",
 							Required:    true,
 						},
+						"description": {
+							Type:        schema.TypeString,
+							Description: "The description of the IPv4 IP address pool IP range",
+							Optional:    true,
+						},
 					},
 				},
 			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Description: "The gateway of the IPv4 IP address pool",
+				Optional:    true,
+			},
+			"netmask": {
+				Type:        schema.TypeString,
+				Description: "The netmask of the IPv4 IP address pool",
+				Optional:    true,
+			},
+			"dns_servers": {
+				Type:        schema.TypeList,
+				Description: "The DNS servers of the IPv4 IP address pool",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dns_search_path": {
+				Type:        schema.TypeString,
+				Description: "The DNS search path of the IPv4 IP address pool",
+				Optional:    true,
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Description:  "Whether the IPv4 IP address pool is visible in sub-tenants or not",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public"}, false),
+				Default:      "private",
+			},
+			"tenant_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of tenant ids the IPv4 IP address pool is shared with",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -57,19 +98,13 @@ func resourceIPv4IPPool() *schema.Resource {
 }
 
 func resourceIPv4IPPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
 	req := &morpheus.Request{
-		Body: map[string]interface{}{
-			"networkPool": map[string]interface{}{
-				"name":     d.Get("name").(string),
-				"type":     "morpheus",
-				"ipRanges": parseIPPoolRanges(d.Get("ip_range").([]interface{})),
-			},
-		},
+		Body: ipv4IPPoolPayload(d, parseIPPoolRanges(d.Get("ip_range").([]interface{}))),
 	}
 	resp, err := client.CreateNetworkPool(req)
 	if err != nil {
@@ -87,7 +122,7 @@ func resourceIPv4IPPoolCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceIPv4IPPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -121,21 +156,20 @@ func resourceIPv4IPPoolRead(ctx context.Context, d *schema.ResourceData, meta in
 	log.Printf("API RESPONSE: %s", resp)
 
 	// store resource data
-	result := resp.Result.(*morpheus.GetNetworkPoolResult)
-	pool := result.NetworkPool
+	var poolDetails IPv4IPPoolPayload
+	if err := json.Unmarshal(resp.Body, &poolDetails); err != nil {
+		return diag.FromErr(err)
+	}
+	pool := poolDetails.NetworkPool
 	d.SetId(int64ToString(pool.ID))
 	d.Set("name", pool.Name)
+	d.Set("gateway", pool.Gateway)
+	d.Set("netmask", pool.Netmask)
+	d.Set("dns_servers", pool.DnsServers)
+	d.Set("dns_search_path", pool.DnsSearchPath)
+	d.Set("visibility", pool.Visibility)
 	var ipRanges []map[string]interface{}
-	var unsortedRanges []IPRange
-	if pool.IpRanges != nil {
-		for _, iprange := range pool.IpRanges {
-			var IPR IPRange
-			IPR.ID = iprange.ID
-			IPR.EndAddress = iprange.EndAddress
-			IPR.StartAddress = iprange.StartAddress
-			unsortedRanges = append(unsortedRanges, IPR)
-		}
-	}
+	unsortedRanges := pool.IpRanges
 	sort.Slice(unsortedRanges, func(i, j int) bool { return unsortedRanges[i].ID < unsortedRanges[j].ID })
 
 	// iterate over the array of IP ranges
@@ -144,23 +178,41 @@ func resourceIPv4IPPoolRead(ctx context.Context, d *schema.ResourceData, meta in
 		rangePayload := make(map[string]interface{})
 		rangePayload["ending_address"] = ipRange.EndAddress
 		rangePayload["starting_address"] = ipRange.StartAddress
+		rangePayload["description"] = ipRange.Description
 		ipRanges = append(ipRanges, rangePayload)
 	}
 	d.Set("ip_range", ipRanges)
+	// tenant ids
+	var tenantIds []int64
+	for _, tenant := range pool.Tenants {
+		tenantIds = append(tenantIds, tenant.ID)
+	}
+	d.Set("tenant_ids", tenantIds)
 	return diags
 }
 
 func resourceIPv4IPPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
+
+	// Existing ranges are matched by their addresses so that they are
+	// updated rather than replaced, which would release the addresses in them
+	ipRanges := parseIPPoolRanges(d.Get("ip_range").([]interface{}))
+	if d.HasChange("ip_range") {
+		resp, err := client.GetNetworkPool(toInt64(id), &morpheus.Request{})
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+		var poolDetails IPv4IPPoolPayload
+		if err := json.Unmarshal(resp.Body, &poolDetails); err != nil {
+			return diag.FromErr(err)
+		}
+		matchIPPoolRanges(ipRanges, poolDetails.NetworkPool.IpRanges)
+	}
+
 	req := &morpheus.Request{
-		Body: map[string]interface{}{
-			"networkPool": map[string]interface{}{
-				"name":     d.Get("name").(string),
-				"type":     "morpheus",
-				"ipRanges": parseIPPoolRanges(d.Get("ip_range").([]interface{})),
-			},
-		},
+		Body: ipv4IPPoolPayload(d, ipRanges),
 	}
 	resp, err := client.UpdateNetworkPool(toInt64(id), req)
 	if err != nil {
@@ -177,7 +229,7 @@ func resourceIPv4IPPoolUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceIPv4IPPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -211,6 +263,8 @@ func parseIPPoolRanges(variables []interface{}) []map[string]interface{} {
 				row["startAddress"] = v.(string)
 			case "ending_address":
 				row["endAddress"] = v.(string)
+			case "description":
+				row["description"] = v.(string)
 			}
 		}
 		poolRanges = append(poolRanges, row)
@@ -218,8 +272,76 @@ func parseIPPoolRanges(variables []interface{}) []map[string]interface{} {
 	return poolRanges
 }
 
+// matchIPPoolRanges sets the ID of the existing range with the same starting
+// and ending address on each range, ranges without an exact match are
+// created as new ranges
+func matchIPPoolRanges(ipRanges []map[string]interface{}, existingRanges []IPRange) {
+	matched := make(map[int64]bool)
+	for _, ipRange := range ipRanges {
+		for _, existingRange := range existingRanges {
+			if matched[existingRange.ID] {
+				continue
+			}
+			if existingRange.StartAddress == ipRange["startAddress"] && existingRange.EndAddress == ipRange["endAddress"] {
+				ipRange["id"] = existingRange.ID
+				matched[existingRange.ID] = true
+				break
+			}
+		}
+	}
+}
+
+// ipv4IPPoolPayload builds the request body of an IPv4 IP pool
+func ipv4IPPoolPayload(d *schema.ResourceData, ipRanges []map[string]interface{}) map[string]interface{} {
+	dnsServers := make([]string, 0)
+	for _, dnsServer := range d.Get("dns_servers").([]interface{}) {
+		dnsServers = append(dnsServers, dnsServer.(string))
+	}
+
+	tenantsPayload := make([]int, 0)
+	if attr, ok := d.GetOk("tenant_ids"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			tenantsPayload = append(tenantsPayload, s.(int))
+		}
+	}
+
+	return map[string]interface{}{
+		"networkPool": map[string]interface{}{
+			"name":          d.Get("name").(string),
+			"type":          "morpheus",
+			"ipRanges":      ipRanges,
+			"gateway":       d.Get("gateway").(string),
+			"netmask":       d.Get("netmask").(string),
+			"dnsServers":    dnsServers,
+			"dnsSearchPath": d.Get("dns_search_path").(string),
+			"visibility":    d.Get("visibility").(string),
+		},
+		"tenantPermissions": map[string]interface{}{
+			"accounts": tenantsPayload,
+		},
+	}
+}
+
 type IPRange struct {
 	ID           int64  `json:"id"`
 	StartAddress string `json:"startAddress"`
 	EndAddress   string `json:"endAddress"`
+	Description  string `json:"description"`
+}
+
+type IPv4IPPoolPayload struct {
+	NetworkPool struct {
+		ID            int64     `json:"id"`
+		Name          string    `json:"name"`
+		Gateway       string    `json:"gateway"`
+		Netmask       string    `json:"netmask"`
+		DnsServers    []string  `json:"dnsServers"`
+		DnsSearchPath string    `json:"dnsSearchPath"`
+		Visibility    string    `json:"visibility"`
+		IpRanges      []IPRange `json:"ipRanges"`
+		Tenants       []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"tenants"`
+	} `json:"networkPool"`
 }
@@ -0,0 +1,231 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceIPv4IPPoolAddress() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus IPv4 ip pool address resource, which reserves or statically assigns an IP address in an IPv4 IP address pool",
+		CreateContext: resourceIPv4IPPoolAddressCreate,
+		ReadContext:   resourceIPv4IPPoolAddressRead,
+		UpdateContext: resourceIPv4IPPoolAddressUpdate,
+		DeleteContext: resourceIPv4IPPoolAddressDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the IP address",
+				Computed:    true,
+			},
+			"pool_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the IPv4 IP address pool to create the IP address in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Description: "The IP address to reserve or assign, the next available address in the pool is used when not set",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "The hostname associated with the IP address",
+				Optional:    true,
+				Computed:    true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the IP address (reserved, static)",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"reserved", "static"}, false),
+				Default:      "reserved",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the IP address",
+				Optional:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIPv4IPPoolAddressImport,
+		},
+	}
+}
+
+func resourceIPv4IPPoolAddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	poolId := int64(d.Get("pool_id").(int))
+
+	poolIp := map[string]interface{}{
+		"ipType":      d.Get("type").(string),
+		"hostname":    d.Get("hostname").(string),
+		"description": d.Get("description").(string),
+	}
+	if ipAddress := d.Get("ip_address").(string); ipAddress != "" {
+		poolIp["ipAddress"] = ipAddress
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/networks/pools/%d/ips", poolId),
+		Body: map[string]interface{}{
+			"networkPoolIp": poolIp,
+		},
+		Result: &GetNetworkPoolIpResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetNetworkPoolIpResult)
+	if result.NetworkPoolIp == nil {
+		return diag.Errorf("create operation: IP address not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.NetworkPoolIp.ID))
+
+	resourceIPv4IPPoolAddressRead(ctx, d, meta)
+	return diags
+}
+
+func resourceIPv4IPPoolAddressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	poolId := int64(d.Get("pool_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/networks/pools/%d/ips/%s", poolId, id),
+		Result: &GetNetworkPoolIpResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetNetworkPoolIpResult)
+	poolIp := result.NetworkPoolIp
+	if poolIp == nil {
+		return diag.Errorf("read operation: IP address not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(poolIp.ID))
+	d.Set("ip_address", poolIp.IpAddress)
+	d.Set("hostname", poolIp.Hostname)
+	d.Set("type", poolIp.IpType)
+	d.Set("description", poolIp.Description)
+	return diags
+}
+
+func resourceIPv4IPPoolAddressUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+	poolId := int64(d.Get("pool_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/networks/pools/%d/ips/%s", poolId, id),
+		Body: map[string]interface{}{
+			"networkPoolIp": map[string]interface{}{
+				"hostname":    d.Get("hostname").(string),
+				"description": d.Get("description").(string),
+			},
+		},
+		Result: &GetNetworkPoolIpResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceIPv4IPPoolAddressRead(ctx, d, meta)
+}
+
+func resourceIPv4IPPoolAddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	poolId := int64(d.Get("pool_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/networks/pools/%d/ips/%s", poolId, id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceIPv4IPPoolAddressImport imports an IP address using an id in the
+// format pool_id/ip_id
+func resourceIPv4IPPoolAddressImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected pool_id/ip_id", d.Id())
+	}
+	d.Set("pool_id", toInt64(parts[0]))
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+type NetworkPoolIp struct {
+	ID          int64  `json:"id"`
+	IpAddress   string `json:"ipAddress"`
+	Hostname    string `json:"hostname"`
+	IpType      string `json:"ipType"`
+	Description string `json:"description"`
+	NetworkPool struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"networkPool"`
+}
+
+type GetNetworkPoolIpResult struct {
+	NetworkPoolIp *NetworkPoolIp `json:"networkPoolIp"`
+}
@@ -0,0 +1,69 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMatchIPPoolRanges(t *testing.T) {
+	existingRanges := []IPRange{
+		{ID: 1, StartAddress: "10.0.0.10", EndAddress: "10.0.0.20"},
+		{ID: 2, StartAddress: "10.0.0.30", EndAddress: "10.0.0.40"},
+	}
+	ipRanges := []map[string]interface{}{
+		{"startAddress": "10.0.0.30", "endAddress": "10.0.0.40"},
+		{"startAddress": "10.0.0.10", "endAddress": "10.0.0.25"},
+		{"startAddress": "10.0.0.30", "endAddress": "10.0.0.40"},
+	}
+	matchIPPoolRanges(ipRanges, existingRanges)
+
+	expected := []map[string]interface{}{
+		{"id": int64(2), "startAddress": "10.0.0.30", "endAddress": "10.0.0.40"},
+		{"startAddress": "10.0.0.10", "endAddress": "10.0.0.25"},
+		{"startAddress": "10.0.0.30", "endAddress": "10.0.0.40"},
+	}
+	if !reflect.DeepEqual(ipRanges, expected) {
+		t.Fatalf("expected %v, got %v", expected, ipRanges)
+	}
+}
+
+func TestResourceIPv4IPPoolUpdate_ranges(t *testing.T) {
+	api := newFakeMorpheus(t)
+	poolId := api.Seed("/api/networks/pools", map[string]interface{}{
+		"name": "tfpool",
+		"ipRanges": []interface{}{
+			map[string]interface{}{"id": 101, "startAddress": "10.0.0.10", "endAddress": "10.0.0.20"},
+			map[string]interface{}{"id": 102, "startAddress": "10.0.0.30", "endAddress": "10.0.0.40"},
+		},
+	})
+
+	// The ranges are reordered and the first range is changed, only the
+	// unchanged range keeps its ID
+	d := schema.TestResourceDataRaw(t, resourceIPv4IPPool().Schema, map[string]interface{}{
+		"name": "tfpool",
+		"ip_range": []interface{}{
+			map[string]interface{}{"starting_address": "10.0.0.30", "ending_address": "10.0.0.40"},
+			map[string]interface{}{"starting_address": "10.0.0.10", "ending_address": "10.0.0.25"},
+		},
+	})
+	d.SetId(int64ToString(poolId))
+	if diags := resourceIPv4IPPoolUpdate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests(http.MethodPut, "/api/networks/pools/"+int64ToString(poolId))
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 update request, got %d", len(requests))
+	}
+	ipRanges := requests[0]["networkPool"].(map[string]interface{})["ipRanges"].([]interface{})
+	if id := ipRanges[0].(map[string]interface{})["id"]; id != float64(102) {
+		t.Errorf("expected the unchanged range to keep the id 102, got %v", id)
+	}
+	if id, ok := ipRanges[1].(map[string]interface{})["id"]; ok {
+		t.Errorf("expected the changed range to be sent without an id, got %v", id)
+	}
+}
@@ -95,7 +95,7 @@ func resourceJavaScriptTask() *schema.Resource {
 }
 
 func resourceJavaScriptTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -149,7 +149,7 @@ func resourceJavaScriptTaskCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceJavaScriptTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -198,7 +198,7 @@ func resourceJavaScriptTaskRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceJavaScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	taskOptions := make(map[string]interface{})
@@ -247,7 +247,7 @@ func resourceJavaScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceJavaScriptTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
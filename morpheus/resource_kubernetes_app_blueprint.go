@@ -90,7 +90,7 @@ func resourceKubernetesAppBlueprint() *schema.Resource {
 }
 
 func resourceKubernetesAppBlueprintCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -169,7 +169,7 @@ func resourceKubernetesAppBlueprintCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceKubernetesAppBlueprintRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -238,7 +238,7 @@ func resourceKubernetesAppBlueprintRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceKubernetesAppBlueprintUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	blueprint_type := "kubernetes"
@@ -312,7 +312,7 @@ func resourceKubernetesAppBlueprintUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceKubernetesAppBlueprintDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -0,0 +1,518 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKubernetesCluster() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus Kubernetes cluster resource for any cluster layout, such as MKS on MVM or Amazon, EKS or AKS",
+		CreateContext: resourceKubernetesClusterCreate,
+		ReadContext:   resourceKubernetesClusterRead,
+		UpdateContext: resourceKubernetesClusterUpdate,
+		DeleteContext: resourceKubernetesClusterDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the cluster",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"api_endpoint": {
+				Description: "The API URL of the cluster",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"kubernetes_version": {
+				Description:      "The Kubernetes version of the cluster, changing the version upgrades the cluster in place",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressKubernetesVersionDiff,
+			},
+			"name": {
+				Description: "The name of the cluster",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The user friendly description of the cluster",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"cluster_type": {
+				Description: "The code of the cluster type (e.g. kubernetes-cluster, eks-cluster or aks-cluster)",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     "kubernetes-cluster",
+			},
+			"cloud_id": {
+				Description: "The ID of the cloud associated with the cluster",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"group_id": {
+				Description: "The ID of the group associated with the cluster",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"cluster_layout_id": {
+				Description: "The ID of the cluster layout to provision the cluster from, changing the layout upgrades the cluster in place to the Kubernetes version of the new layout",
+				Type:        schema.TypeInt,
+				Required:    true,
+			},
+			"resource_prefix": {
+				Description: "The prefix used for the virtual machine name of the master and worker nodes",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+			},
+			"hostname_prefix": {
+				Description: "The prefix used for the guest operating system hostname of the master and worker nodes",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+			},
+			"api_proxy_id": {
+				Description: "The ID of the api proxy associated with the cluster",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Optional:    true,
+			},
+			"pod_cidr": {
+				Description: "The cluster pod cidr (default - 172.20.0.0/16)",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "172.20.0.0/16",
+			},
+			"service_cidr": {
+				Description: "The cluster service cidr (default - 172.30.0.0/16)",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "172.30.0.0/16",
+			},
+			"cluster_repo_account_id": {
+				Description: "The ID of the cluster repo account associated with the cluster",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Optional:    true,
+			},
+			"workflow_id": {
+				Description: "The ID of the provisioning workflow to execute",
+				Type:        schema.TypeInt,
+				ForceNew:    true,
+				Optional:    true,
+			},
+			"config": {
+				Description: "Additional provisioning settings passed in the config section of the request, the keys depend on the provision type of the cluster layout (e.g. availabilityZone, securityGroup or resourceGroup)",
+				Type:        schema.TypeMap,
+				ForceNew:    true,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"layout_options": {
+				Description: "Values for the option types of the cluster layout keyed by the option type field name. Required option types of the layout without a default value must be set here or in config",
+				Type:        schema.TypeMap,
+				ForceNew:    true,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"master_node_pool": {
+				Type:        schema.TypeList,
+				Description: "Master node pool configuration",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"plan_id": {
+							Description: "The ID of the service plan associated with the master nodes in the cluster, changing the plan resizes the master nodes one at a time",
+							Type:        schema.TypeInt,
+							Required:    true,
+						},
+						"resource_pool_id": {
+							Description: "The ID of the resource pool to provision the cluster master nodes to",
+							Type:        schema.TypeInt,
+							ForceNew:    true,
+							Optional:    true,
+							Computed:    true,
+						},
+						"storage_volume": clusterStorageVolumeSchema("The storage volumes to create for the cluster master nodes"),
+						"network_interface": {
+							Description: "The network interfaces to create for the cluster master nodes",
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"network_id": {
+										Description: "The ID of the network to assign the network interface to",
+										Type:        schema.TypeInt,
+										ForceNew:    true,
+										Required:    true,
+									},
+								},
+							},
+						},
+						"tags": {
+							Description: "Tags to assign to the cluster master nodes",
+							Type:        schema.TypeMap,
+							ForceNew:    true,
+							Optional:    true,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"worker_node_pool": {
+				Type:        schema.TypeList,
+				Description: "Worker node pool configuration",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"count": {
+							Description:  "The number of worker nodes",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"plan_id": {
+							Description: "The ID of the service plan associated with the worker nodes in the cluster, changing the plan replaces the worker nodes one at a time by adding a node with the new plan before removing a node with the old plan",
+							Type:        schema.TypeInt,
+							Required:    true,
+						},
+						"resource_pool_id": {
+							Description: "The ID of the resource pool to provision the cluster worker nodes to",
+							Type:        schema.TypeInt,
+							ForceNew:    true,
+							Optional:    true,
+							Computed:    true,
+						},
+						"tags": {
+							Description: "Tags to assign to the cluster worker nodes, changing the tags updates the tags of the existing worker nodes in place",
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"storage_volume": clusterStorageVolumeSchema("The storage volumes to create for the cluster worker nodes"),
+						"network_interface": {
+							Description: "The network interfaces to create for the cluster worker nodes",
+							Type:        schema.TypeList,
+							ForceNew:    true,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"network_id": {
+										Description: "The ID of the network to attach the interface to",
+										Type:        schema.TypeInt,
+										ForceNew:    true,
+										Required:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"on_create_failure": onCreateFailureSchema(),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// clusterStorageVolumeSchema is the storage_volume block of the node pools,
+// the datastore is optional as not every cloud exposes datastores
+func clusterStorageVolumeSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		ForceNew:    true,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": {
+					Description: "The storage volume uuid",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"root": {
+					Description: "Whether the volume is the root volume of the instance",
+					Type:        schema.TypeBool,
+					ForceNew:    true,
+					Required:    true,
+				},
+				"name": {
+					Description: "The name of the volume",
+					Type:        schema.TypeString,
+					ForceNew:    true,
+					Required:    true,
+				},
+				"size": {
+					Description: "The size of the volume in GB",
+					Type:        schema.TypeInt,
+					ForceNew:    true,
+					Required:    true,
+				},
+				"storage_type": {
+					Description: "The storage volume type ID",
+					Type:        schema.TypeInt,
+					ForceNew:    true,
+					Required:    true,
+				},
+				"datastore_id": {
+					Description: "The ID of the datastore",
+					Type:        schema.TypeInt,
+					ForceNew:    true,
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	clusterLayoutId := int64(d.Get("cluster_layout_id").(int))
+	optionTypes, err := getClusterLayoutOptionTypes(client, clusterLayoutId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterPayload := map[string]interface{}{}
+	clusterPayload["name"] = d.Get("name").(string)
+	clusterPayload["type"] = d.Get("cluster_type").(string)
+	clusterPayload["autoRecoverPowerState"] = false
+	clusterPayload["cloud"] = map[string]interface{}{
+		"id": d.Get("cloud_id").(int),
+	}
+	clusterPayload["group"] = map[string]interface{}{
+		"id": d.Get("group_id").(int),
+	}
+	if d.Get("description") != nil {
+		clusterPayload["description"] = d.Get("description").(string)
+	}
+	clusterPayload["layout"] = map[string]interface{}{
+		"id": clusterLayoutId,
+	}
+	if d.Get("workflow_id").(int) != 0 {
+		clusterPayload["taskSetId"] = d.Get("workflow_id").(int)
+	}
+
+	workerpool := d.Get("worker_node_pool").([]interface{})[0].(map[string]interface{})
+
+	// Config
+	config := make(map[string]interface{})
+	for key, value := range d.Get("config").(map[string]interface{}) {
+		config[key] = value.(string)
+	}
+	config["podCidr"] = d.Get("pod_cidr").(string)
+	config["serviceCidr"] = d.Get("service_cidr").(string)
+	config["nodeCount"] = workerpool["count"]
+	if d.Get("cluster_repo_account_id").(int) != 0 {
+		config["defaultRepoAccount"] = d.Get("cluster_repo_account_id").(int)
+	}
+
+	serverPayload := map[string]interface{}{}
+	serverPayload["config"] = config
+	serverPayload["nodeCount"] = workerpool["count"]
+	if d.Get("hostname_prefix").(string) != "" {
+		serverPayload["hostname"] = d.Get("hostname_prefix").(string)
+	}
+	if d.Get("resource_prefix").(string) != "" {
+		serverPayload["name"] = d.Get("resource_prefix").(string)
+	}
+	if d.Get("api_proxy_id").(int) != 0 {
+		serverPayload["apiProxy"] = map[string]interface{}{
+			"id": d.Get("api_proxy_id").(int),
+		}
+	}
+
+	// Master nodes are managed by the provider for layouts such as EKS and
+	// AKS, so the master node pool is optional
+	if masterpools := d.Get("master_node_pool").([]interface{}); len(masterpools) > 0 {
+		masterpool := masterpools[0].(map[string]interface{})
+		if masterpool["resource_pool_id"].(int) != 0 {
+			config["resourcePoolId"] = masterpool["resource_pool_id"]
+		}
+		serverPayload["plan"] = map[string]interface{}{
+			"id": masterpool["plan_id"],
+		}
+		serverPayload["volumes"] = parseStorageVolumes(masterpool["storage_volume"].([]interface{}))
+		serverPayload["networkInterfaces"] = parseMasterNetworkInterfaces(masterpool["network_interface"].([]interface{}))
+		if masterpool["tags"] != nil {
+			serverPayload["tags"] = parseTags(masterpool["tags"].(map[string]interface{}))
+		}
+	}
+
+	// Layout options are set on the server payload, where their field
+	// context (e.g. config) resolves the same way as in the Morpheus UI
+	err = applyLayoutOptions(serverPayload, optionTypes, d.Get("layout_options").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workerPayload := map[string]interface{}{}
+	if d.Get("api_proxy_id").(int) != 0 {
+		workerPayload["apiProxy"] = map[string]interface{}{
+			"id": d.Get("api_proxy_id").(int),
+		}
+	}
+	workerPayload["volumes"] = parseStorageVolumes(workerpool["storage_volume"].([]interface{}))
+	workerPayload["networkInterfaces"] = parseWorkerNetworkInterfaces(workerpool["network_interface"].([]interface{}))
+	workerPayload["config"] = map[string]interface{}{
+		"resourcePoolId": workerpool["resource_pool_id"],
+	}
+	if workerpool["tags"] != nil {
+		workerPayload["tags"] = parseTags(workerpool["tags"].(map[string]interface{}))
+	}
+	workerPayload["server"] = map[string]interface{}{
+		"plan": map[string]interface{}{
+			"id": workerpool["plan_id"],
+		},
+	}
+
+	clusterPayload["worker"] = workerPayload
+	clusterPayload["server"] = serverPayload
+
+	req := &morpheus.Request{Body: map[string]interface{}{
+		"cluster": clusterPayload,
+	}}
+
+	resp, err := client.CreateCluster(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	result := resp.Result.(*morpheus.CreateClusterResult)
+	cluster := result.Cluster
+	clusterStatus, err := waitForClusterProvisioning(ctx, meta, cluster.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.Errorf("error creating cluster: %s", err)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(cluster.ID))
+
+	// Fail the cluster deployment if the cluster status is in a failed state
+	if isProvisioningFailure(clusterStatus) {
+		return handleProvisioningFailure(ctx, d, meta, "cluster", clusterStatus, resourceKubernetesClusterDelete)
+	}
+
+	if upgradeDiags := upgradeNewCluster(ctx, d, meta); upgradeDiags.HasError() {
+		return upgradeDiags
+	}
+	resourceKubernetesClusterRead(ctx, d, meta)
+	return diags
+}
+
+func resourceKubernetesClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.GetCluster(toInt64(id), &morpheus.Request{})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+
+	// store resource data
+	result := resp.Result.(*morpheus.GetClusterResult)
+	cluster := result.Cluster
+	if cluster == nil {
+		return diag.Errorf("Cluster not found in response data.") // should not happen
+	}
+
+	d.SetId(int64ToString(cluster.ID))
+	d.Set("name", cluster.Name)
+	d.Set("description", cluster.Description)
+	d.Set("cloud_id", cluster.Zone.Id)
+	d.Set("group_id", cluster.Site.Id)
+	d.Set("cluster_layout_id", cluster.Layout.Id)
+	d.Set("kubernetes_version", cluster.ServiceVersion)
+	d.Set("api_endpoint", cluster.ServiceUrl)
+
+	workers, err := getClusterWorkers(client, cluster.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	workers = filterOutClusterWorkersByStatus(workers, statusDeprovisioning)
+	d.Set("worker_node_pool", flattenClusterWorkerNodePool(workers))
+
+	return diags
+}
+
+func resourceKubernetesClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := updateCluster(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	return resourceKubernetesClusterRead(ctx, d, meta)
+}
+
+func resourceKubernetesClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVsphereMKSClusterDelete(ctx, d, meta)
+}
+
+// getClusterLayoutOptionTypes returns the option types (inputs) attached to
+// a cluster layout
+func getClusterLayoutOptionTypes(client *morpheus.Client, layoutId int64) ([]LayoutOptionType, error) {
+	resp, err := client.GetClusterLayout(layoutId, &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var layout ClusterLayoutOptionTypesPayload
+	if err := json.Unmarshal(resp.Body, &layout); err != nil {
+		return nil, err
+	}
+	return layout.ClusterLayout.OptionTypes, nil
+}
+
+type ClusterLayoutOptionTypesPayload struct {
+	ClusterLayout struct {
+		ID          int64              `json:"id"`
+		OptionTypes []LayoutOptionType `json:"optionTypes"`
+	} `json:"layout"`
+}
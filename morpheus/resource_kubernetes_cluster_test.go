@@ -0,0 +1,102 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceKubernetesCluster_tagsDiff(t *testing.T) {
+	r := resourceKubernetesCluster()
+	config := func(masterTags map[string]interface{}, workerTags map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":              "tfcluster",
+			"cloud_id":          1,
+			"group_id":          1,
+			"cluster_layout_id": 1,
+			"master_node_pool": []interface{}{
+				map[string]interface{}{"plan_id": 1, "tags": masterTags},
+			},
+			"worker_node_pool": []interface{}{
+				map[string]interface{}{"count": 3, "plan_id": 1, "tags": workerTags},
+			},
+		}
+	}
+	tags := map[string]interface{}{"env": "dev"}
+	changedTags := map[string]interface{}{"env": "prod"}
+
+	cases := []struct {
+		name       string
+		newConfig  map[string]interface{}
+		forceNew   bool
+		tagsChange string
+	}{
+		{"worker tags", config(tags, changedTags), false, "worker_node_pool.0.tags.env"},
+		{"master tags", config(changedTags, tags), true, "master_node_pool.0.tags.env"},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, config(tags, tags))
+		d.SetId("1")
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(c.newConfig), nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.name, err)
+		}
+		if diff == nil || diff.Attributes[c.tagsChange] == nil {
+			t.Fatalf("%s: expected a change of %s", c.name, c.tagsChange)
+		}
+		if diff.RequiresNew() != c.forceNew {
+			t.Errorf("%s: expected force new %t, got %t", c.name, c.forceNew, diff.RequiresNew())
+		}
+	}
+}
+
+func TestDoClusterWorkerTagsUpdate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	meta := api.Meta()
+	api.Handle(http.MethodGet, "/api/clusters/1/workers", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"workers": []interface{}{
+				map[string]interface{}{"id": 11, "status": statusProvisioned, "dateCreated": "2024-01-01T00:00:00Z"},
+				map[string]interface{}{"id": 12, "status": statusProvisioned, "dateCreated": "2024-01-02T00:00:00Z"},
+				map[string]interface{}{"id": 13, "status": statusDeprovisioning, "dateCreated": "2024-01-03T00:00:00Z"},
+			},
+		}
+	})
+	for _, path := range []string{"/api/servers/11", "/api/servers/12"} {
+		api.Handle(http.MethodPut, path, func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+			return http.StatusOK, map[string]interface{}{"success": true}
+		})
+	}
+
+	if err := doClusterWorkerTagsUpdate(meta, 1, map[string]interface{}{"env": "prod"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"server": map[string]interface{}{
+			"tags": []interface{}{
+				map[string]interface{}{"name": "env", "value": "prod"},
+			},
+		},
+	}
+	for _, path := range []string{"/api/servers/11", "/api/servers/12"} {
+		requests := api.Requests(http.MethodPut, path)
+		if len(requests) != 1 || !reflect.DeepEqual(requests[0], expected) {
+			t.Errorf("expected the tags of %s to be replaced with %v, got %v", path, expected, requests)
+		}
+	}
+	if requests := api.Requests(http.MethodPut, "/api/servers/13"); len(requests) != 0 {
+		t.Errorf("expected the worker node being deprovisioned to be skipped, got %v", requests)
+	}
+
+	if err := doClusterWorkerTagsUpdate(meta, 1, map[string]interface{}{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	requests := api.Requests(http.MethodPut, "/api/servers/11")
+	if tags := requests[1]["server"].(map[string]interface{})["tags"].([]interface{}); len(tags) != 0 {
+		t.Errorf("expected removing every tag to send an empty list, got %v", tags)
+	}
+}
@@ -107,7 +107,7 @@ func resourceLibraryScriptTask() *schema.Resource {
 }
 
 func resourceLibraryScriptTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -172,7 +172,7 @@ func resourceLibraryScriptTaskCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceLibraryScriptTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -226,7 +226,7 @@ func resourceLibraryScriptTaskRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceLibraryScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -286,7 +286,7 @@ func resourceLibraryScriptTaskUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceLibraryScriptTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
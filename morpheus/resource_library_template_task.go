@@ -107,7 +107,7 @@ func resourceLibraryTemplateTask() *schema.Resource {
 }
 
 func resourceLibraryTemplateTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -171,7 +171,7 @@ func resourceLibraryTemplateTaskCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceLibraryTemplateTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -225,7 +225,7 @@ func resourceLibraryTemplateTaskRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceLibraryTemplateTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -285,7 +285,7 @@ func resourceLibraryTemplateTaskUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceLibraryTemplateTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
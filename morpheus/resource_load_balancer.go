@@ -0,0 +1,362 @@
+package morpheus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceLoadBalancer() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus load balancer resource",
+		CreateContext: resourceLoadBalancerCreate,
+		ReadContext:   resourceLoadBalancerRead,
+		UpdateContext: resourceLoadBalancerUpdate,
+		DeleteContext: resourceLoadBalancerDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the load balancer",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the load balancer",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the load balancer",
+				Optional:    true,
+			},
+			"type_code": {
+				Type:        schema.TypeString,
+				Description: "The code of the load balancer type (e.g. f5, nsx-t, amazon-alb or netscaler)",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"cloud_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the cloud the load balancer is associated with",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the load balancer is enabled",
+				Optional:    true,
+				Default:     true,
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Description:  "Whether the load balancer is visible in sub-tenants or not",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public"}, false),
+				Default:      "private",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Description: "The hostname or IP address of the load balancer API",
+				Optional:    true,
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Description: "The port of the load balancer API",
+				Optional:    true,
+				Computed:    true,
+			},
+			"credential_id": {
+				Type:          schema.TypeInt,
+				Description:   "The id of the credential store entry used for authentication",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"username", "password"},
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Description: "The username of the account used to connect to the load balancer",
+				Optional:    true,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "The password of the account used to connect to the load balancer",
+				Optional:    true,
+				Sensitive:   true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					h := sha256.New()
+					h.Write([]byte(new))
+					sha256_hash := hex.EncodeToString(h.Sum(nil))
+					return strings.EqualFold(old, sha256_hash)
+				},
+				DiffSuppressOnRefresh: true,
+			},
+			"config": {
+				Type:             schema.TypeString,
+				Description:      "Additional load balancer type specific settings (JSON), such as the NSX-T network server or the F5 partition",
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentJsonDiffs,
+				ValidateFunc:     validation.StringIsJSON,
+			},
+			"tenant_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of tenant ids granted access to the load balancer",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	loadBalancer, err := loadBalancerPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	loadBalancer["type"] = map[string]interface{}{
+		"code": d.Get("type_code").(string),
+	}
+	if cloudId := d.Get("cloud_id").(int); cloudId != 0 {
+		loadBalancer["cloud"] = map[string]interface{}{
+			"id": cloudId,
+		}
+	}
+	if d.Get("credential_id").(int) == 0 {
+		loadBalancer["sshUsername"] = d.Get("username").(string)
+		loadBalancer["sshPassword"] = d.Get("password").(string)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/load-balancers",
+		Body: map[string]interface{}{
+			"loadBalancer": loadBalancer,
+		},
+		Result: &GetMorpheusLoadBalancerResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusLoadBalancerResult)
+	if result.LoadBalancer == nil {
+		return diag.Errorf("create operation: load balancer not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.LoadBalancer.ID))
+
+	resourceLoadBalancerRead(ctx, d, meta)
+	return diags
+}
+
+func resourceLoadBalancerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/load-balancers/%s", id),
+		Result: &GetMorpheusLoadBalancerResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusLoadBalancerResult)
+	loadBalancer := result.LoadBalancer
+	if loadBalancer == nil {
+		return diag.Errorf("read operation: load balancer not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(loadBalancer.ID))
+	d.Set("name", loadBalancer.Name)
+	d.Set("description", loadBalancer.Description)
+	d.Set("type_code", loadBalancer.Type.Code)
+	if loadBalancer.Cloud.ID != 0 {
+		d.Set("cloud_id", loadBalancer.Cloud.ID)
+	}
+	d.Set("enabled", loadBalancer.Enabled)
+	d.Set("visibility", loadBalancer.Visibility)
+	d.Set("host", loadBalancer.SshHost)
+	d.Set("port", loadBalancer.ApiPort)
+	if loadBalancer.Credential.ID == 0 {
+		d.Set("username", loadBalancer.SshUsername)
+		d.Set("password", loadBalancer.SshPasswordHash)
+	} else {
+		d.Set("credential_id", loadBalancer.Credential.ID)
+	}
+	// Tenant Access
+	var tenantIds []int64
+	for _, tenant := range loadBalancer.Tenants {
+		tenantIds = append(tenantIds, tenant.ID)
+	}
+	d.Set("tenant_ids", tenantIds)
+
+	return diags
+}
+
+func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	loadBalancer, err := loadBalancerPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if d.Get("credential_id").(int) == 0 {
+		if d.HasChange("username") {
+			loadBalancer["sshUsername"] = d.Get("username").(string)
+		}
+		if d.HasChange("password") {
+			loadBalancer["sshPassword"] = d.Get("password").(string)
+		}
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/load-balancers/%s", id),
+		Body: map[string]interface{}{
+			"loadBalancer": loadBalancer,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceLoadBalancerRead(ctx, d, meta)
+}
+
+func resourceLoadBalancerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/load-balancers/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// loadBalancerPayload builds the settings of a load balancer that can be
+// changed after it has been created, the username and password are added by
+// the caller as they are only sent when they change
+func loadBalancerPayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	tenantsPayload := make([]int, 0)
+	if attr, ok := d.GetOk("tenant_ids"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			tenantsPayload = append(tenantsPayload, s.(int))
+		}
+	}
+
+	loadBalancer := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"enabled":     d.Get("enabled").(bool),
+		"visibility":  d.Get("visibility").(string),
+		"sshHost":     d.Get("host").(string),
+		"tenantPermissions": map[string]interface{}{
+			"accounts": tenantsPayload,
+		},
+	}
+	if port := d.Get("port").(int); port != 0 {
+		loadBalancer["apiPort"] = port
+	}
+	if credentialId := d.Get("credential_id").(int); credentialId != 0 {
+		loadBalancer["credential"] = map[string]interface{}{
+			"type": "username-password",
+			"id":   credentialId,
+		}
+	}
+
+	if d.Get("config").(string) != "" {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(d.Get("config").(string)), &config); err != nil {
+			return nil, fmt.Errorf("unable to parse the load balancer config: %s", err)
+		}
+		loadBalancer["config"] = config
+	}
+
+	return loadBalancer, nil
+}
+
+type MorpheusLoadBalancer struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Enabled         bool   `json:"enabled"`
+	Visibility      string `json:"visibility"`
+	SshHost         string `json:"sshHost"`
+	ApiPort         int64  `json:"apiPort"`
+	SshUsername     string `json:"sshUsername"`
+	SshPasswordHash string `json:"sshPasswordHash"`
+	Type            struct {
+		ID   int64  `json:"id"`
+		Code string `json:"code"`
+	} `json:"type"`
+	Cloud struct {
+		ID int64 `json:"id"`
+	} `json:"cloud"`
+	Credential struct {
+		ID int64 `json:"id"`
+	} `json:"credential"`
+	Tenants []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"tenants"`
+}
+
+type GetMorpheusLoadBalancerResult struct {
+	LoadBalancer *MorpheusLoadBalancer `json:"loadBalancer"`
+}
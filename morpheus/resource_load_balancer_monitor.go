@@ -0,0 +1,270 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceLoadBalancerMonitor() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus load balancer health monitor resource",
+		CreateContext: resourceLoadBalancerMonitorCreate,
+		ReadContext:   resourceLoadBalancerMonitorRead,
+		UpdateContext: resourceLoadBalancerMonitorUpdate,
+		DeleteContext: resourceLoadBalancerMonitorDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the load balancer monitor",
+				Computed:    true,
+			},
+			"load_balancer_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the load balancer to create the monitor in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the load balancer monitor",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the load balancer monitor",
+				Optional:    true,
+			},
+			"monitor_type": {
+				Type:        schema.TypeString,
+				Description: "The type of the load balancer monitor (e.g. http, https, tcp or icmp)",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"interval": {
+				Type:        schema.TypeInt,
+				Description: "The number of seconds between health checks",
+				Optional:    true,
+				Computed:    true,
+			},
+			"timeout": {
+				Type:        schema.TypeInt,
+				Description: "The number of seconds before a member that fails its health checks is marked down",
+				Optional:    true,
+				Computed:    true,
+			},
+			"send_data": {
+				Type:        schema.TypeString,
+				Description: "The request sent to the member by the health check (e.g. GET /health HTTP/1.1)",
+				Optional:    true,
+			},
+			"receive_data": {
+				Type:        schema.TypeString,
+				Description: "The response expected from the member for the health check to pass",
+				Optional:    true,
+			},
+			"receive_code": {
+				Type:        schema.TypeString,
+				Description: "The HTTP status code expected from the member for the health check to pass",
+				Optional:    true,
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Description: "The destination of the health check in the format ip:port (e.g. *:8080), the address of the member is used when not set",
+				Optional:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLoadBalancerMonitorImport,
+		},
+	}
+}
+
+func resourceLoadBalancerMonitorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	monitor := loadBalancerMonitorPayload(d)
+	monitor["monitorType"] = d.Get("monitor_type").(string)
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/monitors", loadBalancerId),
+		Body: map[string]interface{}{
+			"loadBalancerMonitor": monitor,
+		},
+		Result: &GetMorpheusLoadBalancerMonitorResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusLoadBalancerMonitorResult)
+	if result.LoadBalancerMonitor == nil {
+		return diag.Errorf("create operation: load balancer monitor not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.LoadBalancerMonitor.ID))
+
+	resourceLoadBalancerMonitorRead(ctx, d, meta)
+	return diags
+}
+
+func resourceLoadBalancerMonitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/monitors/%s", loadBalancerId, id),
+		Result: &GetMorpheusLoadBalancerMonitorResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusLoadBalancerMonitorResult)
+	monitor := result.LoadBalancerMonitor
+	if monitor == nil {
+		return diag.Errorf("read operation: load balancer monitor not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(monitor.ID))
+	d.Set("name", monitor.Name)
+	d.Set("description", monitor.Description)
+	d.Set("monitor_type", monitor.MonitorType)
+	d.Set("interval", monitor.MonitorInterval)
+	d.Set("timeout", monitor.MonitorTimeout)
+	d.Set("send_data", monitor.SendData)
+	d.Set("receive_data", monitor.ReceiveData)
+	d.Set("receive_code", monitor.ReceiveCode)
+	d.Set("destination", monitor.MonitorDestination)
+
+	return diags
+}
+
+func resourceLoadBalancerMonitorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/monitors/%s", loadBalancerId, id),
+		Body: map[string]interface{}{
+			"loadBalancerMonitor": loadBalancerMonitorPayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceLoadBalancerMonitorRead(ctx, d, meta)
+}
+
+func resourceLoadBalancerMonitorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/monitors/%s", loadBalancerId, id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceLoadBalancerMonitorImport imports a monitor using an id in the
+// format load_balancer_id/monitor_id
+func resourceLoadBalancerMonitorImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected load_balancer_id/monitor_id", d.Id())
+	}
+	d.Set("load_balancer_id", toInt64(parts[0]))
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// loadBalancerMonitorPayload builds the settings of a load balancer monitor
+// that can be changed after it has been created
+func loadBalancerMonitorPayload(d *schema.ResourceData) map[string]interface{} {
+	monitor := map[string]interface{}{
+		"name":               d.Get("name").(string),
+		"description":        d.Get("description").(string),
+		"sendData":           d.Get("send_data").(string),
+		"receiveData":        d.Get("receive_data").(string),
+		"receiveCode":        d.Get("receive_code").(string),
+		"monitorDestination": d.Get("destination").(string),
+	}
+	if interval := d.Get("interval").(int); interval != 0 {
+		monitor["monitorInterval"] = interval
+	}
+	if timeout := d.Get("timeout").(int); timeout != 0 {
+		monitor["monitorTimeout"] = timeout
+	}
+	return monitor
+}
+
+type MorpheusLoadBalancerMonitor struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	MonitorType        string `json:"monitorType"`
+	MonitorInterval    int64  `json:"monitorInterval"`
+	MonitorTimeout     int64  `json:"monitorTimeout"`
+	SendData           string `json:"sendData"`
+	ReceiveData        string `json:"receiveData"`
+	ReceiveCode        string `json:"receiveCode"`
+	MonitorDestination string `json:"monitorDestination"`
+}
+
+type GetMorpheusLoadBalancerMonitorResult struct {
+	LoadBalancerMonitor *MorpheusLoadBalancerMonitor `json:"loadBalancerMonitor"`
+}
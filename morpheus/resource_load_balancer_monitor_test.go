@@ -0,0 +1,155 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLoadBalancerMonitorPayload(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "defaults",
+			config: map[string]interface{}{
+				"load_balancer_id": 1,
+				"name":             "tfmonitor",
+				"monitor_type":     "tcp",
+			},
+			expected: map[string]interface{}{
+				"name":               "tfmonitor",
+				"description":        "",
+				"sendData":           "",
+				"receiveData":        "",
+				"receiveCode":        "",
+				"monitorDestination": "",
+			},
+		},
+		{
+			name: "http health check",
+			config: map[string]interface{}{
+				"load_balancer_id": 1,
+				"name":             "tfmonitor",
+				"description":      "terraform monitor",
+				"monitor_type":     "http",
+				"interval":         5,
+				"timeout":          16,
+				"send_data":        "GET /health HTTP/1.1",
+				"receive_data":     "ok",
+				"receive_code":     "200",
+				"destination":      "*:8080",
+			},
+			expected: map[string]interface{}{
+				"name":               "tfmonitor",
+				"description":        "terraform monitor",
+				"monitorInterval":    5,
+				"monitorTimeout":     16,
+				"sendData":           "GET /health HTTP/1.1",
+				"receiveData":        "ok",
+				"receiveCode":        "200",
+				"monitorDestination": "*:8080",
+			},
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceLoadBalancerMonitor().Schema, c.config)
+		if payload := loadBalancerMonitorPayload(d); !reflect.DeepEqual(payload, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, payload)
+		}
+	}
+}
+
+func TestResourceLoadBalancerMonitorCreate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	d := schema.TestResourceDataRaw(t, resourceLoadBalancerMonitor().Schema, map[string]interface{}{
+		"load_balancer_id": 1,
+		"name":             "tfmonitor",
+		"monitor_type":     "http",
+		"interval":         5,
+		"send_data":        "GET /health HTTP/1.1",
+	})
+	if diags := resourceLoadBalancerMonitorCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests(http.MethodPost, "/api/load-balancers/1/monitors")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(requests))
+	}
+	monitor := requests[0]["loadBalancerMonitor"].(map[string]interface{})
+	// The monitor type can only be set when the monitor is created
+	if monitor["monitorType"] != "http" {
+		t.Errorf("expected the monitorType http, got %v", monitor["monitorType"])
+	}
+
+	if d.Id() == "" {
+		t.Fatal("expected the id of the created monitor to be set")
+	}
+	if requests := api.Requests(http.MethodGet, "/api/load-balancers/1/monitors/"+d.Id()); len(requests) != 1 {
+		t.Errorf("expected the monitor to be read back from its load balancer, got %d requests", len(requests))
+	}
+	for k, v := range map[string]interface{}{"monitor_type": "http", "interval": 5, "send_data": "GET /health HTTP/1.1"} {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be read back as %v, got %v", k, v, value)
+		}
+	}
+}
+
+func TestResourceLoadBalancerMonitorImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	monitorId := api.Seed("/api/load-balancers/7/monitors", map[string]interface{}{
+		"name":               "tfmonitor",
+		"monitorType":        "https",
+		"monitorInterval":    10,
+		"monitorTimeout":     31,
+		"receiveCode":        "200",
+		"monitorDestination": "*:443",
+	})
+
+	r := resourceLoadBalancerMonitor()
+	d := r.Data(nil)
+	d.SetId("7/" + int64ToString(monitorId))
+	imported, err := resourceLoadBalancerMonitorImport(context.Background(), d, api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+	d = imported[0]
+	if diags := resourceLoadBalancerMonitorRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != int64ToString(monitorId) {
+		t.Errorf("expected the id %d, got %s", monitorId, d.Id())
+	}
+	expected := map[string]interface{}{
+		"load_balancer_id": 7,
+		"name":             "tfmonitor",
+		"monitor_type":     "https",
+		"interval":         10,
+		"timeout":          31,
+		"receive_code":     "200",
+		"destination":      "*:443",
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+
+	for _, importId := range []string{"", "7", "7/", "/1", "7/1/2"} {
+		d := r.Data(nil)
+		d.SetId(importId)
+		if _, err := resourceLoadBalancerMonitorImport(context.Background(), d, api.Meta()); err == nil {
+			t.Errorf("expected an error importing %q", importId)
+		}
+	}
+}
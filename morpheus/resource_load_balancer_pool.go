@@ -0,0 +1,327 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceLoadBalancerPool() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus load balancer pool resource",
+		CreateContext: resourceLoadBalancerPoolCreate,
+		ReadContext:   resourceLoadBalancerPoolRead,
+		UpdateContext: resourceLoadBalancerPoolUpdate,
+		DeleteContext: resourceLoadBalancerPoolDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the load balancer pool",
+				Computed:    true,
+			},
+			"load_balancer_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the load balancer to create the pool in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the load balancer pool",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the load balancer pool",
+				Optional:    true,
+			},
+			"balance_mode": {
+				Type:        schema.TypeString,
+				Description: "The balance mode of the load balancer pool (e.g. roundrobin, leastconnections or sourceip)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"monitor_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of the IDs of the load balancer monitors used to check the health of the pool members",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"member": {
+				Type:        schema.TypeList,
+				Description: "The members of the load balancer pool, identified by an instance or an IP address",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the Morpheus instance that is a member of the pool",
+							Optional:    true,
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Description: "The IP address of the member, used when the member is not a Morpheus instance",
+							Optional:    true,
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Description: "The port traffic is sent to on the member",
+							Required:    true,
+						},
+						"weight": {
+							Type:        schema.TypeInt,
+							Description: "The weight of the member when the traffic is balanced by ratio",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLoadBalancerPoolImport,
+		},
+	}
+}
+
+func resourceLoadBalancerPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	pool, err := loadBalancerPoolPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/pools", loadBalancerId),
+		Body: map[string]interface{}{
+			"loadBalancerPool": pool,
+		},
+		Result: &GetMorpheusLoadBalancerPoolResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusLoadBalancerPoolResult)
+	if result.LoadBalancerPool == nil {
+		return diag.Errorf("create operation: load balancer pool not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.LoadBalancerPool.ID))
+
+	resourceLoadBalancerPoolRead(ctx, d, meta)
+	return diags
+}
+
+func resourceLoadBalancerPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/pools/%s", loadBalancerId, id),
+		Result: &GetMorpheusLoadBalancerPoolResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusLoadBalancerPoolResult)
+	pool := result.LoadBalancerPool
+	if pool == nil {
+		return diag.Errorf("read operation: load balancer pool not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(pool.ID))
+	d.Set("name", pool.Name)
+	d.Set("description", pool.Description)
+	d.Set("balance_mode", pool.VipBalance)
+	var monitorIds []int64
+	for _, monitor := range pool.Monitors {
+		monitorIds = append(monitorIds, monitor.ID)
+	}
+	d.Set("monitor_ids", monitorIds)
+	var members []map[string]interface{}
+	for _, member := range pool.Members {
+		row := map[string]interface{}{
+			"port":   member.Port,
+			"weight": member.Weight,
+		}
+		if member.Instance.ID != 0 {
+			row["instance_id"] = member.Instance.ID
+		} else {
+			row["ip_address"] = member.IpAddress
+		}
+		members = append(members, row)
+	}
+	d.Set("member", members)
+
+	return diags
+}
+
+func resourceLoadBalancerPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	pool, err := loadBalancerPoolPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/pools/%s", loadBalancerId, id),
+		Body: map[string]interface{}{
+			"loadBalancerPool": pool,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceLoadBalancerPoolRead(ctx, d, meta)
+}
+
+func resourceLoadBalancerPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/pools/%s", loadBalancerId, id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceLoadBalancerPoolImport imports a pool using an id in the format
+// load_balancer_id/pool_id
+func resourceLoadBalancerPoolImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected load_balancer_id/pool_id", d.Id())
+	}
+	d.Set("load_balancer_id", toInt64(parts[0]))
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// loadBalancerPoolPayload builds the settings of a load balancer pool. Each
+// member is either a Morpheus instance or an IP address.
+func loadBalancerPoolPayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	monitors := make([]map[string]interface{}, 0)
+	for _, monitorId := range d.Get("monitor_ids").(*schema.Set).List() {
+		monitors = append(monitors, map[string]interface{}{
+			"id": monitorId.(int),
+		})
+	}
+
+	members := make([]map[string]interface{}, 0)
+	for i, item := range d.Get("member").([]interface{}) {
+		memberConfig := item.(map[string]interface{})
+		member := map[string]interface{}{
+			"port": memberConfig["port"].(int),
+		}
+		instanceId := memberConfig["instance_id"].(int)
+		ipAddress := memberConfig["ip_address"].(string)
+		if (instanceId == 0) == (ipAddress == "") {
+			return nil, fmt.Errorf("member %d of the load balancer pool requires either an instance_id or an ip_address", i)
+		}
+		if instanceId != 0 {
+			member["instance"] = map[string]interface{}{
+				"id": instanceId,
+			}
+		} else {
+			member["ipAddress"] = ipAddress
+		}
+		if weight := memberConfig["weight"].(int); weight != 0 {
+			member["weight"] = weight
+		}
+		members = append(members, member)
+	}
+
+	pool := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"monitors":    monitors,
+		"members":     members,
+	}
+	if balanceMode := d.Get("balance_mode").(string); balanceMode != "" {
+		pool["vipBalance"] = balanceMode
+	}
+	return pool, nil
+}
+
+type MorpheusLoadBalancerPool struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	VipBalance  string `json:"vipBalance"`
+	Monitors    []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"monitors"`
+	Members []struct {
+		ID        int64  `json:"id"`
+		IpAddress string `json:"ipAddress"`
+		Port      int64  `json:"port"`
+		Weight    int64  `json:"weight"`
+		Instance  struct {
+			ID int64 `json:"id"`
+		} `json:"instance"`
+	} `json:"members"`
+}
+
+type GetMorpheusLoadBalancerPoolResult struct {
+	LoadBalancerPool *MorpheusLoadBalancerPool `json:"loadBalancerPool"`
+}
@@ -0,0 +1,210 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLoadBalancerPoolPayload(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]interface{}
+		err      string
+	}{
+		{
+			name: "defaults",
+			config: map[string]interface{}{
+				"load_balancer_id": 1,
+				"name":             "tfpool",
+			},
+			expected: map[string]interface{}{
+				"name":        "tfpool",
+				"description": "",
+				"monitors":    []map[string]interface{}{},
+				"members":     []map[string]interface{}{},
+			},
+		},
+		{
+			name: "instance and ip address members",
+			config: map[string]interface{}{
+				"load_balancer_id": 1,
+				"name":             "tfpool",
+				"balance_mode":     "leastconnections",
+				"monitor_ids":      []interface{}{4},
+				"member": []interface{}{
+					map[string]interface{}{"instance_id": 10, "port": 8080},
+					map[string]interface{}{"ip_address": "10.0.0.20", "port": 8080, "weight": 2},
+				},
+			},
+			expected: map[string]interface{}{
+				"name":        "tfpool",
+				"description": "",
+				"vipBalance":  "leastconnections",
+				"monitors":    []map[string]interface{}{{"id": 4}},
+				"members": []map[string]interface{}{
+					{"instance": map[string]interface{}{"id": 10}, "port": 8080},
+					{"ipAddress": "10.0.0.20", "port": 8080, "weight": 2},
+				},
+			},
+		},
+		{
+			name: "member without instance or ip address",
+			config: map[string]interface{}{
+				"load_balancer_id": 1,
+				"name":             "tfpool",
+				"member": []interface{}{
+					map[string]interface{}{"instance_id": 10, "port": 8080},
+					map[string]interface{}{"port": 8080},
+				},
+			},
+			err: "member 1 of the load balancer pool requires either an instance_id or an ip_address",
+		},
+		{
+			name: "member with instance and ip address",
+			config: map[string]interface{}{
+				"load_balancer_id": 1,
+				"name":             "tfpool",
+				"member": []interface{}{
+					map[string]interface{}{"instance_id": 10, "ip_address": "10.0.0.20", "port": 8080},
+				},
+			},
+			err: "member 0 of the load balancer pool requires either an instance_id or an ip_address",
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceLoadBalancerPool().Schema, c.config)
+		payload, err := loadBalancerPoolPayload(d)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("%s: expected the error %q, got %v", c.name, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(payload, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, payload)
+		}
+	}
+}
+
+func TestResourceLoadBalancerPoolCreate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	d := schema.TestResourceDataRaw(t, resourceLoadBalancerPool().Schema, map[string]interface{}{
+		"load_balancer_id": 1,
+		"name":             "tfpool",
+		"balance_mode":     "roundrobin",
+		"monitor_ids":      []interface{}{4},
+		"member": []interface{}{
+			map[string]interface{}{"instance_id": 10, "port": 8080},
+			map[string]interface{}{"ip_address": "10.0.0.20", "port": 8080, "weight": 2},
+		},
+	})
+	if diags := resourceLoadBalancerPoolCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if requests := api.Requests(http.MethodPost, "/api/load-balancers/1/pools"); len(requests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(requests))
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the id of the created pool to be set")
+	}
+	if requests := api.Requests(http.MethodGet, "/api/load-balancers/1/pools/"+d.Id()); len(requests) != 1 {
+		t.Errorf("expected the pool to be read back from its load balancer, got %d requests", len(requests))
+	}
+
+	// The members are read back as an instance or an IP address
+	expected := map[string]interface{}{
+		"balance_mode":         "roundrobin",
+		"monitor_ids.#":        1,
+		"member.#":             2,
+		"member.0.instance_id": 10,
+		"member.0.ip_address":  "",
+		"member.1.instance_id": 0,
+		"member.1.ip_address":  "10.0.0.20",
+		"member.1.weight":      2,
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be read back as %v, got %v", k, v, value)
+		}
+	}
+}
+
+func TestResourceLoadBalancerPoolCreate_invalidMember(t *testing.T) {
+	api := newFakeMorpheus(t)
+	d := schema.TestResourceDataRaw(t, resourceLoadBalancerPool().Schema, map[string]interface{}{
+		"load_balancer_id": 1,
+		"name":             "tfpool",
+		"member": []interface{}{
+			map[string]interface{}{"port": 8080},
+		},
+	})
+	if diags := resourceLoadBalancerPoolCreate(context.Background(), d, api.Meta()); !diags.HasError() {
+		t.Fatal("expected an error for a member without an instance_id or ip_address")
+	}
+	if requests := api.Requests(http.MethodPost, "/api/load-balancers/1/pools"); len(requests) != 0 {
+		t.Errorf("expected no pool to be created, got %d create requests", len(requests))
+	}
+}
+
+func TestResourceLoadBalancerPoolImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	poolId := api.Seed("/api/load-balancers/7/pools", map[string]interface{}{
+		"name":       "tfpool",
+		"vipBalance": "sourceip",
+		"monitors":   []interface{}{map[string]interface{}{"id": 4, "name": "tfmonitor"}},
+		"members": []interface{}{
+			map[string]interface{}{"id": 1, "instance": map[string]interface{}{"id": 10}, "port": 80, "weight": 1},
+		},
+	})
+
+	r := resourceLoadBalancerPool()
+	d := r.Data(nil)
+	d.SetId("7/" + int64ToString(poolId))
+	imported, err := resourceLoadBalancerPoolImport(context.Background(), d, api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+	d = imported[0]
+	if diags := resourceLoadBalancerPoolRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != int64ToString(poolId) {
+		t.Errorf("expected the id %d, got %s", poolId, d.Id())
+	}
+	expected := map[string]interface{}{
+		"load_balancer_id":     7,
+		"name":                 "tfpool",
+		"balance_mode":         "sourceip",
+		"monitor_ids.#":        1,
+		"member.#":             1,
+		"member.0.instance_id": 10,
+		"member.0.port":        80,
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+
+	for _, importId := range []string{"", "7", "7/", "/1", "7/1/2"} {
+		d := r.Data(nil)
+		d.SetId(importId)
+		if _, err := resourceLoadBalancerPoolImport(context.Background(), d, api.Meta()); err == nil {
+			t.Errorf("expected an error importing %q", importId)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLoadBalancerPayload(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]interface{}
+		err      bool
+	}{
+		{
+			name: "defaults",
+			config: map[string]interface{}{
+				"name":      "tflb",
+				"type_code": "f5",
+			},
+			expected: map[string]interface{}{
+				"name":              "tflb",
+				"description":       "",
+				"enabled":           true,
+				"visibility":        "private",
+				"sshHost":           "",
+				"tenantPermissions": map[string]interface{}{"accounts": []int{}},
+			},
+		},
+		{
+			name: "credential and config",
+			config: map[string]interface{}{
+				"name":          "tflb",
+				"description":   "terraform load balancer",
+				"type_code":     "nsx-t",
+				"enabled":       false,
+				"visibility":    "public",
+				"host":          "nsx.example.com",
+				"port":          8443,
+				"credential_id": 3,
+				"config":        `{"networkServer": {"id": 4}}`,
+				"tenant_ids":    []interface{}{2},
+			},
+			expected: map[string]interface{}{
+				"name":              "tflb",
+				"description":       "terraform load balancer",
+				"enabled":           false,
+				"visibility":        "public",
+				"sshHost":           "nsx.example.com",
+				"apiPort":           8443,
+				"credential":        map[string]interface{}{"type": "username-password", "id": 3},
+				"config":            map[string]interface{}{"networkServer": map[string]interface{}{"id": float64(4)}},
+				"tenantPermissions": map[string]interface{}{"accounts": []int{2}},
+			},
+		},
+		{
+			name: "invalid config",
+			config: map[string]interface{}{
+				"name":      "tflb",
+				"type_code": "f5",
+				"config":    "{",
+			},
+			err: true,
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceLoadBalancer().Schema, c.config)
+		payload, err := loadBalancerPayload(d)
+		if (err != nil) != c.err {
+			t.Errorf("%s: expected error %t, got %v", c.name, c.err, err)
+			continue
+		}
+		if !c.err && !reflect.DeepEqual(payload, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, payload)
+		}
+	}
+}
+
+func TestResourceLoadBalancerCreate(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]interface{}
+		omitted  []string
+	}{
+		{
+			name: "username and password",
+			config: map[string]interface{}{
+				"name":      "tflb",
+				"type_code": "f5",
+				"cloud_id":  1,
+				"host":      "f5.example.com",
+				"port":      443,
+				"username":  "admin",
+				"password":  "secret",
+			},
+			expected: map[string]interface{}{
+				"type":        map[string]interface{}{"code": "f5"},
+				"cloud":       map[string]interface{}{"id": float64(1)},
+				"sshHost":     "f5.example.com",
+				"apiPort":     float64(443),
+				"sshUsername": "admin",
+				"sshPassword": "secret",
+			},
+			omitted: []string{"credential"},
+		},
+		{
+			name: "credential",
+			config: map[string]interface{}{
+				"name":          "tflb",
+				"type_code":     "amazon-alb",
+				"credential_id": 3,
+			},
+			expected: map[string]interface{}{
+				"type":       map[string]interface{}{"code": "amazon-alb"},
+				"credential": map[string]interface{}{"type": "username-password", "id": float64(3)},
+			},
+			omitted: []string{"cloud", "sshUsername", "sshPassword"},
+		},
+	}
+	for _, c := range cases {
+		api := newFakeMorpheus(t)
+		d := schema.TestResourceDataRaw(t, resourceLoadBalancer().Schema, c.config)
+		if diags := resourceLoadBalancerCreate(context.Background(), d, api.Meta()); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", c.name, diags)
+		}
+
+		requests := api.Requests(http.MethodPost, "/api/load-balancers")
+		if len(requests) != 1 {
+			t.Fatalf("%s: expected 1 create request, got %d", c.name, len(requests))
+		}
+		loadBalancer := requests[0]["loadBalancer"].(map[string]interface{})
+		for k, v := range c.expected {
+			if !reflect.DeepEqual(loadBalancer[k], v) {
+				t.Errorf("%s: expected %s to be %v, got %v", c.name, k, v, loadBalancer[k])
+			}
+		}
+		for _, k := range c.omitted {
+			if _, ok := loadBalancer[k]; ok {
+				t.Errorf("%s: expected %s not to be sent, got %v", c.name, k, loadBalancer[k])
+			}
+		}
+
+		if d.Id() == "" {
+			t.Fatalf("%s: expected the id of the created load balancer to be set", c.name)
+		}
+		if requests := api.Requests(http.MethodGet, "/api/load-balancers/"+d.Id()); len(requests) != 1 {
+			t.Errorf("%s: expected the load balancer to be read back, got %d requests", c.name, len(requests))
+		}
+		if typeCode := d.Get("type_code"); typeCode != c.config["type_code"] {
+			t.Errorf("%s: expected the type_code %v to be read back, got %v", c.name, c.config["type_code"], typeCode)
+		}
+	}
+}
+
+func TestResourceLoadBalancerImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	loadBalancerId := api.Seed("/api/load-balancers", map[string]interface{}{
+		"name":       "tflb",
+		"type":       map[string]interface{}{"id": 5, "code": "f5"},
+		"cloud":      map[string]interface{}{"id": 1},
+		"enabled":    true,
+		"visibility": "private",
+		"sshHost":    "f5.example.com",
+		"apiPort":    443,
+		"credential": map[string]interface{}{"id": 3},
+		"tenants":    []interface{}{map[string]interface{}{"id": 2, "name": "tenant"}},
+	})
+
+	r := resourceLoadBalancer()
+	d := r.Data(nil)
+	d.SetId(int64ToString(loadBalancerId))
+	imported, err := r.Importer.StateContext(context.Background(), d, api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d = imported[0]
+	if diags := resourceLoadBalancerRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"name":          "tflb",
+		"type_code":     "f5",
+		"cloud_id":      1,
+		"host":          "f5.example.com",
+		"port":          443,
+		"credential_id": 3,
+		"username":      "",
+		"tenant_ids.#":  1,
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceLoadBalancerVirtualServer() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus load balancer virtual server resource",
+		CreateContext: resourceLoadBalancerVirtualServerCreate,
+		ReadContext:   resourceLoadBalancerVirtualServerRead,
+		UpdateContext: resourceLoadBalancerVirtualServerUpdate,
+		DeleteContext: resourceLoadBalancerVirtualServerDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the load balancer virtual server",
+				Computed:    true,
+			},
+			"load_balancer_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the load balancer to create the virtual server in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the load balancer virtual server",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the load balancer virtual server",
+				Optional:    true,
+			},
+			"vip_address": {
+				Type:        schema.TypeString,
+				Description: "The virtual IP address of the virtual server",
+				Required:    true,
+			},
+			"vip_port": {
+				Type:        schema.TypeInt,
+				Description: "The port the virtual server listens on",
+				Required:    true,
+			},
+			"vip_protocol": {
+				Type:         schema.TypeString,
+				Description:  "The protocol of the virtual server (tcp, udp, http, https)",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "http", "https"}, false),
+				Default:      "tcp",
+			},
+			"vip_hostname": {
+				Type:        schema.TypeString,
+				Description: "The hostname of the virtual server",
+				Optional:    true,
+			},
+			"pool_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the load balancer pool traffic is sent to",
+				Optional:    true,
+			},
+			"ssl_profile": {
+				Type:        schema.TypeString,
+				Description: "The name of the client SSL profile used to terminate SSL on the virtual server",
+				Optional:    true,
+			},
+			"ssl_cert_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the SSL certificate served by the virtual server",
+				Optional:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the virtual server is enabled",
+				Optional:    true,
+				Default:     true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "The status of the virtual server",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLoadBalancerVirtualServerImport,
+		},
+	}
+}
+
+func resourceLoadBalancerVirtualServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/virtual-servers", loadBalancerId),
+		Body: map[string]interface{}{
+			"virtualServer": loadBalancerVirtualServerPayload(d),
+		},
+		Result: &GetMorpheusLoadBalancerVirtualServerResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusLoadBalancerVirtualServerResult)
+	if result.VirtualServer == nil {
+		return diag.Errorf("create operation: load balancer virtual server not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.VirtualServer.ID))
+
+	resourceLoadBalancerVirtualServerRead(ctx, d, meta)
+	return diags
+}
+
+func resourceLoadBalancerVirtualServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/virtual-servers/%s", loadBalancerId, id),
+		Result: &GetMorpheusLoadBalancerVirtualServerResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusLoadBalancerVirtualServerResult)
+	virtualServer := result.VirtualServer
+	if virtualServer == nil {
+		return diag.Errorf("read operation: load balancer virtual server not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(virtualServer.ID))
+	d.Set("name", virtualServer.VipName)
+	d.Set("description", virtualServer.Description)
+	d.Set("vip_address", virtualServer.VipAddress)
+	d.Set("vip_port", virtualServer.VipPort)
+	d.Set("vip_protocol", virtualServer.VipProtocol)
+	d.Set("vip_hostname", virtualServer.VipHostname)
+	d.Set("pool_id", virtualServer.Pool.ID)
+	d.Set("ssl_profile", virtualServer.SslProfile)
+	d.Set("ssl_cert_id", virtualServer.SslCert.ID)
+	d.Set("enabled", virtualServer.Active)
+	d.Set("status", virtualServer.VipStatus)
+
+	return diags
+}
+
+func resourceLoadBalancerVirtualServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/virtual-servers/%s", loadBalancerId, id),
+		Body: map[string]interface{}{
+			"virtualServer": loadBalancerVirtualServerPayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceLoadBalancerVirtualServerRead(ctx, d, meta)
+}
+
+func resourceLoadBalancerVirtualServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	loadBalancerId := int64(d.Get("load_balancer_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/load-balancers/%d/virtual-servers/%s", loadBalancerId, id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceLoadBalancerVirtualServerImport imports a virtual server using an
+// id in the format load_balancer_id/virtual_server_id
+func resourceLoadBalancerVirtualServerImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected load_balancer_id/virtual_server_id", d.Id())
+	}
+	d.Set("load_balancer_id", toInt64(parts[0]))
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// loadBalancerVirtualServerPayload builds the settings of a load balancer
+// virtual server
+func loadBalancerVirtualServerPayload(d *schema.ResourceData) map[string]interface{} {
+	virtualServer := map[string]interface{}{
+		"vipName":     d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"vipAddress":  d.Get("vip_address").(string),
+		"vipPort":     d.Get("vip_port").(int),
+		"vipProtocol": d.Get("vip_protocol").(string),
+		"vipHostname": d.Get("vip_hostname").(string),
+		"sslProfile":  d.Get("ssl_profile").(string),
+		"active":      d.Get("enabled").(bool),
+	}
+
+	// A null pool or certificate removes it from the virtual server
+	virtualServer["pool"] = nil
+	if poolId := d.Get("pool_id").(int); poolId != 0 {
+		virtualServer["pool"] = map[string]interface{}{
+			"id": poolId,
+		}
+	}
+	virtualServer["sslCert"] = nil
+	if sslCertId := d.Get("ssl_cert_id").(int); sslCertId != 0 {
+		virtualServer["sslCert"] = map[string]interface{}{
+			"id": sslCertId,
+		}
+	}
+	return virtualServer
+}
+
+type MorpheusLoadBalancerVirtualServer struct {
+	ID          int64  `json:"id"`
+	VipName     string `json:"vipName"`
+	Description string `json:"description"`
+	VipAddress  string `json:"vipAddress"`
+	VipPort     int64  `json:"vipPort"`
+	VipProtocol string `json:"vipProtocol"`
+	VipHostname string `json:"vipHostname"`
+	VipStatus   string `json:"vipStatus"`
+	SslProfile  string `json:"sslProfile"`
+	Active      bool   `json:"active"`
+	Pool        struct {
+		ID int64 `json:"id"`
+	} `json:"pool"`
+	SslCert struct {
+		ID int64 `json:"id"`
+	} `json:"sslCert"`
+}
+
+type GetMorpheusLoadBalancerVirtualServerResult struct {
+	VirtualServer *MorpheusLoadBalancerVirtualServer `json:"virtualServer"`
+}
@@ -0,0 +1,166 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLoadBalancerVirtualServerPayload(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "defaults",
+			config: map[string]interface{}{
+				"load_balancer_id": 1,
+				"name":             "tfvip",
+				"vip_address":      "10.0.0.100",
+				"vip_port":         80,
+			},
+			expected: map[string]interface{}{
+				"vipName":     "tfvip",
+				"description": "",
+				"vipAddress":  "10.0.0.100",
+				"vipPort":     80,
+				"vipProtocol": "tcp",
+				"vipHostname": "",
+				"sslProfile":  "",
+				"active":      true,
+				"pool":        nil,
+				"sslCert":     nil,
+			},
+		},
+		{
+			name: "https with pool and certificate",
+			config: map[string]interface{}{
+				"load_balancer_id": 1,
+				"name":             "tfvip",
+				"description":      "terraform virtual server",
+				"vip_address":      "10.0.0.100",
+				"vip_port":         443,
+				"vip_protocol":     "https",
+				"vip_hostname":     "app.example.com",
+				"pool_id":          5,
+				"ssl_profile":      "clientssl",
+				"ssl_cert_id":      6,
+				"enabled":          false,
+			},
+			expected: map[string]interface{}{
+				"vipName":     "tfvip",
+				"description": "terraform virtual server",
+				"vipAddress":  "10.0.0.100",
+				"vipPort":     443,
+				"vipProtocol": "https",
+				"vipHostname": "app.example.com",
+				"sslProfile":  "clientssl",
+				"active":      false,
+				"pool":        map[string]interface{}{"id": 5},
+				"sslCert":     map[string]interface{}{"id": 6},
+			},
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceLoadBalancerVirtualServer().Schema, c.config)
+		if payload := loadBalancerVirtualServerPayload(d); !reflect.DeepEqual(payload, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, payload)
+		}
+	}
+}
+
+func TestResourceLoadBalancerVirtualServerCreate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	d := schema.TestResourceDataRaw(t, resourceLoadBalancerVirtualServer().Schema, map[string]interface{}{
+		"load_balancer_id": 1,
+		"name":             "tfvip",
+		"vip_address":      "10.0.0.100",
+		"vip_port":         443,
+		"vip_protocol":     "https",
+		"pool_id":          5,
+	})
+	if diags := resourceLoadBalancerVirtualServerCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if requests := api.Requests(http.MethodPost, "/api/load-balancers/1/virtual-servers"); len(requests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(requests))
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the id of the created virtual server to be set")
+	}
+	if requests := api.Requests(http.MethodGet, "/api/load-balancers/1/virtual-servers/"+d.Id()); len(requests) != 1 {
+		t.Errorf("expected the virtual server to be read back from its load balancer, got %d requests", len(requests))
+	}
+	expected := map[string]interface{}{
+		"name":         "tfvip",
+		"vip_address":  "10.0.0.100",
+		"vip_port":     443,
+		"vip_protocol": "https",
+		"pool_id":      5,
+		"enabled":      true,
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be read back as %v, got %v", k, v, value)
+		}
+	}
+}
+
+func TestResourceLoadBalancerVirtualServerImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	virtualServerId := api.Seed("/api/load-balancers/7/virtual-servers", map[string]interface{}{
+		"vipName":     "tfvip",
+		"vipAddress":  "10.0.0.100",
+		"vipPort":     80,
+		"vipProtocol": "http",
+		"vipStatus":   "online",
+		"active":      true,
+		"pool":        map[string]interface{}{"id": 5},
+	})
+
+	r := resourceLoadBalancerVirtualServer()
+	d := r.Data(nil)
+	d.SetId("7/" + int64ToString(virtualServerId))
+	imported, err := resourceLoadBalancerVirtualServerImport(context.Background(), d, api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+	d = imported[0]
+	if diags := resourceLoadBalancerVirtualServerRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != int64ToString(virtualServerId) {
+		t.Errorf("expected the id %d, got %s", virtualServerId, d.Id())
+	}
+	expected := map[string]interface{}{
+		"load_balancer_id": 7,
+		"name":             "tfvip",
+		"vip_address":      "10.0.0.100",
+		"vip_port":         80,
+		"vip_protocol":     "http",
+		"pool_id":          5,
+		"status":           "online",
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+
+	for _, importId := range []string{"", "7", "7/", "/1", "7/1/2"} {
+		d := r.Data(nil)
+		d.SetId(importId)
+		if _, err := resourceLoadBalancerVirtualServerImport(context.Background(), d, api.Meta()); err == nil {
+			t.Errorf("expected an error importing %q", importId)
+		}
+	}
+}
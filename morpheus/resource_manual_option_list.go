@@ -79,7 +79,7 @@ func resourceManualOptionList() *schema.Resource {
 }
 
 func resourceManualOptionListCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -124,7 +124,7 @@ func resourceManualOptionListCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceManualOptionListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -177,7 +177,7 @@ func resourceManualOptionListRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceManualOptionListUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -216,7 +216,7 @@ func resourceManualOptionListUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceManualOptionListDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -0,0 +1,55 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMorpheusManualOptionList_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	resourceName := "morpheus_manual_option_list.tf_example_manual_option_list"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFakeCollectionEmpty(api, "/api/library/option-type-lists"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testAccMorpheusManualOptionListConfig("Terraform manual option list", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tfmanuallistdemo"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Terraform manual option list"),
+					resource.TestCheckResourceAttr(resourceName, "real_time", "false"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testAccMorpheusManualOptionListConfig("Updated manual option list", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Updated manual option list"),
+					resource.TestCheckResourceAttr(resourceName, "real_time", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMorpheusManualOptionListConfig(description string, realTime bool) string {
+	return fmt.Sprintf(`
+resource "morpheus_manual_option_list" "tf_example_manual_option_list" {
+  name        = "tfmanuallistdemo"
+  description = %q
+  visibility  = "private"
+  real_time   = %t
+  dataset     = <<POLICY
+[{"name": "small", "value": "s"}, {"name": "large", "value": "l"}]
+POLICY
+}
+`, description, realTime)
+}
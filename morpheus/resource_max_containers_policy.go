@@ -102,7 +102,7 @@ func resourceMaxContainersPolicy() *schema.Resource {
 }
 
 func resourceMaxContainersPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -172,7 +172,7 @@ func resourceMaxContainersPolicyCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceMaxContainersPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -244,7 +244,7 @@ func resourceMaxContainersPolicyRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceMaxContainersPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -311,7 +311,7 @@ func resourceMaxContainersPolicyUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceMaxContainersPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
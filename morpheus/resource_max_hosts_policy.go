@@ -102,7 +102,7 @@ func resourceMaxHostsPolicy() *schema.Resource {
 }
 
 func resourceMaxHostsPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -172,7 +172,7 @@ func resourceMaxHostsPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceMaxHostsPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -244,7 +244,7 @@ func resourceMaxHostsPolicyRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceMaxHostsPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -311,7 +311,7 @@ func resourceMaxHostsPolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceMaxHostsPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
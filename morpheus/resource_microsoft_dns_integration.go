@@ -0,0 +1,266 @@
+package morpheus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMicrosoftDnsIntegration() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Microsoft DNS integration resource",
+		CreateContext: resourceMicrosoftDnsIntegrationCreate,
+		ReadContext:   resourceMicrosoftDnsIntegrationRead,
+		UpdateContext: resourceMicrosoftDnsIntegrationUpdate,
+		DeleteContext: resourceMicrosoftDnsIntegrationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The id of the Microsoft DNS integration",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the Microsoft DNS integration",
+				Required:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the Microsoft DNS integration is enabled",
+				Optional:    true,
+				Default:     true,
+			},
+			"server": {
+				Type:        schema.TypeString,
+				Description: "The hostname or IP address of the Microsoft DNS server",
+				Required:    true,
+			},
+			"credential_id": {
+				Description:   "The id of the credential store entry used for authentication",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"username", "password"},
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Description: "The username of the account used to connect to the Microsoft DNS server",
+				Optional:    true,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "The password of the account used to connect to the Microsoft DNS server",
+				Optional:    true,
+				Sensitive:   true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					h := sha256.New()
+					h.Write([]byte(new))
+					sha256_hash := hex.EncodeToString(h.Sum(nil))
+					return strings.EqualFold(old, sha256_hash)
+				},
+				DiffSuppressOnRefresh: true,
+			},
+			"zone_filter": {
+				Type:        schema.TypeString,
+				Description: "A filter that limits the DNS zones discovered by the integration (e.g. example.local)",
+				Optional:    true,
+			},
+			"create_pointer": {
+				Type:        schema.TypeBool,
+				Description: "Whether a PTR record is created with each A record",
+				Optional:    true,
+				Default:     true,
+			},
+			"zones": {
+				Type:        schema.TypeList,
+				Description: "The DNS zones discovered by the Microsoft DNS integration",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the network domain of the DNS zone",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the DNS zone",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceMicrosoftDnsIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integration := make(map[string]interface{})
+
+	integration["type"] = "microsoftDns"
+	integration["name"] = d.Get("name").(string)
+	integration["enabled"] = d.Get("enabled").(bool)
+	integration["serviceUrl"] = d.Get("server").(string)
+	integration["zoneFilter"] = d.Get("zone_filter").(string)
+
+	if d.Get("credential_id").(int) != 0 {
+		credential := make(map[string]interface{})
+		credential["type"] = "username-password"
+		credential["id"] = d.Get("credential_id").(int)
+		integration["credential"] = credential
+	} else {
+		integration["serviceUsername"] = d.Get("username").(string)
+		integration["servicePassword"] = d.Get("password").(string)
+	}
+
+	config := make(map[string]interface{})
+	config["createPointer"] = d.Get("create_pointer").(bool)
+	integration["config"] = config
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"integration": integration,
+		},
+	}
+
+	resp, err := client.CreateIntegration(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*morpheus.CreateIntegrationResult)
+	integrationResult := result.Integration
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(integrationResult.ID))
+
+	resourceMicrosoftDnsIntegrationRead(ctx, d, meta)
+	return diags
+}
+
+func resourceMicrosoftDnsIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integration, resp, err := getNetworkIntegration(client, d.Id())
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+
+	// store resource data
+	d.SetId(int64ToString(integration.ID))
+	d.Set("name", integration.Name)
+	d.Set("enabled", integration.Enabled)
+	d.Set("server", integration.ServiceUrl)
+	if integration.Credential.ID == 0 {
+		d.Set("username", integration.ServiceUsername)
+		d.Set("password", integration.ServicePasswordHash)
+	} else {
+		d.Set("credential_id", integration.Credential.ID)
+	}
+	d.Set("zone_filter", integration.ZoneFilter)
+	d.Set("create_pointer", integration.Config.CreatePointer)
+
+	zones, err := networkIntegrationZones(client, integration.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("zones", zones)
+
+	return diags
+}
+
+func resourceMicrosoftDnsIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	integration := make(map[string]interface{})
+
+	integration["type"] = "microsoftDns"
+	integration["name"] = d.Get("name").(string)
+	integration["enabled"] = d.Get("enabled").(bool)
+	integration["serviceUrl"] = d.Get("server").(string)
+	integration["zoneFilter"] = d.Get("zone_filter").(string)
+
+	if d.Get("credential_id").(int) != 0 {
+		credential := make(map[string]interface{})
+		credential["type"] = "username-password"
+		credential["id"] = d.Get("credential_id").(int)
+		integration["credential"] = credential
+	} else {
+		if d.HasChange("username") {
+			integration["serviceUsername"] = d.Get("username").(string)
+		}
+		if d.HasChange("password") {
+			integration["servicePassword"] = d.Get("password").(string)
+		}
+	}
+
+	config := make(map[string]interface{})
+	config["createPointer"] = d.Get("create_pointer").(bool)
+	integration["config"] = config
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"integration": integration,
+		},
+	}
+
+	resp, err := client.UpdateIntegration(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceMicrosoftDnsIntegrationRead(ctx, d, meta)
+}
+
+func resourceMicrosoftDnsIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteIntegration(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
@@ -126,7 +126,7 @@ func resourceMonitoringSetting() *schema.Resource {
 }
 
 func resourceMonitoringSettingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -228,7 +228,7 @@ func resourceMonitoringSettingCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceMonitoringSettingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -273,7 +273,7 @@ func resourceMonitoringSettingRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceMonitoringSettingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	monitoringSettings := make(map[string]interface{})
 
@@ -302,6 +302,8 @@ func resourceMVMInstance() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"power_state":       powerStateSchema(),
+			"on_create_failure": onCreateFailureSchema(),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -310,7 +312,7 @@ func resourceMVMInstance() *schema.Resource {
 }
 
 func resourceMVMInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -501,6 +503,7 @@ func resourceMVMInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 	result := resp.Result.(*morpheus.CreateInstanceResult)
 	instance := result.Instance
 	instanceStatus := "provisioning"
+	pollConfig := meta.(*providerMeta).config
 
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"provisioning", "starting", "stopping", "pending"},
@@ -515,10 +518,10 @@ func resourceMVMInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 			instanceStatus = instance.Status
 			return result, instance.Status, nil
 		},
-		Timeout:      3 * time.Hour,
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 		MinTimeout:   1 * time.Minute,
-		Delay:        2 * time.Minute,
-		PollInterval: 30 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
@@ -529,18 +532,23 @@ func resourceMVMInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	// Successfully created resource, now set id
 	d.SetId(int64ToString(instance.ID))
-	resourceMVMInstanceRead(ctx, d, meta)
 
 	// Fail the instance deployment if the
 	// instance status is in a failed state
-	if instanceStatus == "failed" {
-		return diag.Errorf("error creating instance: failed to create server")
+	if isProvisioningFailure(instanceStatus) {
+		return handleProvisioningFailure(ctx, d, meta, "instance", instanceStatus, resourceMVMInstanceDelete)
+	}
+
+	// Power off or suspend the instance if requested once it has been provisioned
+	if powerDiags := setInstancePowerState(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); powerDiags.HasError() {
+		return powerDiags
 	}
+	resourceMVMInstanceRead(ctx, d, meta)
 	return diags
 }
 
 func resourceMVMInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -581,6 +589,11 @@ func resourceMVMInstanceRead(ctx context.Context, d *schema.ResourceData, meta i
 	d.SetId(int64ToString(instance.ID))
 	d.Set("name", instance.Name)
 	d.Set("description", instance.Description)
+	// Only report settled power states so that an instance that is
+	// starting or stopping is not seen as drift
+	if powerState := instancePowerState(instance.Status); powerState != "" {
+		d.Set("power_state", powerState)
+	}
 	d.Set("cloud_id", instance.Cloud.ID)
 	d.Set("group_id", instance.Group.ID)
 	d.Set("instance_type_id", instance.InstanceType.ID)
@@ -663,7 +676,7 @@ func resourceMVMInstanceRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceMVMInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	instanceGetResp, err := client.GetInstance(toInt64(id), &morpheus.Request{})
@@ -778,9 +791,10 @@ func resourceMVMInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		instance = result.Instance
 	}
 
+	pollConfig := meta.(*providerMeta).config
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"resizing", "pending"},
-		Target:  []string{"running"},
+		Target:  []string{"running", "stopped", "suspended"},
 		Refresh: func() (interface{}, string, error) {
 			instanceDetails, err := client.GetInstance(toInt64(id), &morpheus.Request{})
 			if err != nil {
@@ -790,10 +804,10 @@ func resourceMVMInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 			instance := result.Instance
 			return result, instance.Status, nil
 		},
-		Timeout:      30 * time.Minute,
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
 		MinTimeout:   1 * time.Minute,
-		Delay:        1 * time.Minute,
-		PollInterval: 30 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
@@ -802,13 +816,19 @@ func resourceMVMInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error updating instance: %s", err)
 	}
 
+	if d.HasChange("power_state") {
+		if diags := setInstancePowerState(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
 	// Successfully updated resource, now set id
 	d.SetId(int64ToString(instance.ID))
 	return resourceMVMInstanceRead(ctx, d, meta)
 }
 
 func resourceMVMInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -832,6 +852,7 @@ func resourceMVMInstanceDelete(ctx context.Context, d *schema.ResourceData, meta
 	}
 	log.Printf("API RESPONSE: %s", resp)
 
+	pollConfig := meta.(*providerMeta).config
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"removing", "pendingRemoval", "stopping", "pending", "warning"},
 		Target:  []string{"removed"},
@@ -847,10 +868,10 @@ func resourceMVMInstanceDelete(ctx context.Context, d *schema.ResourceData, meta
 			instance := result.Instance
 			return result, instance.Status, nil
 		},
-		Timeout:      30 * time.Minute,
+		Timeout:      d.Timeout(schema.TimeoutDelete),
 		MinTimeout:   1 * time.Minute,
-		Delay:        1 * time.Minute,
-		PollInterval: 30 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
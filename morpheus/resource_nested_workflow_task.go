@@ -86,7 +86,7 @@ func resourceNestedWorkflowTask() *schema.Resource {
 }
 
 func resourceNestedWorkflowTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -143,7 +143,7 @@ func resourceNestedWorkflowTaskCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceNestedWorkflowTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -194,7 +194,7 @@ func resourceNestedWorkflowTaskRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNestedWorkflowTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -245,7 +245,7 @@ func resourceNestedWorkflowTaskUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceNestedWorkflowTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
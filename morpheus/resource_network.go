@@ -0,0 +1,539 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNetwork() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus network resource",
+		CreateContext: resourceNetworkCreate,
+		ReadContext:   resourceNetworkRead,
+		UpdateContext: resourceNetworkUpdate,
+		DeleteContext: resourceNetworkDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the network",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the network",
+				Required:    true,
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Description: "The display or friendly name of the network",
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the network",
+				Optional:    true,
+			},
+			"cloud_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the cloud to create the network in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the network type (e.g. the MVM VLAN, AWS subnet or NSX-T segment network type)",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"resource_pool_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the resource pool the network is created in (e.g. the AWS VPC)",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Description: "Whether to manage the existing network with the same name in the cloud, such as a network discovered by the cloud sync, instead of creating a new one. An adopted network is left in Morpheus when the resource is destroyed",
+				Optional:    true,
+				Default:     false,
+			},
+			"cidr": {
+				Type:        schema.TypeString,
+				Description: "The CIDR of the network",
+				Optional:    true,
+				Computed:    true,
+			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Description: "The gateway of the network",
+				Optional:    true,
+			},
+			"dns_primary": {
+				Type:        schema.TypeString,
+				Description: "The primary DNS server of the network",
+				Optional:    true,
+			},
+			"dns_secondary": {
+				Type:        schema.TypeString,
+				Description: "The secondary DNS server of the network",
+				Optional:    true,
+			},
+			"search_domains": {
+				Type:        schema.TypeString,
+				Description: "The DNS search domains of the network",
+				Optional:    true,
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Description: "The VLAN ID of the network",
+				Optional:    true,
+				Computed:    true,
+			},
+			"dhcp_server": {
+				Type:        schema.TypeBool,
+				Description: "Whether the network has a DHCP server",
+				Optional:    true,
+				Default:     false,
+			},
+			"allow_static_override": {
+				Type:        schema.TypeBool,
+				Description: "Whether a static IP address can be assigned to instances on the network when it uses an IP pool",
+				Optional:    true,
+				Default:     false,
+			},
+			"pool_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the IP pool assigned to the network",
+				Optional:    true,
+			},
+			"network_domain_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the network domain assigned to the network",
+				Optional:    true,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Description: "Whether the network is active",
+				Optional:    true,
+				Default:     true,
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Description:  "Whether the network is visible in sub-tenants or not",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public"}, false),
+				Default:      "private",
+			},
+			"all_group_access": {
+				Type:        schema.TypeBool,
+				Description: "Whether all groups will be granted access to the network",
+				Optional:    true,
+			},
+			"group_access": {
+				Type:        schema.TypeList,
+				Description: "A list of Morpheus group configuration to enable group access to the network",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the Morpheus group to grant access to the network",
+							Required:    true,
+						},
+						"default": {
+							Type:        schema.TypeBool,
+							Description: "Whether the network will be a default for the associated group",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"tenant_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of tenant ids granted access to the network",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"config": {
+				Type:             schema.TypeString,
+				Description:      "Additional network type specific settings (JSON), such as the availability zone of an AWS subnet or the transport zone of an NSX-T segment",
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentJsonDiffs,
+				ValidateFunc:     validation.StringIsJSON,
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Description: "The external id of the network",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	if d.Get("adopt_existing").(bool) {
+		network, err := findExistingNetwork(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(int64ToString(network.ID))
+		return resourceNetworkUpdate(ctx, d, meta)
+	}
+
+	network, err := networkPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	network["zone"] = map[string]interface{}{
+		"id": d.Get("cloud_id").(int),
+	}
+	network["type"] = map[string]interface{}{
+		"id": d.Get("type_id").(int),
+	}
+	if d.Get("resource_pool_id").(int) != 0 {
+		network["zonePool"] = map[string]interface{}{
+			"id": d.Get("resource_pool_id").(int),
+		}
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/networks",
+		Body: map[string]interface{}{
+			"network": network,
+		},
+		Result: &GetMorpheusNetworkResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusNetworkResult)
+	if result.Network == nil {
+		return diag.Errorf("create operation: network not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.Network.ID))
+
+	resourceNetworkRead(ctx, d, meta)
+	return diags
+}
+
+func resourceNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/networks/%s", id),
+		Result: &GetMorpheusNetworkResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusNetworkResult)
+	network := result.Network
+	if network == nil {
+		return diag.Errorf("read operation: network not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(network.ID))
+	d.Set("name", network.Name)
+	d.Set("display_name", network.DisplayName)
+	d.Set("description", network.Description)
+	d.Set("cloud_id", network.Zone.ID)
+	d.Set("type_id", network.Type.ID)
+	d.Set("resource_pool_id", network.ZonePool.ID)
+	d.Set("cidr", network.Cidr)
+	d.Set("gateway", network.Gateway)
+	d.Set("dns_primary", network.DnsPrimary)
+	d.Set("dns_secondary", network.DnsSecondary)
+	d.Set("search_domains", network.SearchDomains)
+	d.Set("vlan_id", network.VlanId)
+	d.Set("dhcp_server", network.DhcpServer)
+	d.Set("allow_static_override", network.AllowStaticOverride)
+	d.Set("pool_id", network.Pool.ID)
+	d.Set("network_domain_id", network.NetworkDomain.ID)
+	d.Set("active", network.Active)
+	d.Set("visibility", network.Visibility)
+	d.Set("external_id", network.ExternalId)
+	d.Set("all_group_access", network.ResourcePermission.All)
+	// Group Access
+	var groupAccess []map[string]interface{}
+	for _, group := range network.ResourcePermission.Sites {
+		groupAccess = append(groupAccess, map[string]interface{}{
+			"group_id": group.ID,
+			"default":  group.Default,
+		})
+	}
+	d.Set("group_access", groupAccess)
+	// Tenant Access
+	var tenantIds []int64
+	for _, tenant := range network.Tenants {
+		tenantIds = append(tenantIds, tenant.ID)
+	}
+	d.Set("tenant_ids", tenantIds)
+
+	return diags
+}
+
+func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	network, err := networkPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/networks/%s", id),
+		Body: map[string]interface{}{
+			"network": network,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceNetworkRead(ctx, d, meta)
+}
+
+func resourceNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	// An adopted network belongs to the cloud, it is only removed from the
+	// state
+	if d.Get("adopt_existing").(bool) {
+		log.Printf("[INFO] leaving the adopted network %s in Morpheus", id)
+		d.SetId("")
+		return diags
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/networks/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// findExistingNetwork finds the network to adopt, which is the network with
+// the name in the cloud and in the resource pool when one is set
+func findExistingNetwork(client *morpheus.Client, d *schema.ResourceData) (*MorpheusNetwork, error) {
+	name := d.Get("name").(string)
+	cloudId := int64(d.Get("cloud_id").(int))
+	resourcePoolId := int64(d.Get("resource_pool_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   "/api/networks",
+		QueryParams: map[string]string{
+			"name":   name,
+			"zoneId": int64ToString(cloudId),
+		},
+		Result: &ListMorpheusNetworksResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var matches []MorpheusNetwork
+	if networks := resp.Result.(*ListMorpheusNetworksResult).Networks; networks != nil {
+		for _, network := range *networks {
+			if network.Name != name || network.Zone.ID != cloudId {
+				continue
+			}
+			if resourcePoolId != 0 && network.ZonePool.ID != resourcePoolId {
+				continue
+			}
+			matches = append(matches, network)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no network named %s was found in cloud %d to adopt", name, cloudId)
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("found %d networks named %s in cloud %d, set the resource_pool_id or import the network by its ID", len(matches), name, cloudId)
+	}
+
+	network := matches[0]
+	if typeId := int64(d.Get("type_id").(int)); network.Type.ID != typeId {
+		return nil, fmt.Errorf("the network %s in cloud %d has the network type %d, not %d", name, cloudId, network.Type.ID, typeId)
+	}
+	return &network, nil
+}
+
+// networkPayload builds the settings of a network that can be changed after
+// it has been created
+func networkPayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	tenantsPayload := make([]int, 0)
+	if attr, ok := d.GetOk("tenant_ids"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			tenantsPayload = append(tenantsPayload, s.(int))
+		}
+	}
+
+	network := map[string]interface{}{
+		"name":                d.Get("name").(string),
+		"description":         d.Get("description").(string),
+		"cidr":                d.Get("cidr").(string),
+		"gateway":             d.Get("gateway").(string),
+		"dnsPrimary":          d.Get("dns_primary").(string),
+		"dnsSecondary":        d.Get("dns_secondary").(string),
+		"searchDomains":       d.Get("search_domains").(string),
+		"dhcpServer":          d.Get("dhcp_server").(bool),
+		"allowStaticOverride": d.Get("allow_static_override").(bool),
+		"active":              d.Get("active").(bool),
+		"visibility":          d.Get("visibility").(string),
+		"resourcePermissions": map[string]interface{}{
+			"all":   d.Get("all_group_access").(bool),
+			"sites": parseGroupAccess(d.Get("group_access").([]interface{})),
+		},
+		"tenantPermissions": map[string]interface{}{
+			"accounts": tenantsPayload,
+		},
+	}
+	if displayName := d.Get("display_name").(string); displayName != "" {
+		network["displayName"] = displayName
+	}
+	if vlanId := d.Get("vlan_id").(int); vlanId != 0 {
+		network["vlanId"] = vlanId
+	}
+
+	// A null pool or network domain removes it from the network
+	network["pool"] = nil
+	if poolId := d.Get("pool_id").(int); poolId != 0 {
+		network["pool"] = map[string]interface{}{
+			"id": poolId,
+		}
+	}
+	network["networkDomain"] = nil
+	if domainId := d.Get("network_domain_id").(int); domainId != 0 {
+		network["networkDomain"] = map[string]interface{}{
+			"id": domainId,
+		}
+	}
+
+	if d.Get("config").(string) != "" {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(d.Get("config").(string)), &config); err != nil {
+			return nil, fmt.Errorf("unable to parse the network config: %s", err)
+		}
+		network["config"] = config
+	}
+
+	return network, nil
+}
+
+type MorpheusNetwork struct {
+	ID                  int64  `json:"id"`
+	Name                string `json:"name"`
+	DisplayName         string `json:"displayName"`
+	Description         string `json:"description"`
+	ExternalId          string `json:"externalId"`
+	Cidr                string `json:"cidr"`
+	Gateway             string `json:"gateway"`
+	DnsPrimary          string `json:"dnsPrimary"`
+	DnsSecondary        string `json:"dnsSecondary"`
+	SearchDomains       string `json:"searchDomains"`
+	VlanId              int64  `json:"vlanId"`
+	DhcpServer          bool   `json:"dhcpServer"`
+	AllowStaticOverride bool   `json:"allowStaticOverride"`
+	Active              bool   `json:"active"`
+	Visibility          string `json:"visibility"`
+	Zone                struct {
+		ID int64 `json:"id"`
+	} `json:"zone"`
+	Type struct {
+		ID   int64  `json:"id"`
+		Code string `json:"code"`
+	} `json:"type"`
+	ZonePool struct {
+		ID int64 `json:"id"`
+	} `json:"zonePool"`
+	Pool struct {
+		ID int64 `json:"id"`
+	} `json:"pool"`
+	NetworkDomain struct {
+		ID int64 `json:"id"`
+	} `json:"networkDomain"`
+	ResourcePermission struct {
+		All   bool `json:"all"`
+		Sites []struct {
+			ID      int64  `json:"id"`
+			Name    string `json:"name"`
+			Default bool   `json:"default"`
+		} `json:"sites"`
+	} `json:"resourcePermission"`
+	Tenants []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"tenants"`
+}
+
+type GetMorpheusNetworkResult struct {
+	Network *MorpheusNetwork `json:"network"`
+}
+
+type ListMorpheusNetworksResult struct {
+	Networks *[]MorpheusNetwork   `json:"networks"`
+	Meta     *morpheus.MetaResult `json:"meta"`
+}
@@ -0,0 +1,214 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNetworkDomainRecord() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus network domain record resource, the record is created with the DNS integration of the network domain",
+		CreateContext: resourceNetworkDomainRecordCreate,
+		ReadContext:   resourceNetworkDomainRecordRead,
+		DeleteContext: resourceNetworkDomainRecordDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the network domain record",
+				Computed:    true,
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the network domain to create the record in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the record, relative to the network domain (e.g. app01)",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the record (A, AAAA, CNAME, PTR, TXT)",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"A", "AAAA", "CNAME", "PTR", "TXT"}, false),
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Description: "The content of the record, such as the IP address of an A record or the target hostname of a CNAME record",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Description: "The time to live of the record in seconds",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Description: "The fully qualified domain name of the record",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNetworkDomainRecordImport,
+		},
+	}
+}
+
+func resourceNetworkDomainRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	domainId := int64(d.Get("domain_id").(int))
+
+	record := map[string]interface{}{
+		"name":    d.Get("name").(string),
+		"type":    d.Get("type").(string),
+		"content": d.Get("content").(string),
+	}
+	if ttl := d.Get("ttl").(int); ttl != 0 {
+		record["ttl"] = ttl
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/networks/domains/%d/records", domainId),
+		Body: map[string]interface{}{
+			"networkDomainRecord": record,
+		},
+		Result: &GetMorpheusNetworkDomainRecordResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusNetworkDomainRecordResult)
+	if result.NetworkDomainRecord == nil {
+		return diag.Errorf("create operation: network domain record not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.NetworkDomainRecord.ID))
+
+	resourceNetworkDomainRecordRead(ctx, d, meta)
+	return diags
+}
+
+func resourceNetworkDomainRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	domainId := int64(d.Get("domain_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/networks/domains/%d/records/%s", domainId, id),
+		Result: &GetMorpheusNetworkDomainRecordResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusNetworkDomainRecordResult)
+	record := result.NetworkDomainRecord
+	if record == nil {
+		return diag.Errorf("read operation: network domain record not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(record.ID))
+	d.Set("name", record.Name)
+	d.Set("type", record.Type)
+	d.Set("content", record.Content)
+	d.Set("ttl", record.Ttl)
+	d.Set("fqdn", record.Fqdn)
+
+	return diags
+}
+
+func resourceNetworkDomainRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	domainId := int64(d.Get("domain_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/networks/domains/%d/records/%s", domainId, id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceNetworkDomainRecordImport imports a record using an id in the
+// format domain_id/record_id
+func resourceNetworkDomainRecordImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected domain_id/record_id", d.Id())
+	}
+	domainId, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected format of ID (%s), the domain_id must be a number", d.Id())
+	}
+	d.Set("domain_id", domainId)
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+type MorpheusNetworkDomainRecord struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Fqdn    string `json:"fqdn"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	Ttl     int64  `json:"ttl"`
+}
+
+type GetMorpheusNetworkDomainRecordResult struct {
+	NetworkDomainRecord *MorpheusNetworkDomainRecord `json:"networkDomainRecord"`
+}
@@ -0,0 +1,111 @@
+package morpheus
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceNetworkDomainRecordImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	recordId := api.Seed("/api/networks/domains/5/records", map[string]interface{}{
+		"name":    "app01",
+		"fqdn":    "app01.example.com",
+		"type":    "A",
+		"content": "10.0.0.10",
+		"ttl":     300,
+	})
+
+	r := resourceNetworkDomainRecord()
+	d := r.Data(nil)
+	d.SetId("5/" + int64ToString(recordId))
+	imported, err := resourceNetworkDomainRecordImport(context.Background(), d, api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+	d = imported[0]
+	if diags := resourceNetworkDomainRecordRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != int64ToString(recordId) {
+		t.Errorf("expected the id %d, got %s", recordId, d.Id())
+	}
+	expected := map[string]interface{}{
+		"domain_id": 5,
+		"name":      "app01",
+		"fqdn":      "app01.example.com",
+		"type":      "A",
+		"content":   "10.0.0.10",
+		"ttl":       300,
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+	if requests := api.Requests("GET", "/api/networks/domains/5/records/"+int64ToString(recordId)); len(requests) != 1 {
+		t.Errorf("expected the record to be read from its domain, got %d requests", len(requests))
+	}
+
+	// The record is not found in another domain
+	d = r.Data(nil)
+	d.SetId("6/" + int64ToString(recordId))
+	if _, err := resourceNetworkDomainRecordImport(context.Background(), d, api.Meta()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diags := resourceNetworkDomainRecordRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the record not to be found in another domain, got the id %s", d.Id())
+	}
+}
+
+func TestResourceNetworkDomainRecordImport_invalidId(t *testing.T) {
+	for _, id := range []string{"1", "/2", "1/", "1/2/3", "domain/2"} {
+		d := resourceNetworkDomainRecord().Data(nil)
+		d.SetId(id)
+		_, err := resourceNetworkDomainRecordImport(context.Background(), d, nil)
+		if err == nil || !strings.Contains(err.Error(), "unexpected format of ID") {
+			t.Errorf("%q: expected an error for the invalid ID, got %v", id, err)
+		}
+	}
+}
+
+func TestResourceNetworkDomainRecordCreate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	r := resourceNetworkDomainRecord()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"domain_id": 5,
+		"name":      "app01",
+		"type":      "CNAME",
+		"content":   "web01.example.com",
+	})
+
+	if diags := resourceNetworkDomainRecordCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if count := api.Count("/api/networks/domains/5/records"); count != 1 {
+		t.Fatalf("expected 1 record in the domain, got %d", count)
+	}
+	record, _ := api.Get("/api/networks/domains/5/records", toInt64(d.Id()))
+	if record["type"] != "CNAME" || record["content"] != "web01.example.com" {
+		t.Errorf("unexpected record %v", record)
+	}
+	if _, ok := record["ttl"]; ok {
+		t.Errorf("expected the ttl to be left to the DNS integration, got %v", record["ttl"])
+	}
+
+	if diags := resourceNetworkDomainRecordDelete(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if count := api.Count("/api/networks/domains/5/records"); count != 0 {
+		t.Fatalf("expected the record to be deleted, got %d records", count)
+	}
+}
@@ -0,0 +1,315 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNetworkGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus network group resource",
+		CreateContext: resourceNetworkGroupCreate,
+		ReadContext:   resourceNetworkGroupRead,
+		UpdateContext: resourceNetworkGroupUpdate,
+		DeleteContext: resourceNetworkGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the network group",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the network group",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the network group",
+				Optional:    true,
+			},
+			"network_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of the IDs of the networks in the network group",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"subnet_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of the IDs of the network subnets in the network group",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Description: "Whether the network group is active",
+				Optional:    true,
+				Default:     true,
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Description:  "Whether the network group is visible in sub-tenants or not",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public"}, false),
+				Default:      "private",
+			},
+			"all_group_access": {
+				Type:        schema.TypeBool,
+				Description: "Whether all groups will be granted access to the network group",
+				Optional:    true,
+			},
+			"group_access": {
+				Type:        schema.TypeList,
+				Description: "A list of Morpheus group configuration to enable group access to the network group",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the Morpheus group to grant access to the network group",
+							Required:    true,
+						},
+						"default": {
+							Type:        schema.TypeBool,
+							Description: "Whether the network group will be a default for the associated group",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"tenant_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of tenant ids granted access to the network group",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceNetworkGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/networks/groups",
+		Body: map[string]interface{}{
+			"networkGroup": networkGroupPayload(d),
+		},
+		Result: &GetMorpheusNetworkGroupResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusNetworkGroupResult)
+	if result.NetworkGroup == nil {
+		return diag.Errorf("create operation: network group not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.NetworkGroup.ID))
+
+	resourceNetworkGroupRead(ctx, d, meta)
+	return diags
+}
+
+func resourceNetworkGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/networks/groups/%s", id),
+		Result: &GetMorpheusNetworkGroupResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusNetworkGroupResult)
+	networkGroup := result.NetworkGroup
+	if networkGroup == nil {
+		return diag.Errorf("read operation: network group not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(networkGroup.ID))
+	d.Set("name", networkGroup.Name)
+	d.Set("description", networkGroup.Description)
+	d.Set("active", networkGroup.Active)
+	d.Set("visibility", networkGroup.Visibility)
+	// Member networks and subnets, networks removed from the network group
+	// outside of terraform are reported as drift
+	var networkIds []int64
+	for _, network := range networkGroup.Networks {
+		networkIds = append(networkIds, network.ID)
+	}
+	d.Set("network_ids", networkIds)
+	var subnetIds []int64
+	for _, subnet := range networkGroup.Subnets {
+		subnetIds = append(subnetIds, subnet.ID)
+	}
+	d.Set("subnet_ids", subnetIds)
+	d.Set("all_group_access", networkGroup.ResourcePermission.All)
+	// Group Access
+	var groupAccess []map[string]interface{}
+	for _, group := range networkGroup.ResourcePermission.Sites {
+		groupAccess = append(groupAccess, map[string]interface{}{
+			"group_id": group.ID,
+			"default":  group.Default,
+		})
+	}
+	d.Set("group_access", groupAccess)
+	// Tenant Access
+	var tenantIds []int64
+	for _, tenant := range networkGroup.Tenants {
+		tenantIds = append(tenantIds, tenant.ID)
+	}
+	d.Set("tenant_ids", tenantIds)
+
+	return diags
+}
+
+func resourceNetworkGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/networks/groups/%s", id),
+		Body: map[string]interface{}{
+			"networkGroup": networkGroupPayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceNetworkGroupRead(ctx, d, meta)
+}
+
+func resourceNetworkGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/networks/groups/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// networkGroupPayload builds the settings of a network group
+func networkGroupPayload(d *schema.ResourceData) map[string]interface{} {
+	networkIds := make([]int, 0)
+	for _, networkId := range d.Get("network_ids").(*schema.Set).List() {
+		networkIds = append(networkIds, networkId.(int))
+	}
+
+	subnetIds := make([]int, 0)
+	for _, subnetId := range d.Get("subnet_ids").(*schema.Set).List() {
+		subnetIds = append(subnetIds, subnetId.(int))
+	}
+
+	tenantsPayload := make([]int, 0)
+	if attr, ok := d.GetOk("tenant_ids"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			tenantsPayload = append(tenantsPayload, s.(int))
+		}
+	}
+
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"active":      d.Get("active").(bool),
+		"visibility":  d.Get("visibility").(string),
+		"networks":    networkIds,
+		"subnets":     subnetIds,
+		"resourcePermissions": map[string]interface{}{
+			"all":   d.Get("all_group_access").(bool),
+			"sites": parseGroupAccess(d.Get("group_access").([]interface{})),
+		},
+		"tenantPermissions": map[string]interface{}{
+			"accounts": tenantsPayload,
+		},
+	}
+}
+
+type MorpheusNetworkGroup struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Active      bool   `json:"active"`
+	Visibility  string `json:"visibility"`
+	Networks    []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"networks"`
+	Subnets []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"subnets"`
+	ResourcePermission struct {
+		All   bool `json:"all"`
+		Sites []struct {
+			ID      int64  `json:"id"`
+			Name    string `json:"name"`
+			Default bool   `json:"default"`
+		} `json:"sites"`
+	} `json:"resourcePermission"`
+	Tenants []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"tenants"`
+}
+
+type GetMorpheusNetworkGroupResult struct {
+	NetworkGroup *MorpheusNetworkGroup `json:"networkGroup"`
+}
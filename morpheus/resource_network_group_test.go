@@ -0,0 +1,62 @@
+package morpheus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceNetworkGroupRead_networkDrift(t *testing.T) {
+	api := newFakeMorpheus(t)
+	meta := api.Meta()
+	networkGroupId := api.Seed("/api/networks/groups", map[string]interface{}{
+		"name":       "tfnetworkgroup",
+		"active":     true,
+		"visibility": "private",
+		"networks": []interface{}{
+			map[string]interface{}{"id": 1, "name": "net-1"},
+			map[string]interface{}{"id": 2, "name": "net-2"},
+		},
+	})
+
+	r := resourceNetworkGroup()
+	config := map[string]interface{}{
+		"name":        "tfnetworkgroup",
+		"network_ids": []interface{}{1, 2},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId(int64ToString(networkGroupId))
+	if diags := resourceNetworkGroupRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Fatalf("expected no changes after the read, got %v", diff.Attributes)
+	}
+
+	// A network removed from the network group on the appliance is read
+	// back and planned to be added again
+	api.Update("/api/networks/groups", networkGroupId, map[string]interface{}{
+		"networks": []interface{}{
+			map[string]interface{}{"id": 1, "name": "net-1"},
+		},
+	})
+	if diags := resourceNetworkGroupRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if networkIds := d.Get("network_ids").(*schema.Set); networkIds.Len() != 1 || !networkIds.Contains(1) {
+		t.Fatalf("expected the network_ids to be read as [1], got %v", networkIds.List())
+	}
+	diff, err = r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || diff.Attributes["network_ids.#"] == nil || diff.Attributes["network_ids.#"].New != "2" {
+		t.Fatalf("expected a change of network_ids, got %v", diff)
+	}
+}
@@ -0,0 +1,447 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNetworkSubnet() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus network subnet resource",
+		CreateContext: resourceNetworkSubnetCreate,
+		ReadContext:   resourceNetworkSubnetRead,
+		UpdateContext: resourceNetworkSubnetUpdate,
+		DeleteContext: resourceNetworkSubnetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the network subnet",
+				Computed:    true,
+			},
+			"network_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the network to create the subnet in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the network subnet",
+				Required:    true,
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Description: "Whether to manage the existing subnet with the same name in the network, such as a subnet discovered by the cloud sync, instead of creating a new one. An adopted subnet is left in Morpheus when the resource is destroyed",
+				Optional:    true,
+				Default:     false,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the network subnet",
+				Optional:    true,
+			},
+			"cidr": {
+				Type:        schema.TypeString,
+				Description: "The CIDR of the network subnet",
+				Required:    true,
+			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Description: "The gateway of the network subnet",
+				Optional:    true,
+			},
+			"dns_primary": {
+				Type:        schema.TypeString,
+				Description: "The primary DNS server of the network subnet",
+				Optional:    true,
+			},
+			"dns_secondary": {
+				Type:        schema.TypeString,
+				Description: "The secondary DNS server of the network subnet",
+				Optional:    true,
+			},
+			"search_domains": {
+				Type:        schema.TypeString,
+				Description: "The DNS search domains of the network subnet",
+				Optional:    true,
+			},
+			"dhcp_server": {
+				Type:        schema.TypeBool,
+				Description: "Whether the network subnet has a DHCP server",
+				Optional:    true,
+				Default:     false,
+			},
+			"allow_static_override": {
+				Type:        schema.TypeBool,
+				Description: "Whether a static IP address can be assigned to instances on the network subnet when it uses an IP pool",
+				Optional:    true,
+				Default:     false,
+			},
+			"pool_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the IP pool assigned to the network subnet",
+				Optional:    true,
+			},
+			"network_domain_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the network domain assigned to the network subnet",
+				Optional:    true,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Description: "Whether the network subnet is active",
+				Optional:    true,
+				Default:     true,
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Description:  "Whether the network subnet is visible in sub-tenants or not",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public"}, false),
+				Default:      "private",
+			},
+			"all_group_access": {
+				Type:        schema.TypeBool,
+				Description: "Whether all groups will be granted access to the network subnet",
+				Optional:    true,
+			},
+			"group_access": {
+				Type:        schema.TypeList,
+				Description: "A list of Morpheus group configuration to enable group access to the network subnet",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the Morpheus group to grant access to the network subnet",
+							Required:    true,
+						},
+						"default": {
+							Type:        schema.TypeBool,
+							Description: "Whether the network subnet will be a default for the associated group",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"tenant_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of tenant ids granted access to the network subnet",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Description: "The external id of the network subnet",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceNetworkSubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	networkId := int64(d.Get("network_id").(int))
+
+	if d.Get("adopt_existing").(bool) {
+		subnet, err := findExistingNetworkSubnet(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(int64ToString(subnet.ID))
+		return resourceNetworkSubnetUpdate(ctx, d, meta)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/networks/%d/subnets", networkId),
+		Body: map[string]interface{}{
+			"subnet": networkSubnetPayload(d),
+		},
+		Result: &GetMorpheusNetworkSubnetResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusNetworkSubnetResult)
+	if result.Subnet == nil {
+		return diag.Errorf("create operation: network subnet not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.Subnet.ID))
+
+	resourceNetworkSubnetRead(ctx, d, meta)
+	return diags
+}
+
+func resourceNetworkSubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/subnets/%s", id),
+		Result: &GetMorpheusNetworkSubnetResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusNetworkSubnetResult)
+	subnet := result.Subnet
+	if subnet == nil {
+		return diag.Errorf("read operation: network subnet not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(subnet.ID))
+	d.Set("network_id", subnet.Network.ID)
+	d.Set("name", subnet.Name)
+	d.Set("description", subnet.Description)
+	d.Set("cidr", subnet.Cidr)
+	d.Set("gateway", subnet.Gateway)
+	d.Set("dns_primary", subnet.DnsPrimary)
+	d.Set("dns_secondary", subnet.DnsSecondary)
+	d.Set("search_domains", subnet.SearchDomains)
+	d.Set("dhcp_server", subnet.DhcpServer)
+	d.Set("allow_static_override", subnet.AllowStaticOverride)
+	d.Set("pool_id", subnet.Pool.ID)
+	d.Set("network_domain_id", subnet.NetworkDomain.ID)
+	d.Set("active", subnet.Active)
+	d.Set("visibility", subnet.Visibility)
+	d.Set("external_id", subnet.ExternalId)
+	d.Set("all_group_access", subnet.ResourcePermission.All)
+	// Group Access
+	var groupAccess []map[string]interface{}
+	for _, group := range subnet.ResourcePermission.Sites {
+		groupAccess = append(groupAccess, map[string]interface{}{
+			"group_id": group.ID,
+			"default":  group.Default,
+		})
+	}
+	d.Set("group_access", groupAccess)
+	// Tenant Access
+	var tenantIds []int64
+	for _, tenant := range subnet.Tenants {
+		tenantIds = append(tenantIds, tenant.ID)
+	}
+	d.Set("tenant_ids", tenantIds)
+
+	return diags
+}
+
+func resourceNetworkSubnetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/subnets/%s", id),
+		Body: map[string]interface{}{
+			"subnet": networkSubnetPayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceNetworkSubnetRead(ctx, d, meta)
+}
+
+func resourceNetworkSubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	// An adopted subnet belongs to the cloud, it is only removed from the
+	// state
+	if d.Get("adopt_existing").(bool) {
+		log.Printf("[INFO] leaving the adopted network subnet %s in Morpheus", id)
+		d.SetId("")
+		return diags
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/subnets/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// findExistingNetworkSubnet finds the subnet to adopt, which is the subnet
+// with the name in the network
+func findExistingNetworkSubnet(client *morpheus.Client, d *schema.ResourceData) (*MorpheusNetworkSubnet, error) {
+	name := d.Get("name").(string)
+	networkId := int64(d.Get("network_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/networks/%d/subnets", networkId),
+		QueryParams: map[string]string{
+			"name": name,
+		},
+		Result: &ListMorpheusNetworkSubnetsResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return nil, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var matches []MorpheusNetworkSubnet
+	if subnets := resp.Result.(*ListMorpheusNetworkSubnetsResult).Subnets; subnets != nil {
+		for _, subnet := range *subnets {
+			if subnet.Name == name {
+				matches = append(matches, subnet)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no subnet named %s was found in network %d to adopt", name, networkId)
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("found %d subnets named %s in network %d, import the subnet by its ID", len(matches), name, networkId)
+	}
+	return &matches[0], nil
+}
+
+// networkSubnetPayload builds the settings of a network subnet
+func networkSubnetPayload(d *schema.ResourceData) map[string]interface{} {
+	tenantsPayload := make([]int, 0)
+	if attr, ok := d.GetOk("tenant_ids"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			tenantsPayload = append(tenantsPayload, s.(int))
+		}
+	}
+
+	subnet := map[string]interface{}{
+		"name":                d.Get("name").(string),
+		"description":         d.Get("description").(string),
+		"cidr":                d.Get("cidr").(string),
+		"gateway":             d.Get("gateway").(string),
+		"dnsPrimary":          d.Get("dns_primary").(string),
+		"dnsSecondary":        d.Get("dns_secondary").(string),
+		"searchDomains":       d.Get("search_domains").(string),
+		"dhcpServer":          d.Get("dhcp_server").(bool),
+		"allowStaticOverride": d.Get("allow_static_override").(bool),
+		"active":              d.Get("active").(bool),
+		"visibility":          d.Get("visibility").(string),
+		"resourcePermissions": map[string]interface{}{
+			"all":   d.Get("all_group_access").(bool),
+			"sites": parseGroupAccess(d.Get("group_access").([]interface{})),
+		},
+		"tenantPermissions": map[string]interface{}{
+			"accounts": tenantsPayload,
+		},
+	}
+
+	// A null pool or network domain removes it from the subnet
+	subnet["pool"] = nil
+	if poolId := d.Get("pool_id").(int); poolId != 0 {
+		subnet["pool"] = map[string]interface{}{
+			"id": poolId,
+		}
+	}
+	subnet["networkDomain"] = nil
+	if domainId := d.Get("network_domain_id").(int); domainId != 0 {
+		subnet["networkDomain"] = map[string]interface{}{
+			"id": domainId,
+		}
+	}
+
+	return subnet
+}
+
+type MorpheusNetworkSubnet struct {
+	ID                  int64  `json:"id"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	ExternalId          string `json:"externalId"`
+	Cidr                string `json:"cidr"`
+	Gateway             string `json:"gateway"`
+	DnsPrimary          string `json:"dnsPrimary"`
+	DnsSecondary        string `json:"dnsSecondary"`
+	SearchDomains       string `json:"searchDomains"`
+	DhcpServer          bool   `json:"dhcpServer"`
+	AllowStaticOverride bool   `json:"allowStaticOverride"`
+	Active              bool   `json:"active"`
+	Visibility          string `json:"visibility"`
+	Network             struct {
+		ID int64 `json:"id"`
+	} `json:"network"`
+	Pool struct {
+		ID int64 `json:"id"`
+	} `json:"pool"`
+	NetworkDomain struct {
+		ID int64 `json:"id"`
+	} `json:"networkDomain"`
+	ResourcePermission struct {
+		All   bool `json:"all"`
+		Sites []struct {
+			ID      int64  `json:"id"`
+			Name    string `json:"name"`
+			Default bool   `json:"default"`
+		} `json:"sites"`
+	} `json:"resourcePermission"`
+	Tenants []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"tenants"`
+}
+
+type GetMorpheusNetworkSubnetResult struct {
+	Subnet *MorpheusNetworkSubnet `json:"subnet"`
+}
+
+type ListMorpheusNetworkSubnetsResult struct {
+	Subnets *[]MorpheusNetworkSubnet `json:"subnets"`
+	Meta    *morpheus.MetaResult     `json:"meta"`
+}
@@ -0,0 +1,240 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// handleFakeNetworkSubnets emulates the subnets of a network, which are
+// created and listed under the network and then read, updated and deleted
+// under /api/subnets
+func handleFakeNetworkSubnets(api *fakeMorpheus, networkId int64) {
+	path := "/api/networks/" + int64ToString(networkId) + "/subnets"
+	api.Handle(http.MethodPost, path, func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		subnet := body["subnet"].(map[string]interface{})
+		subnet["network"] = map[string]interface{}{"id": networkId}
+		id := s.Seed("/api/subnets", subnet)
+		object, _ := s.Get("/api/subnets", id)
+		return http.StatusOK, map[string]interface{}{"success": true, "subnet": object}
+	})
+	api.Handle(http.MethodGet, path, func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		subnets := []interface{}{}
+		for _, subnet := range s.Objects("/api/subnets") {
+			if subnet["network"].(map[string]interface{})["id"] == float64(networkId) {
+				subnets = append(subnets, subnet)
+			}
+		}
+		return http.StatusOK, map[string]interface{}{"subnets": subnets}
+	})
+}
+
+func TestResourceNetworkSubnetCreate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	handleFakeNetworkSubnets(api, 5)
+	r := resourceNetworkSubnet()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"network_id":  5,
+		"name":        "tf-example-subnet",
+		"cidr":        "10.100.1.0/24",
+		"gateway":     "10.100.1.1",
+		"dhcp_server": true,
+		"pool_id":     7,
+	})
+
+	if diags := resourceNetworkSubnetCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests(http.MethodPost, "/api/networks/5/subnets")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(requests))
+	}
+	subnet := requests[0]["subnet"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"name":          "tf-example-subnet",
+		"cidr":          "10.100.1.0/24",
+		"gateway":       "10.100.1.1",
+		"dhcpServer":    true,
+		"pool":          map[string]interface{}{"id": float64(7)},
+		"networkDomain": nil,
+	}
+	for k, v := range expected {
+		if !reflect.DeepEqual(subnet[k], v) {
+			t.Errorf("expected %s to be %v, got %v", k, v, subnet[k])
+		}
+	}
+
+	if d.Id() == "" {
+		t.Fatal("expected the id of the created subnet to be set")
+	}
+	if requests := api.Requests(http.MethodGet, "/api/subnets/"+d.Id()); len(requests) != 1 {
+		t.Errorf("expected the subnet to be read back, got %d requests", len(requests))
+	}
+	for k, v := range map[string]interface{}{"network_id": 5, "cidr": "10.100.1.0/24", "pool_id": 7} {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be read back as %v, got %v", k, v, value)
+		}
+	}
+
+	// A subnet created by the resource is deleted with it
+	if diags := resourceNetworkSubnetDelete(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if count := api.Count("/api/subnets"); count != 0 {
+		t.Errorf("expected the subnet to be deleted, %d subnets remain", count)
+	}
+}
+
+func TestResourceNetworkSubnetImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	subnetId := api.Seed("/api/subnets", map[string]interface{}{
+		"name":       "subnet-a",
+		"network":    map[string]interface{}{"id": 5},
+		"cidr":       "10.100.2.0/24",
+		"externalId": "subnet-0a1b2c",
+		"active":     true,
+		"visibility": "private",
+	})
+
+	r := resourceNetworkSubnet()
+	d := r.Data(nil)
+	d.SetId(int64ToString(subnetId))
+	imported, err := r.Importer.StateContext(context.Background(), d, api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d = imported[0]
+	if diags := resourceNetworkSubnetRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := map[string]interface{}{
+		"network_id":  5,
+		"name":        "subnet-a",
+		"cidr":        "10.100.2.0/24",
+		"external_id": "subnet-0a1b2c",
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+}
+
+func TestResourceNetworkSubnetUpdate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	subnetId := api.Seed("/api/subnets", map[string]interface{}{
+		"name":       "subnet-a",
+		"network":    map[string]interface{}{"id": 5},
+		"cidr":       "10.100.2.0/24",
+		"active":     true,
+		"visibility": "private",
+	})
+
+	r := resourceNetworkSubnet()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"network_id":        5,
+		"name":              "subnet-a",
+		"cidr":              "10.100.2.0/24",
+		"dns_primary":       "10.100.2.2",
+		"network_domain_id": 3,
+		"visibility":        "public",
+	})
+	d.SetId(int64ToString(subnetId))
+	if diags := resourceNetworkSubnetUpdate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests(http.MethodPut, "/api/subnets/"+int64ToString(subnetId))
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 update request, got %d", len(requests))
+	}
+	subnet := requests[0]["subnet"].(map[string]interface{})
+	if !reflect.DeepEqual(subnet["networkDomain"], map[string]interface{}{"id": float64(3)}) {
+		t.Errorf("expected the network domain 3 to be sent, got %v", subnet["networkDomain"])
+	}
+	expected := map[string]interface{}{
+		"dns_primary":       "10.100.2.2",
+		"network_domain_id": 3,
+		"visibility":        "public",
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+}
+
+func TestResourceNetworkSubnetCreate_adoptExisting(t *testing.T) {
+	api := newFakeMorpheus(t)
+	handleFakeNetworkSubnets(api, 5)
+	subnet := func(name string, networkId int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":       name,
+			"network":    map[string]interface{}{"id": networkId},
+			"cidr":       "10.100.3.0/24",
+			"active":     true,
+			"visibility": "private",
+		}
+	}
+	api.Seed("/api/subnets", subnet("subnet-b", 6))
+	api.Seed("/api/subnets", subnet("subnet-a", 5))
+	subnetId := api.Seed("/api/subnets", subnet("subnet-b", 5))
+
+	r := resourceNetworkSubnet()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"network_id":     5,
+		"name":           "subnet-b",
+		"cidr":           "10.100.3.0/24",
+		"gateway":        "10.100.3.1",
+		"adopt_existing": true,
+	})
+	if diags := resourceNetworkSubnetCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != int64ToString(subnetId) {
+		t.Errorf("expected the subnet %d to be adopted, got %s", subnetId, d.Id())
+	}
+	if requests := api.Requests(http.MethodPost, "/api/networks/5/subnets"); len(requests) != 0 {
+		t.Errorf("expected no subnet to be created, got %d create requests", len(requests))
+	}
+	if queries := api.Queries(http.MethodGet, "/api/networks/5/subnets"); len(queries) != 1 || queries[0].Get("name") != "subnet-b" {
+		t.Errorf("expected the subnets of the network to be listed by name, got %v", queries)
+	}
+	if requests := api.Requests(http.MethodPut, "/api/subnets/"+int64ToString(subnetId)); len(requests) != 1 {
+		t.Errorf("expected the adopted subnet to be updated, got %d update requests", len(requests))
+	}
+	if gateway := d.Get("gateway"); gateway != "10.100.3.1" {
+		t.Errorf("expected the gateway to be read back, got %v", gateway)
+	}
+
+	// The adopted subnet belongs to the cloud and is left in Morpheus
+	if diags := resourceNetworkSubnetDelete(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, ok := api.Get("/api/subnets", subnetId); !ok {
+		t.Errorf("expected the adopted subnet to remain")
+	}
+
+	// A subnet that is not found is not created
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"network_id":     5,
+		"name":           "subnet-c",
+		"cidr":           "10.100.4.0/24",
+		"adopt_existing": true,
+	})
+	diags := resourceNetworkSubnetCreate(context.Background(), d, api.Meta())
+	if !diags.HasError() {
+		t.Fatal("expected an error for a subnet that does not exist")
+	}
+	if expected := "no subnet named subnet-c was found in network 5 to adopt"; diags[0].Summary != expected {
+		t.Errorf("expected the error %q, got %q", expected, diags[0].Summary)
+	}
+	if count := api.Count("/api/subnets"); count != 3 {
+		t.Errorf("expected no subnet to be created, got %d subnets", count)
+	}
+}
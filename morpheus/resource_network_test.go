@@ -0,0 +1,289 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceNetworkCreate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	r := resourceNetwork()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":             "tf-example-aws-subnet",
+		"cloud_id":         3,
+		"type_id":          12,
+		"resource_pool_id": 25,
+		"cidr":             "172.31.100.0/24",
+		"vlan_id":          100,
+		"pool_id":          7,
+		"config":           `{"availabilityZone": "us-east-1a"}`,
+	})
+
+	if diags := resourceNetworkCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests(http.MethodPost, "/api/networks")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(requests))
+	}
+	network := requests[0]["network"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"name":          "tf-example-aws-subnet",
+		"zone":          map[string]interface{}{"id": float64(3)},
+		"type":          map[string]interface{}{"id": float64(12)},
+		"zonePool":      map[string]interface{}{"id": float64(25)},
+		"cidr":          "172.31.100.0/24",
+		"vlanId":        float64(100),
+		"pool":          map[string]interface{}{"id": float64(7)},
+		"networkDomain": nil,
+		"config":        map[string]interface{}{"availabilityZone": "us-east-1a"},
+		"visibility":    "private",
+	}
+	for k, v := range expected {
+		if !reflect.DeepEqual(network[k], v) {
+			t.Errorf("expected %s to be %v, got %v", k, v, network[k])
+		}
+	}
+
+	if d.Id() == "" {
+		t.Fatal("expected the id of the created network to be set")
+	}
+	for k, v := range map[string]interface{}{"cloud_id": 3, "type_id": 12, "resource_pool_id": 25, "pool_id": 7} {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be read back as %v, got %v", k, v, value)
+		}
+	}
+
+	// A network created by the resource is deleted with it
+	if diags := resourceNetworkDelete(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if count := api.Count("/api/networks"); count != 0 {
+		t.Errorf("expected the network to be deleted, %d networks remain", count)
+	}
+}
+
+func TestResourceNetworkImport(t *testing.T) {
+	api := newFakeMorpheus(t)
+	networkId := api.Seed("/api/networks", map[string]interface{}{
+		"name":       "VM Network",
+		"zone":       map[string]interface{}{"id": 4},
+		"type":       map[string]interface{}{"id": 2, "code": "vmwarePortGroup"},
+		"cidr":       "10.0.0.0/24",
+		"externalId": "network-12",
+		"active":     true,
+		"visibility": "private",
+	})
+
+	r := resourceNetwork()
+	d := r.Data(nil)
+	d.SetId(int64ToString(networkId))
+	imported, err := r.Importer.StateContext(context.Background(), d, api.Meta())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d = imported[0]
+	if diags := resourceNetworkRead(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	expected := map[string]interface{}{
+		"name":        "VM Network",
+		"cloud_id":    4,
+		"type_id":     2,
+		"cidr":        "10.0.0.0/24",
+		"external_id": "network-12",
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+}
+
+func TestResourceNetworkUpdate(t *testing.T) {
+	api := newFakeMorpheus(t)
+	networkId := api.Seed("/api/networks", map[string]interface{}{
+		"name":       "VM Network",
+		"zone":       map[string]interface{}{"id": 4},
+		"type":       map[string]interface{}{"id": 2},
+		"cidr":       "10.0.0.0/24",
+		"pool":       map[string]interface{}{"id": 7},
+		"active":     true,
+		"visibility": "private",
+	})
+
+	r := resourceNetwork()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":              "VM Network",
+		"cloud_id":          4,
+		"type_id":           2,
+		"cidr":              "10.0.0.0/24",
+		"gateway":           "10.0.0.1",
+		"dns_primary":       "10.0.0.2",
+		"network_domain_id": 3,
+	})
+	d.SetId(int64ToString(networkId))
+	if diags := resourceNetworkUpdate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requests := api.Requests(http.MethodPut, "/api/networks/"+int64ToString(networkId))
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 update request, got %d", len(requests))
+	}
+	network := requests[0]["network"].(map[string]interface{})
+	// The cloud and network type cannot be changed, the pool removed from
+	// the configuration is removed from the network
+	for _, k := range []string{"zone", "type", "zonePool"} {
+		if _, ok := network[k]; ok {
+			t.Errorf("expected %s not to be sent on update", k)
+		}
+	}
+	if pool, ok := network["pool"]; !ok || pool != nil {
+		t.Errorf("expected a null pool to be sent, got %v", pool)
+	}
+	expected := map[string]interface{}{
+		"gateway":           "10.0.0.1",
+		"dns_primary":       "10.0.0.2",
+		"network_domain_id": 3,
+		"pool_id":           0,
+	}
+	for k, v := range expected {
+		if value := d.Get(k); value != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, value)
+		}
+	}
+}
+
+// testDiscoveredNetworks seeds the networks discovered by the cloud sync, two
+// of them have the same name in different VPCs of the same cloud
+func testDiscoveredNetworks(api *fakeMorpheus) map[string]int64 {
+	network := func(name string, cloudId int, resourcePoolId int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":       name,
+			"zone":       map[string]interface{}{"id": cloudId},
+			"type":       map[string]interface{}{"id": 2},
+			"zonePool":   map[string]interface{}{"id": resourcePoolId},
+			"active":     true,
+			"visibility": "private",
+		}
+	}
+	return map[string]int64{
+		"vm network":         api.Seed("/api/networks", network("VM Network", 4, 0)),
+		"vm network cloud 5": api.Seed("/api/networks", network("VM Network", 5, 0)),
+		"app vpc 1":          api.Seed("/api/networks", network("app", 4, 21)),
+		"app vpc 2":          api.Seed("/api/networks", network("app", 4, 22)),
+	}
+}
+
+func TestResourceNetworkCreate_adoptExisting(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  map[string]interface{}
+		adopted string
+	}{
+		{"by name and cloud", map[string]interface{}{"name": "VM Network", "cloud_id": 4}, "vm network"},
+		{"in another cloud", map[string]interface{}{"name": "VM Network", "cloud_id": 5}, "vm network cloud 5"},
+		{"by resource pool", map[string]interface{}{"name": "app", "cloud_id": 4, "resource_pool_id": 22}, "app vpc 2"},
+	}
+	for _, c := range cases {
+		api := newFakeMorpheus(t)
+		networks := testDiscoveredNetworks(api)
+		r := resourceNetwork()
+		config := map[string]interface{}{
+			"type_id":        2,
+			"adopt_existing": true,
+			"dns_primary":    "10.0.0.2",
+		}
+		for k, v := range c.config {
+			config[k] = v
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+		if diags := resourceNetworkCreate(context.Background(), d, api.Meta()); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", c.name, diags)
+		}
+		networkId := networks[c.adopted]
+		if d.Id() != int64ToString(networkId) {
+			t.Errorf("%s: expected the network %d to be adopted, got %s", c.name, networkId, d.Id())
+		}
+		if requests := api.Requests(http.MethodPost, "/api/networks"); len(requests) != 0 {
+			t.Errorf("%s: expected no network to be created, got %d create requests", c.name, len(requests))
+		}
+		requests := api.Requests(http.MethodPut, "/api/networks/"+int64ToString(networkId))
+		if len(requests) != 1 {
+			t.Fatalf("%s: expected the adopted network to be updated, got %d update requests", c.name, len(requests))
+		}
+		if dns := requests[0]["network"].(map[string]interface{})["dnsPrimary"]; dns != "10.0.0.2" {
+			t.Errorf("%s: expected the configuration to be applied, got the dnsPrimary %v", c.name, dns)
+		}
+		if dns := d.Get("dns_primary"); dns != "10.0.0.2" {
+			t.Errorf("%s: expected the dns_primary to be read back, got %v", c.name, dns)
+		}
+
+		// The adopted network belongs to the cloud and is left in Morpheus
+		if diags := resourceNetworkDelete(context.Background(), d, api.Meta()); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", c.name, diags)
+		}
+		if requests := api.Requests(http.MethodDelete, "/api/networks/"+int64ToString(networkId)); len(requests) != 0 {
+			t.Errorf("%s: expected the adopted network not to be deleted, got %d delete requests", c.name, len(requests))
+		}
+		if _, ok := api.Get("/api/networks", networkId); !ok {
+			t.Errorf("%s: expected the adopted network to remain", c.name)
+		}
+		if d.Id() != "" {
+			t.Errorf("%s: expected the network to be removed from the state", c.name)
+		}
+	}
+}
+
+func TestResourceNetworkCreate_adoptExistingErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{"not found", map[string]interface{}{"name": "missing", "cloud_id": 4, "type_id": 2}, "no network named missing was found in cloud 4 to adopt"},
+		{"ambiguous", map[string]interface{}{"name": "app", "cloud_id": 4, "type_id": 2}, "found 2 networks named app in cloud 4"},
+		{"network type", map[string]interface{}{"name": "VM Network", "cloud_id": 4, "type_id": 9}, "has the network type 2, not 9"},
+	}
+	for _, c := range cases {
+		api := newFakeMorpheus(t)
+		testDiscoveredNetworks(api)
+		c.config["adopt_existing"] = true
+		d := schema.TestResourceDataRaw(t, resourceNetwork().Schema, c.config)
+
+		diags := resourceNetworkCreate(context.Background(), d, api.Meta())
+		if !diags.HasError() {
+			t.Errorf("%s: expected an error", c.name)
+			continue
+		}
+		if !strings.Contains(diags[0].Summary, c.err) {
+			t.Errorf("%s: expected the error to contain %q, got %q", c.name, c.err, diags[0].Summary)
+		}
+		if requests := api.Requests(http.MethodPost, "/api/networks"); len(requests) != 0 {
+			t.Errorf("%s: expected no network to be created, got %d create requests", c.name, len(requests))
+		}
+		if d.Id() != "" {
+			t.Errorf("%s: expected no id to be set, got %s", c.name, d.Id())
+		}
+	}
+
+	// The lookup is filtered by the name and cloud
+	api := newFakeMorpheus(t)
+	testDiscoveredNetworks(api)
+	d := schema.TestResourceDataRaw(t, resourceNetwork().Schema, map[string]interface{}{
+		"name": "VM Network", "cloud_id": 4, "type_id": 2, "adopt_existing": true,
+	})
+	resourceNetworkCreate(context.Background(), d, api.Meta())
+	queries := api.Queries(http.MethodGet, "/api/networks")
+	if len(queries) != 1 || queries[0].Get("name") != "VM Network" || queries[0].Get("zoneId") != "4" {
+		t.Errorf("expected the networks to be listed by name and cloud, got %v", queries)
+	}
+}
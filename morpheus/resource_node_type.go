@@ -201,7 +201,7 @@ func resourceNodeType() *schema.Resource {
 }
 
 func resourceNodeTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -272,7 +272,7 @@ func resourceNodeTypeCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceNodeTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -360,7 +360,7 @@ func resourceNodeTypeRead(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceNodeTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	name := d.Get("name").(string)
@@ -417,7 +417,7 @@ func resourceNodeTypeUpdate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceNodeTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -80,7 +80,7 @@ func resourceOperationalWorkflow() *schema.Resource {
 }
 
 func resourceOperationalWorkflowCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -140,7 +140,7 @@ func resourceOperationalWorkflowCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceOperationalWorkflowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -203,7 +203,7 @@ func resourceOperationalWorkflowRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceOperationalWorkflowUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -258,7 +258,7 @@ func resourceOperationalWorkflowUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceOperationalWorkflowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
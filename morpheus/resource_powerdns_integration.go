@@ -0,0 +1,256 @@
+package morpheus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePowerDnsIntegration() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a PowerDNS integration resource",
+		CreateContext: resourcePowerDnsIntegrationCreate,
+		ReadContext:   resourcePowerDnsIntegrationRead,
+		UpdateContext: resourcePowerDnsIntegrationUpdate,
+		DeleteContext: resourcePowerDnsIntegrationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The id of the PowerDNS integration",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the PowerDNS integration",
+				Required:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the PowerDNS integration is enabled",
+				Optional:    true,
+				Default:     true,
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Description: "The url of the PowerDNS API (e.g. https://powerdns.example.local:8081)",
+				Required:    true,
+			},
+			"credential_id": {
+				Description:   "The id of the credential store entry used for authentication",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"api_key"},
+			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Description: "The API key used to connect to PowerDNS",
+				Optional:    true,
+				Sensitive:   true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					h := sha256.New()
+					h.Write([]byte(new))
+					sha256_hash := hex.EncodeToString(h.Sum(nil))
+					return strings.EqualFold(old, sha256_hash)
+				},
+				DiffSuppressOnRefresh: true,
+			},
+			"zone_filter": {
+				Type:        schema.TypeString,
+				Description: "A filter that limits the DNS zones discovered by the integration (e.g. example.local)",
+				Optional:    true,
+			},
+			"create_pointer": {
+				Type:        schema.TypeBool,
+				Description: "Whether a PTR record is created with each A record",
+				Optional:    true,
+				Default:     true,
+			},
+			"zones": {
+				Type:        schema.TypeList,
+				Description: "The DNS zones discovered by the PowerDNS integration",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the network domain of the DNS zone",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the DNS zone",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourcePowerDnsIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integration := make(map[string]interface{})
+
+	integration["type"] = "powerDns"
+	integration["name"] = d.Get("name").(string)
+	integration["enabled"] = d.Get("enabled").(bool)
+	integration["serviceUrl"] = d.Get("url").(string)
+	integration["zoneFilter"] = d.Get("zone_filter").(string)
+
+	if d.Get("credential_id").(int) != 0 {
+		credential := make(map[string]interface{})
+		credential["type"] = "api-key"
+		credential["id"] = d.Get("credential_id").(int)
+		integration["credential"] = credential
+	} else {
+		integration["serviceToken"] = d.Get("api_key").(string)
+	}
+
+	config := make(map[string]interface{})
+	config["createPointer"] = d.Get("create_pointer").(bool)
+	integration["config"] = config
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"integration": integration,
+		},
+	}
+
+	resp, err := client.CreateIntegration(req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*morpheus.CreateIntegrationResult)
+	integrationResult := result.Integration
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(integrationResult.ID))
+
+	resourcePowerDnsIntegrationRead(ctx, d, meta)
+	return diags
+}
+
+func resourcePowerDnsIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integration, resp, err := getNetworkIntegration(client, d.Id())
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+
+	// store resource data
+	d.SetId(int64ToString(integration.ID))
+	d.Set("name", integration.Name)
+	d.Set("enabled", integration.Enabled)
+	d.Set("url", integration.ServiceUrl)
+	if integration.Credential.ID == 0 {
+		d.Set("api_key", integration.ServiceTokenHash)
+	} else {
+		d.Set("credential_id", integration.Credential.ID)
+	}
+	d.Set("zone_filter", integration.ZoneFilter)
+	d.Set("create_pointer", integration.Config.CreatePointer)
+
+	zones, err := networkIntegrationZones(client, integration.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("zones", zones)
+
+	return diags
+}
+
+func resourcePowerDnsIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	integration := make(map[string]interface{})
+
+	integration["type"] = "powerDns"
+	integration["name"] = d.Get("name").(string)
+	integration["enabled"] = d.Get("enabled").(bool)
+	integration["serviceUrl"] = d.Get("url").(string)
+	integration["zoneFilter"] = d.Get("zone_filter").(string)
+
+	if d.Get("credential_id").(int) != 0 {
+		credential := make(map[string]interface{})
+		credential["type"] = "api-key"
+		credential["id"] = d.Get("credential_id").(int)
+		integration["credential"] = credential
+	} else {
+		if d.HasChange("api_key") {
+			integration["serviceToken"] = d.Get("api_key").(string)
+		}
+	}
+
+	config := make(map[string]interface{})
+	config["createPointer"] = d.Get("create_pointer").(bool)
+	integration["config"] = config
+
+	req := &morpheus.Request{
+		Body: map[string]interface{}{
+			"integration": integration,
+		},
+	}
+
+	resp, err := client.UpdateIntegration(toInt64(id), req)
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourcePowerDnsIntegrationRead(ctx, d, meta)
+}
+
+func resourcePowerDnsIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	req := &morpheus.Request{}
+	resp, err := client.DeleteIntegration(toInt64(id), req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
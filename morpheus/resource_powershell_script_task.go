@@ -167,7 +167,7 @@ func resourcePowerShellScriptTask() *schema.Resource {
 }
 
 func resourcePowerShellScriptTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -252,7 +252,7 @@ func resourcePowerShellScriptTaskCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourcePowerShellScriptTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -316,7 +316,7 @@ func resourcePowerShellScriptTaskRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourcePowerShellScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -395,7 +395,7 @@ func resourcePowerShellScriptTaskUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourcePowerShellScriptTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -48,7 +48,7 @@ func resourcePreseedScript() *schema.Resource {
 }
 
 func resourcePreseedScriptCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -79,7 +79,7 @@ func resourcePreseedScriptCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourcePreseedScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -122,7 +122,7 @@ func resourcePreseedScriptRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourcePreseedScriptUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	req := &morpheus.Request{
@@ -149,7 +149,7 @@ func resourcePreseedScriptUpdate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourcePreseedScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
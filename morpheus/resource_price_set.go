@@ -83,7 +83,7 @@ func resourcePriceSet() *schema.Resource {
 }
 
 func resourcePriceSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -135,7 +135,7 @@ func resourcePriceSetCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourcePriceSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -205,7 +205,7 @@ func resourcePriceSetRead(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourcePriceSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	priceSet := make(map[string]interface{})
@@ -258,7 +258,7 @@ func resourcePriceSetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourcePriceSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -114,7 +114,7 @@ func resourceProvisionApprovalPolicy() *schema.Resource {
 }
 
 func resourceProvisionApprovalPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -188,7 +188,7 @@ func resourceProvisionApprovalPolicyCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceProvisionApprovalPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -269,7 +269,7 @@ func resourceProvisionApprovalPolicyRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceProvisionApprovalPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -340,7 +340,7 @@ func resourceProvisionApprovalPolicyUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceProvisionApprovalPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
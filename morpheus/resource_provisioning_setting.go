@@ -138,7 +138,7 @@ func resourceProvisioningSetting() *schema.Resource {
 }
 
 func resourceProvisioningSettingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -186,7 +186,7 @@ func resourceProvisioningSettingCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceProvisioningSettingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -232,7 +232,7 @@ func resourceProvisioningSettingRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceProvisioningSettingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	req := &morpheus.Request{
 		Body: map[string]interface{}{
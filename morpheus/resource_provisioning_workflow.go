@@ -86,7 +86,7 @@ func resourceProvisioningWorkflow() *schema.Resource {
 }
 
 func resourceProvisioningWorkflowCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -145,7 +145,7 @@ func resourceProvisioningWorkflowCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceProvisioningWorkflowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -220,7 +220,7 @@ func resourceProvisioningWorkflowRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceProvisioningWorkflowUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -272,7 +272,7 @@ func resourceProvisioningWorkflowUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceProvisioningWorkflowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
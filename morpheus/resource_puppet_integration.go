@@ -77,7 +77,7 @@ func resourcePuppetIntegration() *schema.Resource {
 }
 
 func resourcePuppetIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -123,7 +123,7 @@ func resourcePuppetIntegrationCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourcePuppetIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -173,7 +173,7 @@ func resourcePuppetIntegrationRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourcePuppetIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -216,7 +216,7 @@ func resourcePuppetIntegrationUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourcePuppetIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
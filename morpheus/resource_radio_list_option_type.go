@@ -128,7 +128,7 @@ func resourceRadioListOptionType() *schema.Resource {
 }
 
 func resourceRadioListOptionTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -185,7 +185,7 @@ func resourceRadioListOptionTypeCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceRadioListOptionTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -247,7 +247,7 @@ func resourceRadioListOptionTypeRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceRadioListOptionTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -297,7 +297,7 @@ func resourceRadioListOptionTypeUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceRadioListOptionTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
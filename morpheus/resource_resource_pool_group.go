@@ -93,7 +93,7 @@ func resourceResourcePoolGroup() *schema.Resource {
 }
 
 func resourceResourcePoolGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -153,7 +153,7 @@ func resourceResourcePoolGroupCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceResourcePoolGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -222,7 +222,7 @@ func resourceResourcePoolGroupRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceResourcePoolGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	resourcePermissions := make(map[string]interface{})
@@ -277,7 +277,7 @@ func resourceResourcePoolGroupUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceResourcePoolGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -129,7 +129,7 @@ func resourceRestOptionList() *schema.Resource {
 }
 
 func resourceRestOptionListCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	headers := d.Get("source_headers").([]interface{})
 	var sourceHeaders []map[string]interface{}
@@ -199,7 +199,7 @@ func resourceRestOptionListCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceRestOptionListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -254,7 +254,7 @@ func resourceRestOptionListRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceRestOptionListUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -320,7 +320,7 @@ func resourceRestOptionListUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceRestOptionListDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -103,7 +103,7 @@ func resourceRouterQuotaPolicy() *schema.Resource {
 }
 
 func resourceRouterQuotaPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -172,7 +172,7 @@ func resourceRouterQuotaPolicyCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceRouterQuotaPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -244,7 +244,7 @@ func resourceRouterQuotaPolicyRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceRouterQuotaPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -310,7 +310,7 @@ func resourceRouterQuotaPolicyUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceRouterQuotaPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -111,7 +111,7 @@ func resourceScaleThreshold() *schema.Resource {
 }
 
 func resourceScaleThresholdCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -154,7 +154,7 @@ func resourceScaleThresholdCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceScaleThresholdRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -211,7 +211,7 @@ func resourceScaleThresholdRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceScaleThresholdUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	req := &morpheus.Request{
@@ -250,7 +250,7 @@ func resourceScaleThresholdUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceScaleThresholdDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
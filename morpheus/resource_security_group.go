@@ -0,0 +1,274 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSecurityGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus security group resource",
+		CreateContext: resourceSecurityGroupCreate,
+		ReadContext:   resourceSecurityGroupRead,
+		UpdateContext: resourceSecurityGroupUpdate,
+		DeleteContext: resourceSecurityGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the security group",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the security group",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the security group",
+				Optional:    true,
+			},
+			"cloud_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the cloud the security group is scoped to, the security group is available to every cloud when not set",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"resource_pool_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the resource pool the security group is created in (e.g. the AWS VPC)",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"config": {
+				Type:             schema.TypeString,
+				Description:      "Additional cloud specific settings (JSON) used to create the security group",
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEquivalentJsonDiffs,
+				ValidateFunc:     validation.StringIsJSON,
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Description:  "Whether the security group is visible in sub-tenants or not",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public"}, false),
+				Default:      "private",
+			},
+			"tenant_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of tenant ids granted access to the security group",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Description: "The external id of the security group in the cloud",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	securityGroup := securityGroupPayload(d)
+	if cloudId := d.Get("cloud_id").(int); cloudId != 0 {
+		securityGroup["zoneId"] = cloudId
+	}
+	if resourcePoolId := d.Get("resource_pool_id").(int); resourcePoolId != 0 {
+		securityGroup["zonePool"] = map[string]interface{}{
+			"id": resourcePoolId,
+		}
+	}
+	if d.Get("config").(string) != "" {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(d.Get("config").(string)), &config); err != nil {
+			return diag.Errorf("unable to parse the security group config: %s", err)
+		}
+		securityGroup["customOptions"] = config
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/security-groups",
+		Body: map[string]interface{}{
+			"securityGroup": securityGroup,
+		},
+		Result: &GetMorpheusSecurityGroupResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusSecurityGroupResult)
+	if result.SecurityGroup == nil {
+		return diag.Errorf("create operation: security group not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.SecurityGroup.ID))
+
+	resourceSecurityGroupRead(ctx, d, meta)
+	return diags
+}
+
+func resourceSecurityGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/security-groups/%s", id),
+		Result: &GetMorpheusSecurityGroupResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusSecurityGroupResult)
+	securityGroup := result.SecurityGroup
+	if securityGroup == nil {
+		return diag.Errorf("read operation: security group not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(securityGroup.ID))
+	d.Set("name", securityGroup.Name)
+	d.Set("description", securityGroup.Description)
+	d.Set("visibility", securityGroup.Visibility)
+	d.Set("external_id", securityGroup.ExternalId)
+	if securityGroup.Zone.ID != 0 {
+		d.Set("cloud_id", securityGroup.Zone.ID)
+	}
+	if securityGroup.ZonePool.ID != 0 {
+		d.Set("resource_pool_id", securityGroup.ZonePool.ID)
+	}
+	// Tenant Access
+	var tenantIds []int64
+	for _, tenant := range securityGroup.Tenants {
+		tenantIds = append(tenantIds, tenant.ID)
+	}
+	d.Set("tenant_ids", tenantIds)
+
+	return diags
+}
+
+func resourceSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/security-groups/%s", id),
+		Body: map[string]interface{}{
+			"securityGroup": securityGroupPayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceSecurityGroupRead(ctx, d, meta)
+}
+
+func resourceSecurityGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/security-groups/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// securityGroupPayload builds the settings of a security group that can be
+// changed after it has been created
+func securityGroupPayload(d *schema.ResourceData) map[string]interface{} {
+	tenantsPayload := make([]int, 0)
+	if attr, ok := d.GetOk("tenant_ids"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			tenantsPayload = append(tenantsPayload, s.(int))
+		}
+	}
+
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"visibility":  d.Get("visibility").(string),
+		"tenantPermissions": map[string]interface{}{
+			"accounts": tenantsPayload,
+		},
+	}
+}
+
+type MorpheusSecurityGroup struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ExternalId  string `json:"externalId"`
+	Visibility  string `json:"visibility"`
+	Zone        struct {
+		ID int64 `json:"id"`
+	} `json:"zone"`
+	ZonePool struct {
+		ID int64 `json:"id"`
+	} `json:"zonePool"`
+	Tenants []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"tenants"`
+}
+
+type GetMorpheusSecurityGroupResult struct {
+	SecurityGroup *MorpheusSecurityGroup `json:"securityGroup"`
+}
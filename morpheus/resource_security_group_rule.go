@@ -0,0 +1,380 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSecurityGroupRule() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus security group rule resource",
+		CreateContext: resourceSecurityGroupRuleCreate,
+		ReadContext:   resourceSecurityGroupRuleRead,
+		UpdateContext: resourceSecurityGroupRuleUpdate,
+		DeleteContext: resourceSecurityGroupRuleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the security group rule",
+				Computed:    true,
+			},
+			"security_group_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the security group to create the rule in",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the security group rule",
+				Optional:    true,
+				Computed:    true,
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Description:  "The direction of the traffic the rule applies to (ingress, egress)",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ingress", "egress"}, false),
+				Default:      "ingress",
+			},
+			"rule_type": {
+				Type:        schema.TypeString,
+				Description: "The type of the rule, either custom or a predefined rule type such as ssh, http or https which sets the protocol and port range",
+				Optional:    true,
+				Default:     "custom",
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Description:  "The protocol of the traffic the rule applies to (tcp, udp, icmp, any)",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp", "any"}, false),
+			},
+			"port_range": {
+				Type:        schema.TypeString,
+				Description: "The destination port or port range of the rule (e.g. 22 or 8000-8100)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"source_port_range": {
+				Type:        schema.TypeString,
+				Description: "The source port or port range of the rule (e.g. 1024-65535)",
+				Optional:    true,
+			},
+			"source_type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the source of the traffic (cidr, group, tier, instance, all)",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cidr", "group", "tier", "instance", "all"}, false),
+				Default:      "cidr",
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Description: "The CIDR of the source of the traffic when the source type is cidr",
+				Optional:    true,
+			},
+			"source_group_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the security group that is the source of the traffic when the source type is group",
+				Optional:    true,
+			},
+			"source_tier_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the tier that is the source of the traffic when the source type is tier",
+				Optional:    true,
+			},
+			"destination_type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the destination of the traffic (cidr, group, tier, instance, all)",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cidr", "group", "tier", "instance", "all"}, false),
+				Default:      "instance",
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Description: "The CIDR of the destination of the traffic when the destination type is cidr",
+				Optional:    true,
+			},
+			"destination_group_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the security group that is the destination of the traffic when the destination type is group",
+				Optional:    true,
+			},
+			"destination_tier_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the tier that is the destination of the traffic when the destination type is tier",
+				Optional:    true,
+			},
+			"policy": {
+				Type:         schema.TypeString,
+				Description:  "Whether the traffic is accepted or rejected (accept, reject)",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"accept", "reject"}, false),
+				Default:      "accept",
+			},
+			"priority": {
+				Type:        schema.TypeInt,
+				Description: "The priority of the rule",
+				Optional:    true,
+				Computed:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the rule is enabled",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecurityGroupRuleImport,
+		},
+	}
+}
+
+func resourceSecurityGroupRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	securityGroupId := int64(d.Get("security_group_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   fmt.Sprintf("/api/security-groups/%d/rules", securityGroupId),
+		Body: map[string]interface{}{
+			"rule": securityGroupRulePayload(d),
+		},
+		Result: &GetMorpheusSecurityGroupRuleResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusSecurityGroupRuleResult)
+	if result.Rule == nil {
+		return diag.Errorf("create operation: security group rule not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.Rule.ID))
+
+	resourceSecurityGroupRuleRead(ctx, d, meta)
+	return diags
+}
+
+func resourceSecurityGroupRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	securityGroupId := int64(d.Get("security_group_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/security-groups/%d/rules/%s", securityGroupId, id),
+		Result: &GetMorpheusSecurityGroupRuleResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusSecurityGroupRuleResult)
+	rule := result.Rule
+	if rule == nil {
+		return diag.Errorf("read operation: security group rule not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(rule.ID))
+	d.Set("name", rule.Name)
+	d.Set("direction", rule.Direction)
+	d.Set("rule_type", rule.RuleType)
+	d.Set("protocol", rule.Protocol)
+	d.Set("port_range", rule.PortRange)
+	d.Set("source_port_range", rule.SourcePortRange)
+	d.Set("source_type", rule.SourceType)
+	d.Set("source", rule.Source)
+	d.Set("source_group_id", rule.SourceGroup.ID)
+	d.Set("source_tier_id", rule.SourceTier.ID)
+	d.Set("destination_type", rule.DestinationType)
+	d.Set("destination", rule.Destination)
+	d.Set("destination_group_id", rule.DestinationGroup.ID)
+	d.Set("destination_tier_id", rule.DestinationTier.ID)
+	d.Set("policy", rule.Policy)
+	d.Set("priority", rule.Priority)
+	d.Set("enabled", rule.Enabled)
+
+	return diags
+}
+
+func resourceSecurityGroupRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+	securityGroupId := int64(d.Get("security_group_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/security-groups/%d/rules/%s", securityGroupId, id),
+		Body: map[string]interface{}{
+			"rule": securityGroupRulePayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceSecurityGroupRuleRead(ctx, d, meta)
+}
+
+func resourceSecurityGroupRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	securityGroupId := int64(d.Get("security_group_id").(int))
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/security-groups/%d/rules/%s", securityGroupId, id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// resourceSecurityGroupRuleImport imports a rule using an id in the format
+// security_group_id/rule_id
+func resourceSecurityGroupRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected security_group_id/rule_id", d.Id())
+	}
+	d.Set("security_group_id", toInt64(parts[0]))
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// securityGroupRulePayload builds the settings of a security group rule. The
+// source and destination are only sent for the types that use them.
+func securityGroupRulePayload(d *schema.ResourceData) map[string]interface{} {
+	rule := map[string]interface{}{
+		"direction":       d.Get("direction").(string),
+		"ruleType":        d.Get("rule_type").(string),
+		"sourceType":      d.Get("source_type").(string),
+		"destinationType": d.Get("destination_type").(string),
+		"policy":          d.Get("policy").(string),
+		"enabled":         d.Get("enabled").(bool),
+	}
+	if name := d.Get("name").(string); name != "" {
+		rule["name"] = name
+	}
+	if protocol := d.Get("protocol").(string); protocol != "" {
+		rule["protocol"] = protocol
+	}
+	if portRange := d.Get("port_range").(string); portRange != "" {
+		rule["portRange"] = portRange
+	}
+	if sourcePortRange := d.Get("source_port_range").(string); sourcePortRange != "" {
+		rule["sourcePortRange"] = sourcePortRange
+	}
+	if priority := d.Get("priority").(int); priority != 0 {
+		rule["priority"] = priority
+	}
+
+	switch d.Get("source_type").(string) {
+	case "cidr":
+		rule["source"] = d.Get("source").(string)
+	case "group":
+		rule["sourceGroup"] = map[string]interface{}{
+			"id": d.Get("source_group_id").(int),
+		}
+	case "tier":
+		rule["sourceTier"] = map[string]interface{}{
+			"id": d.Get("source_tier_id").(int),
+		}
+	}
+
+	switch d.Get("destination_type").(string) {
+	case "cidr":
+		rule["destination"] = d.Get("destination").(string)
+	case "group":
+		rule["destinationGroup"] = map[string]interface{}{
+			"id": d.Get("destination_group_id").(int),
+		}
+	case "tier":
+		rule["destinationTier"] = map[string]interface{}{
+			"id": d.Get("destination_tier_id").(int),
+		}
+	}
+
+	return rule
+}
+
+type MorpheusSecurityGroupRule struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	Direction       string `json:"direction"`
+	RuleType        string `json:"ruleType"`
+	Protocol        string `json:"protocol"`
+	PortRange       string `json:"portRange"`
+	SourcePortRange string `json:"sourcePortRange"`
+	SourceType      string `json:"sourceType"`
+	Source          string `json:"source"`
+	SourceGroup     struct {
+		ID int64 `json:"id"`
+	} `json:"sourceGroup"`
+	SourceTier struct {
+		ID int64 `json:"id"`
+	} `json:"sourceTier"`
+	DestinationType  string `json:"destinationType"`
+	Destination      string `json:"destination"`
+	DestinationGroup struct {
+		ID int64 `json:"id"`
+	} `json:"destinationGroup"`
+	DestinationTier struct {
+		ID int64 `json:"id"`
+	} `json:"destinationTier"`
+	Policy   string `json:"policy"`
+	Priority int64  `json:"priority"`
+	Enabled  bool   `json:"enabled"`
+}
+
+type GetMorpheusSecurityGroupRuleResult struct {
+	Rule *MorpheusSecurityGroupRule `json:"rule"`
+}
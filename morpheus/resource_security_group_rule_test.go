@@ -0,0 +1,93 @@
+package morpheus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSecurityGroupRulePayload(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "defaults",
+			config: map[string]interface{}{
+				"security_group_id": 1,
+				"source":            "10.0.0.0/8",
+			},
+			expected: map[string]interface{}{
+				"direction":       "ingress",
+				"ruleType":        "custom",
+				"sourceType":      "cidr",
+				"source":          "10.0.0.0/8",
+				"destinationType": "instance",
+				"policy":          "accept",
+				"enabled":         true,
+			},
+		},
+		{
+			name: "group source and cidr destination",
+			config: map[string]interface{}{
+				"security_group_id": 1,
+				"name":              "tfrule",
+				"direction":         "egress",
+				"protocol":          "tcp",
+				"port_range":        "443",
+				"source_port_range": "1024-65535",
+				"source_type":       "group",
+				"source_group_id":   2,
+				"source":            "ignored",
+				"destination_type":  "cidr",
+				"destination":       "0.0.0.0/0",
+				"policy":            "reject",
+				"priority":          100,
+				"enabled":           false,
+			},
+			expected: map[string]interface{}{
+				"name":            "tfrule",
+				"direction":       "egress",
+				"ruleType":        "custom",
+				"protocol":        "tcp",
+				"portRange":       "443",
+				"sourcePortRange": "1024-65535",
+				"sourceType":      "group",
+				"sourceGroup":     map[string]interface{}{"id": 2},
+				"destinationType": "cidr",
+				"destination":     "0.0.0.0/0",
+				"policy":          "reject",
+				"priority":        100,
+				"enabled":         false,
+			},
+		},
+		{
+			name: "tier source and group destination",
+			config: map[string]interface{}{
+				"security_group_id":    1,
+				"source_type":          "tier",
+				"source_tier_id":       3,
+				"destination_type":     "group",
+				"destination_group_id": 4,
+			},
+			expected: map[string]interface{}{
+				"direction":        "ingress",
+				"ruleType":         "custom",
+				"sourceType":       "tier",
+				"sourceTier":       map[string]interface{}{"id": 3},
+				"destinationType":  "group",
+				"destinationGroup": map[string]interface{}{"id": 4},
+				"policy":           "accept",
+				"enabled":          true,
+			},
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceSecurityGroupRule().Schema, c.config)
+		if payload := securityGroupRulePayload(d); !reflect.DeepEqual(payload, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, payload)
+		}
+	}
+}
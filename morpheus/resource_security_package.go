@@ -61,7 +61,7 @@ func resourceSecurityPackage() *schema.Resource {
 }
 
 func resourceSecurityPackageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -103,7 +103,7 @@ func resourceSecurityPackageCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceSecurityPackageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -148,7 +148,7 @@ func resourceSecurityPackageRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceSecurityPackageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	securityPackage := make(map[string]interface{})
 
@@ -185,7 +185,7 @@ func resourceSecurityPackageUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceSecurityPackageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
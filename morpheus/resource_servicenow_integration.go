@@ -94,7 +94,7 @@ func resourceServiceNowIntegration() *schema.Resource {
 }
 
 func resourceServiceNowIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -171,7 +171,7 @@ func resourceServiceNowIntegrationCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceServiceNowIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -230,7 +230,7 @@ func resourceServiceNowIntegrationRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceServiceNowIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	integration := make(map[string]interface{})
@@ -311,7 +311,7 @@ func resourceServiceNowIntegrationUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceServiceNowIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
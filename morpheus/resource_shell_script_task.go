@@ -186,7 +186,7 @@ func resourceShellScriptTask() *schema.Resource {
 }
 
 func resourceShellScriptTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -283,7 +283,7 @@ func resourceShellScriptTaskCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceShellScriptTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -351,7 +351,7 @@ func resourceShellScriptTaskRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceShellScriptTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -442,7 +442,7 @@ func resourceShellScriptTaskUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceShellScriptTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
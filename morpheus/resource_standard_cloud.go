@@ -148,7 +148,7 @@ func resourceStandardCloud() *schema.Resource {
 }
 
 func resourceStandardCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -298,7 +298,7 @@ func resourceStandardCloudCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceStandardCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -372,7 +372,7 @@ func resourceStandardCloudRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceStandardCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	cloud := make(map[string]interface{})
 	// Name
@@ -493,7 +493,7 @@ func resourceStandardCloudUpdate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceStandardCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
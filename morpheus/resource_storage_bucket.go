@@ -0,0 +1,503 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// storageBucketProvider describes how the settings of a provider block map to
+// the config of a storage bucket
+type storageBucketProvider struct {
+	providerType string
+	// fields maps the provider block attributes to their config keys
+	fields map[string]string
+	// secrets are the attributes that are masked in API responses
+	secrets []string
+}
+
+var storageBucketProviders = map[string]storageBucketProvider{
+	"s3": {
+		providerType: "s3",
+		fields: map[string]string{
+			"access_key": "accessKey",
+			"secret_key": "secretKey",
+			"region":     "region",
+			"endpoint":   "endpoint",
+		},
+		secrets: []string{"secret_key"},
+	},
+	"azure": {
+		providerType: "azure",
+		fields: map[string]string{
+			"storage_account": "storageAccount",
+			"storage_key":     "storageKey",
+		},
+		secrets: []string{"storage_key"},
+	},
+	"nfs": {
+		providerType: "nfs",
+		fields: map[string]string{
+			"host":          "host",
+			"export_folder": "exportFolder",
+		},
+	},
+	"cifs": {
+		providerType: "cifs",
+		fields: map[string]string{
+			"host":     "host",
+			"username": "username",
+			"password": "password",
+		},
+		secrets: []string{"password"},
+	},
+	"local": {
+		providerType: "local",
+		fields: map[string]string{
+			"path": "basePath",
+		},
+	},
+	"google": {
+		providerType: "google",
+		fields: map[string]string{
+			"project_id":   "projectId",
+			"client_email": "clientEmail",
+			"private_key":  "privateKey",
+		},
+		secrets: []string{"private_key"},
+	},
+}
+
+var storageBucketProviderBlocks = []string{"s3", "azure", "nfs", "cifs", "local", "google"}
+
+func resourceStorageBucket() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus storage bucket resource",
+		CreateContext: resourceStorageBucketCreate,
+		ReadContext:   resourceStorageBucketRead,
+		UpdateContext: resourceStorageBucketUpdate,
+		DeleteContext: resourceStorageBucketDelete,
+		CustomizeDiff: storageBucketProviderForceNew,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the storage bucket",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the storage bucket",
+				Required:    true,
+			},
+			"bucket_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the bucket or container in the storage provider",
+				Optional:    true,
+			},
+			"create_bucket": {
+				Type:        schema.TypeBool,
+				Description: "Whether the bucket is created in the storage provider if it does not exist, only used when the storage bucket is created",
+				Optional:    true,
+				Default:     false,
+			},
+			"default_backup_target": {
+				Type:        schema.TypeBool,
+				Description: "Whether the storage bucket is the default target for backups",
+				Optional:    true,
+				Default:     false,
+			},
+			"default_deployment_target": {
+				Type:        schema.TypeBool,
+				Description: "Whether the storage bucket is the default target for deployment archives",
+				Optional:    true,
+				Default:     false,
+			},
+			"default_virtual_image_target": {
+				Type:        schema.TypeBool,
+				Description: "Whether the storage bucket is the default target for virtual images",
+				Optional:    true,
+				Default:     false,
+			},
+			"archive_snapshots": {
+				Type:        schema.TypeBool,
+				Description: "Whether snapshots are archived to the storage bucket",
+				Optional:    true,
+				Default:     false,
+			},
+			"s3": {
+				Type:         schema.TypeList,
+				Description:  "The settings of an Amazon S3 or S3 compatible storage bucket",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: storageBucketProviderBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_key": {
+							Type:        schema.TypeString,
+							Description: "The access key used to connect to S3",
+							Required:    true,
+						},
+						"secret_key": {
+							Type:        schema.TypeString,
+							Description: "The secret key used to connect to S3",
+							Required:    true,
+							Sensitive:   true,
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Description: "The region of the bucket (e.g. us-east-1)",
+							Optional:    true,
+						},
+						"endpoint": {
+							Type:        schema.TypeString,
+							Description: "The endpoint url of an S3 compatible storage service",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"azure": {
+				Type:         schema.TypeList,
+				Description:  "The settings of an Azure Blob storage container",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: storageBucketProviderBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_account": {
+							Type:        schema.TypeString,
+							Description: "The name of the Azure storage account",
+							Required:    true,
+						},
+						"storage_key": {
+							Type:        schema.TypeString,
+							Description: "The access key of the Azure storage account",
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"nfs": {
+				Type:         schema.TypeList,
+				Description:  "The settings of an NFS storage bucket",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: storageBucketProviderBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:        schema.TypeString,
+							Description: "The hostname or IP address of the NFS server",
+							Required:    true,
+						},
+						"export_folder": {
+							Type:        schema.TypeString,
+							Description: "The exported folder of the NFS server (e.g. /exports/morpheus)",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"cifs": {
+				Type:         schema.TypeList,
+				Description:  "The settings of a CIFS (SMB) storage bucket",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: storageBucketProviderBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:        schema.TypeString,
+							Description: "The hostname or IP address of the CIFS server",
+							Required:    true,
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Description: "The username of the account used to connect to the CIFS server",
+							Optional:    true,
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Description: "The password of the account used to connect to the CIFS server",
+							Optional:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"local": {
+				Type:         schema.TypeList,
+				Description:  "The settings of a storage bucket on the local storage of the Morpheus appliance",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: storageBucketProviderBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Description: "The path of the storage bucket on the Morpheus appliance (e.g. /var/opt/morpheus/backups)",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"google": {
+				Type:         schema.TypeList,
+				Description:  "The settings of a Google Cloud Storage bucket",
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: storageBucketProviderBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the Google Cloud project",
+							Required:    true,
+						},
+						"client_email": {
+							Type:        schema.TypeString,
+							Description: "The email address of the service account used to connect to Google Cloud Storage",
+							Required:    true,
+						},
+						"private_key": {
+							Type:        schema.TypeString,
+							Description: "The private key of the service account used to connect to Google Cloud Storage",
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"provider_type": {
+				Type:        schema.TypeString,
+				Description: "The storage provider type of the storage bucket",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// storageBucketProviderForceNew forces a new storage bucket when the provider
+// block is replaced by another one, as the provider type cannot be changed
+func storageBucketProviderForceNew(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, block := range storageBucketProviderBlocks {
+		if !d.HasChange(block) {
+			continue
+		}
+		o, n := d.GetChange(block)
+		if len(o.([]interface{})) != len(n.([]interface{})) && d.Id() != "" {
+			if err := d.ForceNew(block); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func resourceStorageBucketCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	storageBucket := storageBucketPayload(d)
+	storageBucket["createBucket"] = d.Get("create_bucket").(bool)
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/storage-buckets",
+		Body: map[string]interface{}{
+			"storageBucket": storageBucket,
+		},
+		Result: &GetMorpheusStorageBucketResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusStorageBucketResult)
+	if result.StorageBucket == nil {
+		return diag.Errorf("create operation: storage bucket not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.StorageBucket.ID))
+
+	resourceStorageBucketRead(ctx, d, meta)
+	return diags
+}
+
+func resourceStorageBucketRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/storage-buckets/%s", id),
+		Result: &GetMorpheusStorageBucketResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusStorageBucketResult)
+	storageBucket := result.StorageBucket
+	if storageBucket == nil {
+		return diag.Errorf("read operation: storage bucket not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(storageBucket.ID))
+	d.Set("name", storageBucket.Name)
+	d.Set("bucket_name", storageBucket.BucketName)
+	d.Set("provider_type", storageBucket.ProviderType)
+	d.Set("default_backup_target", storageBucket.DefaultBackupTarget)
+	d.Set("default_deployment_target", storageBucket.DefaultDeploymentTarget)
+	d.Set("default_virtual_image_target", storageBucket.DefaultVirtualImageTarget)
+	d.Set("archive_snapshots", storageBucket.CopyToStore)
+
+	// The credentials are masked in the response, so they are kept from the
+	// configuration
+	for _, block := range storageBucketProviderBlocks {
+		provider := storageBucketProviders[block]
+		if provider.providerType != storageBucket.ProviderType {
+			d.Set(block, nil)
+			continue
+		}
+		current := make(map[string]interface{})
+		if blocks := d.Get(block).([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+			current = blocks[0].(map[string]interface{})
+		}
+		settings := make(map[string]interface{})
+		for field, key := range provider.fields {
+			if value, ok := storageBucket.Config[key]; ok && value != nil {
+				settings[field] = fmt.Sprintf("%v", value)
+			}
+		}
+		for _, secret := range provider.secrets {
+			settings[secret] = current[secret]
+		}
+		d.Set(block, []interface{}{settings})
+	}
+
+	return diags
+}
+
+func resourceStorageBucketUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/storage-buckets/%s", id),
+		Body: map[string]interface{}{
+			"storageBucket": storageBucketPayload(d),
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceStorageBucketRead(ctx, d, meta)
+}
+
+func resourceStorageBucketDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/storage-buckets/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// storageBucketPayload builds the settings of a storage bucket, the config
+// is built from the provider block that is set
+func storageBucketPayload(d *schema.ResourceData) map[string]interface{} {
+	storageBucket := map[string]interface{}{
+		"name":                      d.Get("name").(string),
+		"bucketName":                d.Get("bucket_name").(string),
+		"defaultBackupTarget":       d.Get("default_backup_target").(bool),
+		"defaultDeploymentTarget":   d.Get("default_deployment_target").(bool),
+		"defaultVirtualImageTarget": d.Get("default_virtual_image_target").(bool),
+		"copyToStore":               d.Get("archive_snapshots").(bool),
+	}
+
+	for _, block := range storageBucketProviderBlocks {
+		blocks := d.Get(block).([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+		provider := storageBucketProviders[block]
+		settings := blocks[0].(map[string]interface{})
+		config := make(map[string]interface{})
+		for field, key := range provider.fields {
+			if value := settings[field].(string); value != "" {
+				config[key] = value
+			}
+		}
+		storageBucket["providerType"] = provider.providerType
+		storageBucket["config"] = config
+	}
+
+	return storageBucket
+}
+
+type MorpheusStorageBucket struct {
+	ID                        int64                  `json:"id"`
+	Name                      string                 `json:"name"`
+	BucketName                string                 `json:"bucketName"`
+	ProviderType              string                 `json:"providerType"`
+	DefaultBackupTarget       bool                   `json:"defaultBackupTarget"`
+	DefaultDeploymentTarget   bool                   `json:"defaultDeploymentTarget"`
+	DefaultVirtualImageTarget bool                   `json:"defaultVirtualImageTarget"`
+	CopyToStore               bool                   `json:"copyToStore"`
+	Config                    map[string]interface{} `json:"config"`
+}
+
+type GetMorpheusStorageBucketResult struct {
+	StorageBucket *MorpheusStorageBucket `json:"storageBucket"`
+}
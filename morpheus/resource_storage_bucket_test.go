@@ -0,0 +1,128 @@
+package morpheus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestStorageBucketPayload(t *testing.T) {
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "s3",
+			raw: map[string]interface{}{
+				"name":                  "tfbackups",
+				"bucket_name":           "tf-backups",
+				"default_backup_target": true,
+				"archive_snapshots":     true,
+				"s3": []interface{}{
+					map[string]interface{}{
+						"access_key": "AKIAEXAMPLE",
+						"secret_key": "secret",
+						"region":     "us-east-1",
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"name":                      "tfbackups",
+				"bucketName":                "tf-backups",
+				"providerType":              "s3",
+				"defaultBackupTarget":       true,
+				"defaultDeploymentTarget":   false,
+				"defaultVirtualImageTarget": false,
+				"copyToStore":               true,
+				"config": map[string]interface{}{
+					"accessKey": "AKIAEXAMPLE",
+					"secretKey": "secret",
+					"region":    "us-east-1",
+				},
+			},
+		},
+		{
+			name: "nfs",
+			raw: map[string]interface{}{
+				"name":                      "tfdeployments",
+				"bucket_name":               "deployments",
+				"default_deployment_target": true,
+				"nfs": []interface{}{
+					map[string]interface{}{
+						"host":          "10.0.0.5",
+						"export_folder": "/exports/morpheus",
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"name":                      "tfdeployments",
+				"bucketName":                "deployments",
+				"providerType":              "nfs",
+				"defaultBackupTarget":       false,
+				"defaultDeploymentTarget":   true,
+				"defaultVirtualImageTarget": false,
+				"copyToStore":               false,
+				"config": map[string]interface{}{
+					"host":         "10.0.0.5",
+					"exportFolder": "/exports/morpheus",
+				},
+			},
+		},
+		{
+			name: "local",
+			raw: map[string]interface{}{
+				"name":                         "tfimages",
+				"bucket_name":                  "images",
+				"default_virtual_image_target": true,
+				"local": []interface{}{
+					map[string]interface{}{
+						"path": "/var/opt/morpheus/images",
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"name":                      "tfimages",
+				"bucketName":                "images",
+				"providerType":              "local",
+				"defaultBackupTarget":       false,
+				"defaultDeploymentTarget":   false,
+				"defaultVirtualImageTarget": true,
+				"copyToStore":               false,
+				"config": map[string]interface{}{
+					"basePath": "/var/opt/morpheus/images",
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceStorageBucket().Schema, c.raw)
+		if payload := storageBucketPayload(d); !reflect.DeepEqual(payload, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, payload)
+		}
+	}
+}
+
+func TestStorageBucketProviders(t *testing.T) {
+	s := resourceStorageBucket().Schema
+	for _, block := range storageBucketProviderBlocks {
+		provider, ok := storageBucketProviders[block]
+		if !ok {
+			t.Errorf("%s: no storage bucket provider", block)
+			continue
+		}
+		// Every attribute of the block is sent in the config
+		elem := s[block].Elem.(*schema.Resource)
+		for field := range elem.Schema {
+			if _, ok := provider.fields[field]; !ok {
+				t.Errorf("%s: the attribute %s is not mapped to a config key", block, field)
+			}
+		}
+		for _, secret := range provider.secrets {
+			if attr, ok := elem.Schema[secret]; !ok || !attr.Sensitive {
+				t.Errorf("%s: the secret %s is not a sensitive attribute", block, secret)
+			}
+		}
+	}
+}
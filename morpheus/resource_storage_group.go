@@ -0,0 +1,228 @@
+package morpheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceStorageGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus storage group resource, the storage group is a pool of capacity on a storage server (such as a 3PAR CPG or a NetApp aggregate) that volumes and datastores are created in",
+		CreateContext: resourceStorageGroupCreate,
+		ReadContext:   resourceStorageGroupRead,
+		UpdateContext: resourceStorageGroupUpdate,
+		DeleteContext: resourceStorageGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the storage group",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the storage group",
+				Required:    true,
+			},
+			"storage_server_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the storage server to create the storage group on",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"config": {
+				Type:             schema.TypeString,
+				Description:      "Additional storage server type specific settings (JSON) of the storage group",
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentJsonDiffs,
+				ValidateFunc:     validation.StringIsJSON,
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Description: "The external id of the storage group on the storage server",
+				Computed:    true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "The status of the storage group",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceStorageGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	storageGroup, err := storageGroupPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storageGroup["storageServer"] = map[string]interface{}{
+		"id": d.Get("storage_server_id").(int),
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/storage-groups",
+		Body: map[string]interface{}{
+			"storageGroup": storageGroup,
+		},
+		Result: &GetMorpheusStorageGroupResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusStorageGroupResult)
+	if result.StorageGroup == nil {
+		return diag.Errorf("create operation: storage group not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.StorageGroup.ID))
+
+	resourceStorageGroupRead(ctx, d, meta)
+	return diags
+}
+
+func resourceStorageGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/storage-groups/%s", id),
+		Result: &GetMorpheusStorageGroupResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusStorageGroupResult)
+	storageGroup := result.StorageGroup
+	if storageGroup == nil {
+		return diag.Errorf("read operation: storage group not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(storageGroup.ID))
+	d.Set("name", storageGroup.Name)
+	d.Set("storage_server_id", storageGroup.StorageServer.ID)
+	d.Set("external_id", storageGroup.ExternalId)
+	d.Set("status", storageGroup.Status)
+
+	return diags
+}
+
+func resourceStorageGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	storageGroup, err := storageGroupPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/storage-groups/%s", id),
+		Body: map[string]interface{}{
+			"storageGroup": storageGroup,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceStorageGroupRead(ctx, d, meta)
+}
+
+func resourceStorageGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/storage-groups/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// storageGroupPayload builds the settings of a storage group that can be
+// changed after it has been created
+func storageGroupPayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	storageGroup := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+
+	if d.Get("config").(string) != "" {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(d.Get("config").(string)), &config); err != nil {
+			return nil, fmt.Errorf("unable to parse the storage group config: %s", err)
+		}
+		storageGroup["config"] = config
+	}
+
+	return storageGroup, nil
+}
+
+type MorpheusStorageGroup struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	ExternalId    string `json:"externalId"`
+	Status        string `json:"status"`
+	StorageServer struct {
+		ID int64 `json:"id"`
+	} `json:"storageServer"`
+}
+
+type GetMorpheusStorageGroupResult struct {
+	StorageGroup *MorpheusStorageGroup `json:"storageGroup"`
+}
@@ -0,0 +1,333 @@
+package morpheus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceStorageServer() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus storage server resource, such as an HPE 3PAR, Pure Storage, NetApp, Isilon or Dell EMC storage integration",
+		CreateContext: resourceStorageServerCreate,
+		ReadContext:   resourceStorageServerRead,
+		UpdateContext: resourceStorageServerUpdate,
+		DeleteContext: resourceStorageServerDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the storage server",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the storage server",
+				Required:    true,
+			},
+			"type_code": {
+				Type:        schema.TypeString,
+				Description: "The code of the storage server type (e.g. 3par, pureStorage, netapp, isilon or ecs)",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the storage server is enabled",
+				Optional:    true,
+				Default:     true,
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Description: "The url of the storage server API (e.g. https://3par.example.local:8080)",
+				Required:    true,
+			},
+			"credential_id": {
+				Type:          schema.TypeInt,
+				Description:   "The id of the credential store entry used for authentication",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"username", "password"},
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Description: "The username of the account used to connect to the storage server",
+				Optional:    true,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "The password of the account used to connect to the storage server",
+				Optional:    true,
+				Sensitive:   true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					h := sha256.New()
+					h.Write([]byte(new))
+					sha256_hash := hex.EncodeToString(h.Sum(nil))
+					return strings.EqualFold(old, sha256_hash)
+				},
+				DiffSuppressOnRefresh: true,
+			},
+			"config": {
+				Type:             schema.TypeString,
+				Description:      "Additional storage server type specific settings (JSON), such as the NetApp SVM or the Isilon access zone",
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentJsonDiffs,
+				ValidateFunc:     validation.StringIsJSON,
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Description:  "Whether the storage server is visible in sub-tenants or not",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public"}, false),
+				Default:      "private",
+			},
+			"tenant_ids": {
+				Type:        schema.TypeSet,
+				Description: "A list of tenant ids granted access to the storage server",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "The status of the storage server",
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceStorageServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	storageServer, err := storageServerPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storageServer["type"] = map[string]interface{}{
+		"code": d.Get("type_code").(string),
+	}
+	if d.Get("credential_id").(int) == 0 {
+		storageServer["serviceUsername"] = d.Get("username").(string)
+		storageServer["servicePassword"] = d.Get("password").(string)
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/storage-servers",
+		Body: map[string]interface{}{
+			"storageServer": storageServer,
+		},
+		Result: &GetMorpheusStorageServerResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusStorageServerResult)
+	if result.StorageServer == nil {
+		return diag.Errorf("create operation: storage server not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.StorageServer.ID))
+
+	resourceStorageServerRead(ctx, d, meta)
+	return diags
+}
+
+func resourceStorageServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/storage-servers/%s", id),
+		Result: &GetMorpheusStorageServerResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	// store resource data
+	result := resp.Result.(*GetMorpheusStorageServerResult)
+	storageServer := result.StorageServer
+	if storageServer == nil {
+		return diag.Errorf("read operation: storage server not found in response data") // should not happen
+	}
+
+	d.SetId(int64ToString(storageServer.ID))
+	d.Set("name", storageServer.Name)
+	d.Set("type_code", storageServer.Type.Code)
+	d.Set("enabled", storageServer.Enabled)
+	d.Set("url", storageServer.ServiceUrl)
+	if storageServer.Credential.ID == 0 {
+		d.Set("username", storageServer.ServiceUsername)
+		d.Set("password", storageServer.ServicePasswordHash)
+	} else {
+		d.Set("credential_id", storageServer.Credential.ID)
+	}
+	d.Set("visibility", storageServer.Visibility)
+	d.Set("status", storageServer.Status)
+	// Tenant Access
+	var tenantIds []int64
+	for _, tenant := range storageServer.Tenants {
+		tenantIds = append(tenantIds, tenant.ID)
+	}
+	d.Set("tenant_ids", tenantIds)
+
+	return diags
+}
+
+func resourceStorageServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	storageServer, err := storageServerPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if d.Get("credential_id").(int) == 0 {
+		if d.HasChange("username") {
+			storageServer["serviceUsername"] = d.Get("username").(string)
+		}
+		if d.HasChange("password") {
+			storageServer["servicePassword"] = d.Get("password").(string)
+		}
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/storage-servers/%s", id),
+		Body: map[string]interface{}{
+			"storageServer": storageServer,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	return resourceStorageServerRead(ctx, d, meta)
+}
+
+func resourceStorageServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/storage-servers/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// storageServerPayload builds the settings of a storage server that can be
+// changed after it has been created, the username and password are added by
+// the caller as they are only sent when they change
+func storageServerPayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	tenantsPayload := make([]int, 0)
+	if attr, ok := d.GetOk("tenant_ids"); ok {
+		for _, s := range attr.(*schema.Set).List() {
+			tenantsPayload = append(tenantsPayload, s.(int))
+		}
+	}
+
+	storageServer := map[string]interface{}{
+		"name":       d.Get("name").(string),
+		"enabled":    d.Get("enabled").(bool),
+		"serviceUrl": d.Get("url").(string),
+		"visibility": d.Get("visibility").(string),
+		"tenantPermissions": map[string]interface{}{
+			"accounts": tenantsPayload,
+		},
+	}
+	if credentialId := d.Get("credential_id").(int); credentialId != 0 {
+		storageServer["credential"] = map[string]interface{}{
+			"type": "username-password",
+			"id":   credentialId,
+		}
+	}
+
+	if d.Get("config").(string) != "" {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(d.Get("config").(string)), &config); err != nil {
+			return nil, fmt.Errorf("unable to parse the storage server config: %s", err)
+		}
+		storageServer["config"] = config
+	}
+
+	return storageServer, nil
+}
+
+type MorpheusStorageServer struct {
+	ID                  int64  `json:"id"`
+	Name                string `json:"name"`
+	Enabled             bool   `json:"enabled"`
+	Visibility          string `json:"visibility"`
+	Status              string `json:"status"`
+	ServiceUrl          string `json:"serviceUrl"`
+	ServiceUsername     string `json:"serviceUsername"`
+	ServicePasswordHash string `json:"servicePasswordHash"`
+	Type                struct {
+		ID   int64  `json:"id"`
+		Code string `json:"code"`
+	} `json:"type"`
+	Credential struct {
+		ID int64 `json:"id"`
+	} `json:"credential"`
+	Tenants []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"tenants"`
+}
+
+type GetMorpheusStorageServerResult struct {
+	StorageServer *MorpheusStorageServer `json:"storageServer"`
+}
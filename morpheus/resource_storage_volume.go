@@ -0,0 +1,382 @@
+package morpheus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// storageVolumeGigabyte is the number of bytes in a gigabyte, the size of a
+// storage volume is returned in bytes
+const storageVolumeGigabyte = 1024 * 1024 * 1024
+
+// The statuses of a storage volume once it has been provisioned
+const (
+	storageVolumeStatusAvailable  = "available"
+	storageVolumeStatusUnattached = "unattached"
+)
+
+func resourceStorageVolume() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a Morpheus storage volume resource, the storage volume is a standalone volume that can be attached to an instance",
+		CreateContext: resourceStorageVolumeCreate,
+		ReadContext:   resourceStorageVolumeRead,
+		UpdateContext: resourceStorageVolumeUpdate,
+		DeleteContext: resourceStorageVolumeDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the storage volume",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the storage volume",
+				Required:    true,
+			},
+			"type_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the storage volume type",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"size": {
+				Type:         schema.TypeInt,
+				Description:  "The size of the storage volume in GB, the volume is grown in place while shrinking it forces a new storage volume. A volume grown outside of Terraform is replaced unless the size is updated to match",
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"cloud_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the cloud to create the storage volume in",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"storage_server_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the storage server to create the storage volume on",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"storage_group_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the storage group to create the storage volume in",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"instance_id": {
+				Type:        schema.TypeInt,
+				Description: "The ID of the instance the storage volume is attached to",
+				Optional:    true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "The status of the storage volume",
+				Computed:    true,
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Description: "The uuid of the storage volume",
+				Computed:    true,
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Description: "The external id of the storage volume on the storage server or cloud",
+				Computed:    true,
+			},
+		},
+		CustomizeDiff: customdiff.ForceNewIfChange("size", func(ctx context.Context, old, new, meta interface{}) bool {
+			// Volumes can only be grown, so shrinking a volume replaces it
+			return new.(int) < old.(int)
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceStorageVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	storageVolume := map[string]interface{}{
+		"name":       d.Get("name").(string),
+		"maxStorage": int64(d.Get("size").(int)) * storageVolumeGigabyte,
+		"type": map[string]interface{}{
+			"id": d.Get("type_id").(int),
+		},
+	}
+	if cloudId := d.Get("cloud_id").(int); cloudId != 0 {
+		storageVolume["zone"] = map[string]interface{}{
+			"id": cloudId,
+		}
+	}
+	if storageServerId := d.Get("storage_server_id").(int); storageServerId != 0 {
+		storageVolume["storageServer"] = map[string]interface{}{
+			"id": storageServerId,
+		}
+	}
+	if storageGroupId := d.Get("storage_group_id").(int); storageGroupId != 0 {
+		storageVolume["storageGroup"] = map[string]interface{}{
+			"id": storageGroupId,
+		}
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/storage-volumes",
+		Body: map[string]interface{}{
+			"storageVolume": storageVolume,
+		},
+		Result: &GetMorpheusStorageVolumeResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return diag.FromErr(err)
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusStorageVolumeResult)
+	if result.StorageVolume == nil {
+		return diag.Errorf("create operation: storage volume not found in response data") // should not happen
+	}
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(result.StorageVolume.ID))
+
+	// The volume is provisioned on the storage server or cloud in the
+	// background and cannot be attached until it is ready
+	pollConfig := meta.(*providerMeta).config
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{statusPending, statusProvisioning},
+		Target:  []string{statusProvisioned, storageVolumeStatusAvailable, storageVolumeStatusUnattached, statusFailed},
+		Refresh: func() (interface{}, string, error) {
+			storageVolume, resp, err := getStorageVolume(client, d.Id())
+			if err != nil {
+				log.Printf("API FAILURE: %s - %s", resp, err)
+				return "", "", err
+			}
+			return storageVolume, storageVolume.Status, nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		MinTimeout:   10 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+	storageVolumeResult, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error creating storage volume: %s", err)
+	}
+	if status := storageVolumeResult.(*MorpheusStorageVolume).Status; status == statusFailed {
+		return diag.Errorf("error creating storage volume: storage volume %s is in a %s state", d.Id(), status)
+	}
+
+	if instanceId := d.Get("instance_id").(int); instanceId != 0 {
+		if err := storageVolumeAttachment(client, d.Id(), "attach", instanceId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	resourceStorageVolumeRead(ctx, d, meta)
+	return diags
+}
+
+func resourceStorageVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	storageVolume, resp, err := getStorageVolume(client, d.Id())
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			log.Printf("Forcing recreation of resource")
+			d.SetId("")
+			return diags
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+
+	// store resource data
+	d.SetId(int64ToString(storageVolume.ID))
+	d.Set("name", storageVolume.Name)
+	d.Set("type_id", storageVolume.Type.ID)
+	// The size is read back so that a volume resized outside of Terraform is
+	// reported as drift
+	d.Set("size", storageVolume.MaxStorage/storageVolumeGigabyte)
+	if storageVolume.Zone.ID != 0 {
+		d.Set("cloud_id", storageVolume.Zone.ID)
+	}
+	if storageVolume.StorageServer.ID != 0 {
+		d.Set("storage_server_id", storageVolume.StorageServer.ID)
+	}
+	if storageVolume.StorageGroup.ID != 0 {
+		d.Set("storage_group_id", storageVolume.StorageGroup.ID)
+	}
+	d.Set("instance_id", storageVolume.Instance.ID)
+	d.Set("status", storageVolume.Status)
+	d.Set("uuid", storageVolume.Uuid)
+	d.Set("external_id", storageVolume.ExternalId)
+
+	return diags
+}
+
+func resourceStorageVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	id := d.Id()
+
+	if d.HasChanges("name", "size") {
+		resp, err := client.Execute(&morpheus.Request{
+			Method: "PUT",
+			Path:   fmt.Sprintf("/api/storage-volumes/%s", id),
+			Body: map[string]interface{}{
+				"storageVolume": map[string]interface{}{
+					"name":       d.Get("name").(string),
+					"maxStorage": int64(d.Get("size").(int)) * storageVolumeGigabyte,
+				},
+			},
+			Result: &morpheus.StandardResult{},
+		})
+		if err != nil {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+		log.Printf("API RESPONSE: %s", resp)
+	}
+
+	// Moving the volume to another instance detaches it before attaching it
+	if d.HasChange("instance_id") {
+		oldInstanceId, newInstanceId := d.GetChange("instance_id")
+		if oldInstanceId.(int) != 0 {
+			if err := storageVolumeAttachment(client, id, "detach", oldInstanceId.(int)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if newInstanceId.(int) != 0 {
+			if err := storageVolumeAttachment(client, id, "attach", newInstanceId.(int)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return resourceStorageVolumeRead(ctx, d, meta)
+}
+
+func resourceStorageVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	id := d.Id()
+
+	// An attached volume cannot be deleted, so it is detached first
+	if instanceId := d.Get("instance_id").(int); instanceId != 0 {
+		if err := storageVolumeAttachment(client, id, "detach", instanceId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/api/storage-volumes/%s", id),
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("API 404: %s - %s", resp, err)
+			return nil
+		} else {
+			log.Printf("API FAILURE: %s - %s", resp, err)
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	d.SetId("")
+	return diags
+}
+
+// getStorageVolume fetches a storage volume
+func getStorageVolume(client *morpheus.Client, id string) (*MorpheusStorageVolume, *morpheus.Response, error) {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/storage-volumes/%s", id),
+		Result: &GetMorpheusStorageVolumeResult{},
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	result := resp.Result.(*GetMorpheusStorageVolumeResult)
+	if result.StorageVolume == nil {
+		return nil, resp, fmt.Errorf("storage volume not found in response data") // should not happen
+	}
+	return result.StorageVolume, resp, nil
+}
+
+// storageVolumeAttachment attaches a storage volume to an instance or
+// detaches it from the instance, the action is either attach or detach
+func storageVolumeAttachment(client *morpheus.Client, id string, action string, instanceId int) error {
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/storage-volumes/%s/%s", id, action),
+		Body: map[string]interface{}{
+			"instance": map[string]interface{}{
+				"id": instanceId,
+			},
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	return nil
+}
+
+type MorpheusStorageVolume struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Uuid       string `json:"uuid"`
+	ExternalId string `json:"externalId"`
+	MaxStorage int64  `json:"maxStorage"`
+	Type       struct {
+		ID int64 `json:"id"`
+	} `json:"type"`
+	Zone struct {
+		ID int64 `json:"id"`
+	} `json:"zone"`
+	StorageServer struct {
+		ID int64 `json:"id"`
+	} `json:"storageServer"`
+	StorageGroup struct {
+		ID int64 `json:"id"`
+	} `json:"storageGroup"`
+	Instance struct {
+		ID int64 `json:"id"`
+	} `json:"instance"`
+}
+
+type GetMorpheusStorageVolumeResult struct {
+	StorageVolume *MorpheusStorageVolume `json:"storageVolume"`
+}
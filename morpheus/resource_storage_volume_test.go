@@ -0,0 +1,171 @@
+package morpheus
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testStorageVolumeAPI emulates a storage volume that is provisioned in the
+// background, the volume reports the provisioning status for the first polls
+// and then the final status. The statuses of the volume when it was attached
+// are returned by attached.
+func testStorageVolumeAPI(t *testing.T, finalStatus string) (api *fakeMorpheus, attached func() []string) {
+	t.Helper()
+	api = newFakeMorpheus(t)
+	var mu sync.Mutex
+	var statuses []string
+
+	api.Handle("POST", "/api/storage-volumes", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		storageVolume := body["storageVolume"].(map[string]interface{})
+		storageVolume["status"] = "provisioning"
+		id := s.Seed("/api/storage-volumes", storageVolume)
+		path := "/api/storage-volumes/" + int64ToString(id)
+
+		polls := 0
+		s.Handle("GET", path, func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+			polls++
+			if polls > 2 {
+				s.Update("/api/storage-volumes", id, map[string]interface{}{"status": finalStatus})
+			}
+			object, _ := s.Get("/api/storage-volumes", id)
+			return 200, map[string]interface{}{"storageVolume": object}
+		})
+		s.Handle("PUT", path+"/attach", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+			object, _ := s.Get("/api/storage-volumes", id)
+			mu.Lock()
+			statuses = append(statuses, object["status"].(string))
+			mu.Unlock()
+			s.Update("/api/storage-volumes", id, map[string]interface{}{"instance": body["instance"]})
+			return 200, map[string]interface{}{"success": true}
+		})
+
+		object, _ := s.Get("/api/storage-volumes", id)
+		return 200, map[string]interface{}{"success": true, "storageVolume": object}
+	})
+
+	attached = func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), statuses...)
+	}
+	return api, attached
+}
+
+func TestResourceStorageVolumeCreate_waitsBeforeAttach(t *testing.T) {
+	api, attached := testStorageVolumeAPI(t, "provisioned")
+
+	d := schema.TestResourceDataRaw(t, resourceStorageVolume().Schema, map[string]interface{}{
+		"name":        "tfvolume",
+		"type_id":     1,
+		"size":        20,
+		"instance_id": 12,
+	})
+	if diags := resourceStorageVolumeCreate(context.Background(), d, api.Meta()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	statuses := attached()
+	if len(statuses) != 1 {
+		t.Fatalf("expected the volume to be attached once, got %d attach requests", len(statuses))
+	}
+	if statuses[0] != "provisioned" {
+		t.Errorf("expected the volume to be attached once it was provisioned, it was %s", statuses[0])
+	}
+	if instanceId := d.Get("instance_id"); instanceId != 12 {
+		t.Errorf("expected the volume to be attached to instance 12, got %v", instanceId)
+	}
+	if status := d.Get("status"); status != "provisioned" {
+		t.Errorf("expected the status provisioned, got %v", status)
+	}
+}
+
+func TestResourceStorageVolumeCreate_failed(t *testing.T) {
+	api, attached := testStorageVolumeAPI(t, "failed")
+
+	d := schema.TestResourceDataRaw(t, resourceStorageVolume().Schema, map[string]interface{}{
+		"name":        "tfvolume",
+		"type_id":     1,
+		"size":        20,
+		"instance_id": 12,
+	})
+	diags := resourceStorageVolumeCreate(context.Background(), d, api.Meta())
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "failed state") {
+		t.Fatalf("expected an error for the failed volume, got %v", diags)
+	}
+	if d.Id() == "" {
+		t.Errorf("expected the failed volume to be kept in the state so it is deleted")
+	}
+	if statuses := attached(); len(statuses) != 0 {
+		t.Errorf("expected the failed volume not to be attached, got %d attach requests", len(statuses))
+	}
+}
+
+func TestResourceStorageVolumeRead_sizeDrift(t *testing.T) {
+	api := newFakeMorpheus(t)
+	id := api.Seed("/api/storage-volumes", map[string]interface{}{
+		"name":       "tfvolume",
+		"type":       map[string]interface{}{"id": 1},
+		"maxStorage": 20 * storageVolumeGigabyte,
+	})
+
+	r := resourceStorageVolume()
+	config := func(size int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":    "tfvolume",
+			"type_id": 1,
+			"size":    size,
+		}
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config(20))
+	d.SetId(int64ToString(id))
+	meta := api.Meta()
+	if diags := resourceStorageVolumeRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if size := d.Get("size"); size != 20 {
+		t.Fatalf("expected the size 20, got %v", size)
+	}
+
+	// The volume is grown outside of Terraform
+	api.Update("/api/storage-volumes", id, map[string]interface{}{"maxStorage": 40 * storageVolumeGigabyte})
+	if diags := resourceStorageVolumeRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if size := d.Get("size"); size != 40 {
+		t.Fatalf("expected the size to be read back as 40, got %v", size)
+	}
+
+	cases := []struct {
+		name     string
+		size     int
+		change   bool
+		forceNew bool
+	}{
+		// Planning the configured size shrinks the volume, which replaces it
+		{"configured size", 20, true, true},
+		{"matching size", 40, false, false},
+		{"grown size", 60, true, false},
+	}
+	for _, c := range cases {
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config(c.size)), meta)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.name, err)
+		}
+		var attr *terraform.ResourceAttrDiff
+		if diff != nil {
+			attr = diff.Attributes["size"]
+		}
+		if (attr != nil) != c.change {
+			t.Errorf("%s: expected a change of the size %t, got %v", c.name, c.change, attr)
+			continue
+		}
+		if attr != nil && attr.RequiresNew != c.forceNew {
+			t.Errorf("%s: expected the size change to force a new volume %t, got %t", c.name, c.forceNew, attr.RequiresNew)
+		}
+	}
+}
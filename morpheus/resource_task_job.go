@@ -113,7 +113,7 @@ func resourceTaskJob() *schema.Resource {
 }
 
 func resourceTaskJobCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -203,7 +203,7 @@ func resourceTaskJobCreate(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceTaskJobRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -298,7 +298,7 @@ func resourceTaskJobRead(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourceTaskJobUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	job := make(map[string]interface{})
@@ -386,7 +386,7 @@ func resourceTaskJobUpdate(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceTaskJobDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -52,7 +52,7 @@ func resourceTenantRole() *schema.Resource {
 }
 
 func resourceTenantRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -111,7 +111,7 @@ func resourceTenantRoleCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceTenantRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -368,7 +368,7 @@ func resourceTenantRoleRead(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceTenantRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	data := PermissionSet{}
@@ -420,7 +420,7 @@ func resourceTenantRoleUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceTenantRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
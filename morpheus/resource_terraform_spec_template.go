@@ -74,7 +74,7 @@ func resourceTerraformSpecTemplate() *schema.Resource {
 }
 
 func resourceTerraformSpecTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -123,7 +123,7 @@ func resourceTerraformSpecTemplateCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceTerraformSpecTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -180,7 +180,7 @@ func resourceTerraformSpecTemplateRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceTerraformSpecTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -224,7 +224,7 @@ func resourceTerraformSpecTemplateUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceTerraformSpecTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -134,7 +134,7 @@ func resourceTextOptionType() *schema.Resource {
 }
 
 func resourceTextOptionTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -188,7 +188,7 @@ func resourceTextOptionTypeCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceTextOptionTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -250,7 +250,7 @@ func resourceTextOptionTypeRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceTextOptionTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -299,7 +299,7 @@ func resourceTextOptionTypeUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceTextOptionTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
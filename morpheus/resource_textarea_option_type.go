@@ -140,7 +140,7 @@ func resourceTextAreaOptionType() *schema.Resource {
 }
 
 func resourceTextAreaOptionTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -198,7 +198,7 @@ func resourceTextAreaOptionTypeCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceTextAreaOptionTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -261,7 +261,7 @@ func resourceTextAreaOptionTypeRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceTextAreaOptionTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -313,7 +313,7 @@ func resourceTextAreaOptionTypeUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceTextAreaOptionTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
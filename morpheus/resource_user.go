@@ -113,7 +113,7 @@ func resourceMorpheusUser() *schema.Resource {
 }
 
 func resourceMorpheusUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -176,7 +176,7 @@ func resourceMorpheusUserCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceMorpheusUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -233,7 +233,7 @@ func resourceMorpheusUserRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceMorpheusUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	// roles
@@ -283,7 +283,7 @@ func resourceMorpheusUserUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceMorpheusUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -104,7 +104,7 @@ func resourceVrealizeOrchestratorTask() *schema.Resource {
 }
 
 func resourceVrealizeOrchestratorTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -160,7 +160,7 @@ func resourceVrealizeOrchestratorTaskCreate(ctx context.Context, d *schema.Resou
 }
 
 func resourceVrealizeOrchestratorTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -215,7 +215,7 @@ func resourceVrealizeOrchestratorTaskRead(ctx context.Context, d *schema.Resourc
 }
 
 func resourceVrealizeOrchestratorTaskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 
@@ -273,7 +273,7 @@ func resourceVrealizeOrchestratorTaskUpdate(ctx context.Context, d *schema.Resou
 }
 
 func resourceVrealizeOrchestratorTaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -228,7 +228,7 @@ func resourceVsphereCloud() *schema.Resource {
 }
 
 func resourceVsphereCloudCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -402,7 +402,7 @@ func resourceVsphereCloudCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceVsphereCloudRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -515,7 +515,7 @@ func resourceVsphereCloudRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceVsphereCloudUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	cloud := make(map[string]interface{})
 	// Name
@@ -667,7 +667,7 @@ func resourceVsphereCloudUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceVsphereCloudDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -76,9 +76,8 @@ func resourceVsphereInstance() *schema.Resource {
 				Required:    true,
 			},
 			"plan_id": {
-				Description: "The service plan associated with the instance",
+				Description: "The service plan associated with the instance, changing the plan resizes the instance in place",
 				Type:        schema.TypeInt,
-				ForceNew:    true,
 				Required:    true,
 			},
 			"resource_pool_id": {
@@ -207,7 +206,7 @@ func resourceVsphereInstance() *schema.Resource {
 				},
 			},
 			"volumes": {
-				Description: "The instance volumes to create",
+				Description: "The instance volumes to create, volumes can be added or grown in place while removing or shrinking a volume forces a new instance",
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem: &schema.Resource{
@@ -256,7 +255,7 @@ func resourceVsphereInstance() *schema.Resource {
 				},
 			},
 			"interfaces": {
-				Description: "The instance network interfaces to create",
+				Description: "The instance network interfaces to create, interfaces can be added in place while removing an interface forces a new instance",
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem: &schema.Resource{
@@ -274,13 +273,13 @@ func resourceVsphereInstance() *schema.Resource {
 							Computed:    true,
 						},
 						"ip_address": {
-							Description: "",
+							Description: "The static IP address to assign to the network interface",
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
 						},
 						"ip_mode": {
-							Description: "",
+							Description: "The IP address assignment mode of the network interface (e.g. static or dhcp)",
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
@@ -318,9 +317,12 @@ func resourceVsphereInstance() *schema.Resource {
 					},
 				},
 			},
+			"power_state":       powerStateSchema(),
+			"on_create_failure": onCreateFailureSchema(),
 		},
 		CustomizeDiff: customdiff.All(
 			volumesCustomizeDiff,
+			instanceResizeForceNew,
 			customdiff.ForceNewIfChange("instance_type_code", func(ctx context.Context, old, new, meta interface{}) bool {
 				// We will force a new instance if instance_type_code has a non-zero value, which means that it has been
 				// set by the user
@@ -358,7 +360,7 @@ func volumesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta inte
 }
 
 func resourceVsphereInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -541,6 +543,8 @@ func resourceVsphereInstanceCreate(ctx context.Context, d *schema.ResourceData,
 	log.Printf("API RESPONSE: %s", resp)
 	result := resp.Result.(*morpheus.CreateInstanceResult)
 	instance := result.Instance
+	instanceStatus := "provisioning"
+	pollConfig := meta.(*providerMeta).config
 
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"provisioning", "starting", "stopping", "pending"},
@@ -552,12 +556,13 @@ func resourceVsphereInstanceCreate(ctx context.Context, d *schema.ResourceData,
 			}
 			result := instanceDetails.Result.(*morpheus.GetInstanceResult)
 			instance := result.Instance
+			instanceStatus = instance.Status
 			return result, instance.Status, nil
 		},
-		Timeout:      3 * time.Hour,
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 		MinTimeout:   1 * time.Minute,
-		Delay:        3 * time.Minute,
-		PollInterval: 1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
@@ -568,12 +573,23 @@ func resourceVsphereInstanceCreate(ctx context.Context, d *schema.ResourceData,
 
 	// Successfully created resource, now set id
 	d.SetId(int64ToString(instance.ID))
+
+	// Fail the instance deployment if the
+	// instance status is in a failed state
+	if isProvisioningFailure(instanceStatus) {
+		return handleProvisioningFailure(ctx, d, meta, "instance", instanceStatus, resourceVsphereInstanceDelete)
+	}
+
+	// Power off or suspend the instance if requested once it has been provisioned
+	if powerDiags := setInstancePowerState(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); powerDiags.HasError() {
+		return powerDiags
+	}
 	resourceVsphereInstanceRead(ctx, d, meta)
 	return diags
 }
 
 func resourceVsphereInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -626,6 +642,11 @@ func resourceVsphereInstanceRead(ctx context.Context, d *schema.ResourceData, me
 	d.SetId(int64ToString(instance.ID))
 	d.Set("name", instance.Name)
 	d.Set("description", instance.Description)
+	// Only report settled power states so that an instance that is
+	// starting or stopping is not seen as drift
+	if powerState := instancePowerState(instance.Status); powerState != "" {
+		d.Set("power_state", powerState)
+	}
 	d.Set("cloud_id", instance.Cloud.ID)
 	d.Set("group_id", instance.Group.ID)
 	d.Set("instance_layout_id", instance.Layout.ID)
@@ -693,7 +714,7 @@ func resourceVsphereInstanceRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceVsphereInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -741,13 +762,28 @@ func resourceVsphereInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 	log.Printf("API RESPONSE: %s", resp)
 	result := resp.Result.(*morpheus.UpdateInstanceResult)
 	instance := result.Instance
+
+	// Plan, volume and interface changes are applied with a resize
+	if diags := resizeInstance(ctx, d, meta,
+		parseStorageVolumes(d.Get("volumes").([]interface{})),
+		parseNetworkInterfaces(d.Get("interfaces").([]interface{})),
+	); diags.HasError() {
+		return diags
+	}
+
+	if d.HasChange("power_state") {
+		if diags := setInstancePowerState(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
 	// Successfully updated resource, now set id
 	d.SetId(int64ToString(instance.ID))
 	return resourceVsphereInstanceRead(ctx, d, meta)
 }
 
 func resourceVsphereInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -775,9 +811,9 @@ func resourceVsphereInstanceDelete(ctx context.Context, d *schema.ResourceData,
 	// "name must be unique" we will GET the VM instance until such time as the instance
 	// isn't available
 	stateConf := retry.StateChangeConf{
-		Delay:        1 * time.Second,
-		Timeout:      30 * time.Second,
-		PollInterval: 1 * time.Second,
+		Delay:        deletePollInterval,
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		PollInterval: deletePollInterval,
 		MinTimeout:   1 * time.Second,
 		Pending:      []string{"200"},
 		Target:       []string{"404"},
@@ -802,73 +838,3 @@ func resourceVsphereInstanceDelete(ctx context.Context, d *schema.ResourceData,
 	d.SetId("")
 	return diags
 }
-
-func parseNetworkInterfaces(interfaces []interface{}) []map[string]interface{} {
-	var networkInterfaces []map[string]interface{}
-	for i := 0; i < len(interfaces); i++ {
-		row := make(map[string]interface{})
-		item := (interfaces)[i].(map[string]interface{})
-		if item["network_id"] != nil {
-			if item["network_group"].(bool) {
-				row["network"] = map[string]interface{}{
-					"id": fmt.Sprintf("networkGroup-%d", item["network_id"].(int)),
-				}
-			} else {
-				row["network"] = map[string]interface{}{
-					"id": fmt.Sprintf("network-%d", item["network_id"].(int)),
-				}
-			}
-		}
-		if item["ip_address"] != nil {
-			row["ipAddress"] = item["ip_address"] //.(string)
-		}
-		if item["ip_mode"] != nil {
-			row["ipMode"] = item["ip_mode"] // .(string)
-		}
-		if item["network_interface_type_id"] != nil {
-			row["networkInterfaceTypeId"] = item["network_interface_type_id"] //.(int)
-		}
-		networkInterfaces = append(networkInterfaces, row)
-	}
-	return networkInterfaces
-}
-
-func parseStorageVolumes(volumes []interface{}) []map[string]interface{} {
-	var storageVolumes []map[string]interface{}
-	for i := 0; i < len(volumes); i++ {
-		row := make(map[string]interface{})
-		item := (volumes)[i].(map[string]interface{})
-		if item["id"] != nil {
-			row["id"] = item["id"]
-		}
-		if item["root"] != nil {
-			row["rootVolume"] = item["root"]
-		}
-		if item["name"] != nil {
-			row["name"] = item["name"] // .(string)
-		}
-		// Check for non-zero value of size
-		if item["size"] != nil && item["size"].(int) != 0 {
-			row["size"] = item["size"] // .(int)
-		}
-		// Check for non-zero value of size_id
-		if item["size_id"] != nil && item["size_id"].(int) != 0 {
-			row["sizeId"] = item["size_id"] // .(int)
-		}
-		// Check for non-zero value of storage_type
-		if item["storage_type"] != nil && item["storage_type"].(int) != 0 {
-			row["storageType"] = item["storage_type"] // .(int)
-		}
-		// Check for non-zero value of datastore_id
-		if item["datastore_id"] != nil && item["datastore_id"].(int) != 0 {
-			row["datastoreId"] = item["datastore_id"] // .(int)
-		}
-		// If "auto" or "autoCluster" have been specified set the datastoreId to the value
-		// Our CustomizeDiff function will ensure that only one of these is set
-		if item["datastore_auto_selection"] != nil && item["datastore_auto_selection"].(string) != "" {
-			row["datastoreId"] = item["datastore_auto_selection"] // .(string)
-		}
-		storageVolumes = append(storageVolumes, row)
-	}
-	return storageVolumes // .([]map[string]interface{})
-}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gomorpheus/morpheus-go-sdk"
@@ -18,7 +19,9 @@ import (
 
 const (
 	minimumMKSWorkerNodes = 3
-	pollIntervalSeconds   = 10
+	// clusterFailureGracePeriod is how long a cluster may report a failed
+	// status during provisioning before the failure is treated as final
+	clusterFailureGracePeriod = 3 * time.Minute
 
 	statusCancelled      = "cancelled"
 	statusDenied         = "denied"
@@ -32,11 +35,15 @@ const (
 	statusProvisioned    = "provisioned"
 	statusRemoved        = "removed"
 	statusRemoving       = "removing"
+	statusResizing       = "resizing"
 	statusRunning        = "running"
 	statusStarting       = "starting"
+	statusStopped        = "stopped"
 	statusStopping       = "stopping"
 	statusSuspended      = "suspended"
+	statusSuspending     = "suspending"
 	statusSyncing        = "syncing"
+	statusUpgrading      = "upgrading"
 	statusWarning        = "warning"
 )
 
@@ -78,9 +85,11 @@ func resourceVsphereMKSCluster() *schema.Resource {
 				Computed:    true,
 			},
 			"kubernetes_version": {
-				Description: "The Kubernetes version of the cluster",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Description:      "The Kubernetes version of the cluster, changing the version upgrades the cluster in place",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressKubernetesVersionDiff,
 			},
 			"name": {
 				Description: "The name of the cluster",
@@ -121,9 +130,8 @@ func resourceVsphereMKSCluster() *schema.Resource {
 				Required:    true,
 			},
 			"cluster_layout_id": {
-				Description: "The ID of the cluster layout to provision the cluster from",
+				Description: "The ID of the cluster layout to provision the cluster from, changing the layout upgrades the cluster in place to the Kubernetes version of the new layout",
 				Type:        schema.TypeInt,
-				ForceNew:    true,
 				Required:    true,
 			},
 			"api_proxy_id": {
@@ -178,15 +186,13 @@ func resourceVsphereMKSCluster() *schema.Resource {
 			"master_node_pool": {
 				Type:        schema.TypeList,
 				Description: "Master node pool configuration",
-				ForceNew:    true,
 				Optional:    true,
 				MaxItems:    1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"plan_id": {
-							Description: "The ID of the service plan associated with the master nodes in the cluster",
+							Description: "The ID of the service plan associated with the master nodes in the cluster, changing the plan resizes the master nodes one at a time",
 							Type:        schema.TypeInt,
-							ForceNew:    true,
 							Required:    true,
 						},
 						"resource_pool_id": {
@@ -267,7 +273,7 @@ func resourceVsphereMKSCluster() *schema.Resource {
 						"tags": {
 							Description: "Tags to assign to the cluster master nodes",
 							Type:        schema.TypeMap,
-							ForceNew:    false,
+							ForceNew:    true,
 							Optional:    true,
 							Computed:    true,
 							Elem:        &schema.Schema{Type: schema.TypeString},
@@ -292,9 +298,8 @@ func resourceVsphereMKSCluster() *schema.Resource {
 							ValidateDiagFunc: validateCountDiagFunc,
 						},
 						"plan_id": {
-							Description: "The ID of the service plan associated with the worker nodes in the cluster",
+							Description: "The ID of the service plan associated with the worker nodes in the cluster, changing the plan replaces the worker nodes one at a time by adding a node with the new plan before removing a node with the old plan",
 							Type:        schema.TypeInt,
-							ForceNew:    true,
 							Required:    true,
 						},
 						"resource_pool_id": {
@@ -305,7 +310,7 @@ func resourceVsphereMKSCluster() *schema.Resource {
 							Computed:    true,
 						},
 						"tags": {
-							Description: "Tags to assign to the cluster worker nodes",
+							Description: "Tags to assign to the cluster worker nodes, changing the tags updates the tags of the existing worker nodes in place",
 							Type:        schema.TypeMap,
 							ForceNew:    false,
 							Optional:    true,
@@ -383,6 +388,7 @@ func resourceVsphereMKSCluster() *schema.Resource {
 					},
 				},
 			},
+			"on_create_failure": onCreateFailureSchema(),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -435,7 +441,7 @@ func filterOutClusterWorkersByStatus(workers []morpheus.ClusterWorker, status st
 }
 
 func resourceVsphereMKSClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -537,13 +543,63 @@ func resourceVsphereMKSClusterCreate(ctx context.Context, d *schema.ResourceData
 	log.Printf("API RESPONSE: %s", resp)
 	result := resp.Result.(*morpheus.CreateClusterResult)
 	cluster := result.Cluster
+	clusterStatus, err := waitForClusterProvisioning(ctx, meta, cluster.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.Errorf("error creating cluster: %s", err)
+	}
+
+	// Successfully created resource, now set id
+	d.SetId(int64ToString(cluster.ID))
+
+	// Fail the cluster deployment if the cluster status is in a failed state
+	if isProvisioningFailure(clusterStatus) {
+		return handleProvisioningFailure(ctx, d, meta, "cluster", clusterStatus, resourceVsphereMKSClusterDelete)
+	}
+
+	if upgradeDiags := upgradeNewCluster(ctx, d, meta); upgradeDiags.HasError() {
+		return upgradeDiags
+	}
+	resourceVsphereMKSClusterRead(ctx, d, meta)
+	return diags
+}
+
+// upgradeNewCluster upgrades a newly provisioned cluster when the configured
+// kubernetes_version differs from the version of the cluster layout
+func upgradeNewCluster(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	clusterId := toInt64(d.Id())
+
+	version := d.Get("kubernetes_version").(string)
+	if version == "" {
+		return nil
+	}
+	clusterDetails, err := client.GetCluster(clusterId, &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", clusterDetails, err)
+		return diag.FromErr(err)
+	}
+	if !kubernetesVersionMatches(version, clusterDetails.Result.(*morpheus.GetClusterResult).Cluster.ServiceVersion) {
+		err := doClusterUpgrade(ctx, meta, clusterId, version, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.Errorf("error upgrading cluster to %s: %s", version, err)
+		}
+	}
+	return nil
+}
+
+// waitForClusterProvisioning waits for a new cluster to finish provisioning
+// and returns the status it settled on
+func waitForClusterProvisioning(ctx context.Context, meta interface{}, clusterId int64, timeout time.Duration) (string, error) {
+	client := meta.(*providerMeta).client
 	clusterStatus := statusProvisioning
+	var failedSince time.Time
+	pollConfig := meta.(*providerMeta).config
 
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{statusProvisioning, statusStarting, statusStopping, statusPending, statusSyncing},
 		Target:  []string{statusRunning, statusFailed, statusWarning, statusDenied, statusCancelled, statusSuspended, statusOk},
 		Refresh: func() (interface{}, string, error) {
-			clusterDetails, err := client.GetCluster(cluster.ID, &morpheus.Request{})
+			clusterDetails, err := client.GetCluster(clusterId, &morpheus.Request{})
 			if err != nil {
 				return "", "", err
 			}
@@ -558,7 +614,7 @@ func resourceVsphereMKSClusterCreate(ctx context.Context, d *schema.ResourceData
 					},
 				})
 				if err != nil {
-					log.Printf("API FAILURE: %s - %s", resp, err)
+					log.Printf("API FAILURE: %s - %s", hostsDetails, err)
 				}
 				hostsResults := hostsDetails.Result.(*morpheus.ListHostsResult)
 				for _, host := range *hostsResults.Hosts {
@@ -570,41 +626,35 @@ func resourceVsphereMKSClusterCreate(ctx context.Context, d *schema.ResourceData
 					}
 				}
 			}
-			// Added an arbitrary wait period for cluster refresh.
-			// This should probably trigger a cluster refresh and then poll
-			// the cluster to reach a definitive state.
+			// The cluster can briefly report a failed status while it is
+			// being refreshed, so keep polling for a grace period before
+			// treating the failure as final.
 			if clusterStatus == statusFailed {
-				time.Sleep(3 * time.Minute)
-				clusterStatus = statusOk
+				if failedSince.IsZero() {
+					failedSince = time.Now()
+				}
+				if time.Since(failedSince) < clusterFailureGracePeriod {
+					clusterStatus = statusProvisioning
+				}
+			} else {
+				failedSince = time.Time{}
 			}
 
 			return result, clusterStatus, nil
 		},
-		Timeout:      3 * time.Hour,
+		Timeout:      timeout,
 		MinTimeout:   1 * time.Minute,
-		Delay:        3 * time.Minute,
-		PollInterval: 1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("error creating cluster: %s", err)
-	}
-
-	// Successfully created resource, now set id
-	d.SetId(int64ToString(cluster.ID))
-	resourceVsphereMKSClusterRead(ctx, d, meta)
-
-	// Fail the cluster deployment if the cluster status is in a failed state
-	if clusterStatus == statusFailed {
-		return diag.Errorf("error creating cluster: failed to create cluster")
-	}
-	return diags
+	_, err := stateConf.WaitForStateContext(ctx)
+	return clusterStatus, err
 }
 
 func resourceVsphereMKSClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -656,6 +706,18 @@ func resourceVsphereMKSClusterRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 	workers = filterOutClusterWorkersByStatus(workers, statusDeprovisioning)
+	d.Set("worker_node_pool", flattenClusterWorkerNodePool(workers))
+
+	return diags
+}
+
+// flattenClusterWorkerNodePool builds the worker_node_pool block from the
+// worker nodes of a cluster, the first worker node is used for the settings
+// shared by the pool
+func flattenClusterWorkerNodePool(workers []morpheus.ClusterWorker) []interface{} {
+	if len(workers) == 0 {
+		return nil
+	}
 	worker := workers[0]
 
 	tags := make(map[string]interface{}, len(worker.Tags))
@@ -685,7 +747,7 @@ func resourceVsphereMKSClusterRead(ctx context.Context, d *schema.ResourceData,
 		networks = append(networks, network)
 	}
 
-	workerNodePool := []interface{}{
+	return []interface{}{
 		map[string]interface{}{
 			"count":             len(workers),
 			"plan_id":           worker.Plan.ID,
@@ -695,13 +757,10 @@ func resourceVsphereMKSClusterRead(ctx context.Context, d *schema.ResourceData,
 			"network_interface": networks,
 		},
 	}
-
-	d.Set("worker_node_pool", workerNodePool)
-
-	return diags
 }
 
-func doClusterWorkerAdd(ctx context.Context, client *morpheus.Client, clusterId int64, nodeCount int, d *schema.ResourceData) error {
+func doClusterWorkerAdd(ctx context.Context, meta interface{}, clusterId int64, nodeCount int, d *schema.ResourceData, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
 	workerpool := d.Get("worker_node_pool").([]interface{})[0].(map[string]interface{})
 
 	workers, err := getClusterWorkers(client, clusterId)
@@ -753,6 +812,7 @@ func doClusterWorkerAdd(ctx context.Context, client *morpheus.Client, clusterId
 		return err
 	}
 
+	pollConfig := meta.(*providerMeta).config
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{statusProvisioning},
 		Target:  []string{statusProvisioned},
@@ -776,10 +836,10 @@ func doClusterWorkerAdd(ctx context.Context, client *morpheus.Client, clusterId
 
 			return "", statusProvisioning, nil
 		},
-		Timeout:      30 * time.Minute,
+		Timeout:      timeout,
 		MinTimeout:   1 * time.Minute,
-		Delay:        1 * time.Minute,
-		PollInterval: pollIntervalSeconds * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
@@ -791,14 +851,21 @@ func doClusterWorkerAdd(ctx context.Context, client *morpheus.Client, clusterId
 	return nil
 }
 
-func doClusterWorkerDelete(ctx context.Context, client *morpheus.Client, clusterId int64, nodeCount int) error {
+func doClusterWorkerDelete(ctx context.Context, meta interface{}, clusterId int64, nodeCount int, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
 	workers, err := getClusterWorkers(client, clusterId)
 	if err != nil {
 		return err
 	}
 	workers = filterOutClusterWorkersByStatus(workers, statusDeprovisioning)
 
-	deleteWorkers := workers[len(workers)+nodeCount:]
+	return deleteClusterWorkers(ctx, meta, clusterId, workers[len(workers)+nodeCount:], timeout)
+}
+
+// deleteClusterWorkers removes the given worker nodes from the cluster and
+// waits for them to be deprovisioned
+func deleteClusterWorkers(ctx context.Context, meta interface{}, clusterId int64, deleteWorkers []morpheus.ClusterWorker, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
 	for _, worker := range deleteWorkers {
 		resp, err := client.DeleteClusterWorker(clusterId, worker.ID, &morpheus.Request{})
 		if err != nil {
@@ -808,6 +875,7 @@ func doClusterWorkerDelete(ctx context.Context, client *morpheus.Client, cluster
 		}
 	}
 
+	pollConfig := meta.(*providerMeta).config
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{statusDeprovisioning},
 		Target:  []string{statusDeprovisioned},
@@ -826,10 +894,234 @@ func doClusterWorkerDelete(ctx context.Context, client *morpheus.Client, cluster
 
 			return "", statusDeprovisioning, nil
 		},
-		Timeout:      30 * time.Minute,
+		Timeout:      timeout,
 		MinTimeout:   1 * time.Minute,
-		Delay:        1 * time.Minute,
-		PollInterval: pollIntervalSeconds * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+
+	// Wait, catching any errors
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// doClusterWorkerReplace replaces every worker node that does not use the
+// plan of the worker node pool. Nodes are replaced one at a time, adding a
+// node with the new plan before removing a node with the old plan, so the
+// capacity of the cluster never drops below the configured count.
+func doClusterWorkerReplace(ctx context.Context, meta interface{}, clusterId int64, d *schema.ResourceData, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
+	workerpool := d.Get("worker_node_pool").([]interface{})[0].(map[string]interface{})
+	planId := int64(workerpool["plan_id"].(int))
+
+	workers, err := getClusterWorkers(client, clusterId)
+	if err != nil {
+		return err
+	}
+	workers = filterOutClusterWorkersByStatus(workers, statusDeprovisioning)
+
+	for _, worker := range workers {
+		if worker.Plan.ID == planId {
+			continue
+		}
+		log.Printf("Replacing cluster worker node %d using plan %d with a node using plan %d", worker.ID, worker.Plan.ID, planId)
+
+		if err := doClusterWorkerAdd(ctx, meta, clusterId, 1, d, timeout); err != nil {
+			return err
+		}
+		if err := deleteClusterWorkers(ctx, meta, clusterId, []morpheus.ClusterWorker{worker}, timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doClusterMasterResize resizes every master node that does not use the
+// given plan, one node at a time so the control plane stays available
+func doClusterMasterResize(ctx context.Context, meta interface{}, clusterId int64, planId int64, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "GET",
+		Path:   fmt.Sprintf("/api/clusters/%d/masters", clusterId),
+		Result: &ClusterMastersResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE - Error in listing cluster master nodes: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+	masters := resp.Result.(*ClusterMastersResult).Masters
+
+	pollConfig := meta.(*providerMeta).config
+	for _, master := range masters {
+		if master.Plan.ID == planId {
+			continue
+		}
+		log.Printf("Resizing cluster master node %d from plan %d to plan %d", master.ID, master.Plan.ID, planId)
+
+		resp, err := client.Execute(&morpheus.Request{
+			Method: "PUT",
+			Path:   fmt.Sprintf("/api/servers/%d/resize", master.ID),
+			Body: map[string]interface{}{
+				"server": map[string]interface{}{
+					"id": master.ID,
+					"plan": map[string]interface{}{
+						"id": planId,
+					},
+				},
+				"deleteOriginalVolumes": false,
+			},
+			Result: &morpheus.StandardResult{},
+		})
+		if err != nil {
+			log.Printf("API FAILURE - Error in resizing cluster master node: %s - %s", resp, err)
+			return err
+		}
+		log.Printf("API RESPONSE: %s", resp)
+
+		masterId := master.ID
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{statusResizing, statusPending},
+			Target:  []string{statusProvisioned, statusRunning},
+			Refresh: func() (interface{}, string, error) {
+				log.Printf("Waiting for cluster master node %d to be resized...", masterId)
+
+				resp, err := client.Execute(&morpheus.Request{
+					Method: "GET",
+					Path:   fmt.Sprintf("/api/servers/%d", masterId),
+					Result: &ClusterNodeResult{},
+				})
+				if err != nil {
+					return "", "", err
+				}
+				server := resp.Result.(*ClusterNodeResult).Server
+				return server, server.Status, nil
+			},
+			Timeout:      timeout,
+			MinTimeout:   1 * time.Minute,
+			Delay:        pollConfig.PollDelay,
+			PollInterval: pollConfig.PollInterval,
+		}
+
+		// Wait, catching any errors
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doClusterWorkerTagsUpdate replaces the tags of every worker node of the
+// cluster with the tags of the worker node pool
+func doClusterWorkerTagsUpdate(meta interface{}, clusterId int64, tags map[string]interface{}) error {
+	client := meta.(*providerMeta).client
+	workers, err := getClusterWorkers(client, clusterId)
+	if err != nil {
+		return err
+	}
+	workers = filterOutClusterWorkersByStatus(workers, statusDeprovisioning)
+
+	// An empty list removes every tag of the worker node
+	serverTags := parseTags(tags)
+	if serverTags == nil {
+		serverTags = []map[string]interface{}{}
+	}
+	for _, worker := range workers {
+		resp, err := client.Execute(&morpheus.Request{
+			Method: "PUT",
+			Path:   fmt.Sprintf("/api/servers/%d", worker.ID),
+			Body: map[string]interface{}{
+				"server": map[string]interface{}{
+					"tags": serverTags,
+				},
+			},
+			Result: &morpheus.StandardResult{},
+		})
+		if err != nil {
+			log.Printf("API FAILURE - Error in updating cluster worker node tags: %s - %s", resp, err)
+			return err
+		}
+		log.Printf("API RESPONSE: %s", resp)
+	}
+
+	return nil
+}
+
+// clusterUpgradeStartTimeout is how long the cluster may take to report an
+// upgrade before the upgrade is treated as not started
+var clusterUpgradeStartTimeout = 5 * time.Minute
+
+// doClusterUpgrade upgrades the Kubernetes version of the cluster and waits
+// for the cluster to finish syncing
+func doClusterUpgrade(ctx context.Context, meta interface{}, clusterId int64, targetVersion string, timeout time.Duration) error {
+	client := meta.(*providerMeta).client
+	resp, err := client.Execute(&morpheus.Request{
+		Method: "PUT",
+		Path:   fmt.Sprintf("/api/clusters/%d/upgrade-cluster", clusterId),
+		Body: map[string]interface{}{
+			"targetVersion": targetVersion,
+		},
+		Result: &morpheus.StandardResult{},
+	})
+	if err != nil {
+		log.Printf("API FAILURE - Error in upgrading cluster: %s - %s", resp, err)
+		return err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var cluster *morpheus.Cluster
+	refresh := func() (interface{}, string, error) {
+		log.Printf("Waiting for cluster to be upgraded to %s...", targetVersion)
+
+		clusterDetails, err := client.GetCluster(clusterId, &morpheus.Request{})
+		if err != nil {
+			return "", "", err
+		}
+		result := clusterDetails.Result.(*morpheus.GetClusterResult)
+		cluster = result.Cluster
+		return result, cluster.Status, nil
+	}
+
+	// The upgrade is carried out by a background process and the cluster
+	// keeps its ok status until the upgrade starts, so wait for the status
+	// or the version to change before waiting for the upgrade to finish
+	pollConfig := meta.(*providerMeta).config
+	startConf := &resource.StateChangeConf{
+		Pending: []string{statusOk, statusRunning},
+		Target:  []string{statusUpgrading, statusSyncing, statusProvisioning, statusPending, statusFailed, statusWarning, "upgraded"},
+		Refresh: func() (interface{}, string, error) {
+			result, status, err := refresh()
+			if err == nil && kubernetesVersionMatches(targetVersion, cluster.ServiceVersion) {
+				return result, "upgraded", nil
+			}
+			return result, status, err
+		},
+		Timeout:      clusterUpgradeStartTimeout,
+		MinTimeout:   10 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
+	}
+	if _, err := startConf.WaitForStateContext(ctx); err != nil {
+		if _, ok := err.(*resource.TimeoutError); ok {
+			return fmt.Errorf("the cluster did not start upgrading within %s", clusterUpgradeStartTimeout)
+		}
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{statusUpgrading, statusSyncing, statusProvisioning, statusPending},
+		Target:       []string{statusOk, statusRunning, statusFailed, statusWarning},
+		Refresh:      refresh,
+		Timeout:      timeout,
+		MinTimeout:   1 * time.Minute,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
@@ -837,15 +1129,105 @@ func doClusterWorkerDelete(ctx context.Context, client *morpheus.Client, cluster
 	if err != nil {
 		return err
 	}
+	if cluster.Status != statusOk && cluster.Status != statusRunning {
+		return fmt.Errorf("the cluster is in a %s state", cluster.Status)
+	}
+	if !kubernetesVersionMatches(targetVersion, cluster.ServiceVersion) {
+		return fmt.Errorf("the cluster reports version %s after the upgrade", cluster.ServiceVersion)
+	}
 
 	return nil
 }
 
+// normalizeKubernetesVersion strips the v prefix and any wildcard patch
+// segment so that versions such as v1.28, 1.28.x and 1.28 compare equal
+func normalizeKubernetesVersion(version string) string {
+	version = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+	for strings.HasSuffix(version, ".x") || strings.HasSuffix(version, ".*") {
+		version = strings.TrimSuffix(strings.TrimSuffix(version, ".x"), ".*")
+	}
+	return version
+}
+
+// kubernetesVersionMatches reports whether two Kubernetes versions are the
+// same once normalized, a version without a patch release such as 1.28
+// matches any 1.28 patch release
+func kubernetesVersionMatches(version string, otherVersion string) bool {
+	version = normalizeKubernetesVersion(version)
+	otherVersion = normalizeKubernetesVersion(otherVersion)
+	return version == otherVersion || strings.HasPrefix(otherVersion, version+".") || strings.HasPrefix(version, otherVersion+".")
+}
+
+// suppressKubernetesVersionDiff ignores a configured kubernetes_version that
+// is written differently from the version reported by the cluster
+func suppressKubernetesVersionDiff(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && new != "" && kubernetesVersionMatches(new, old)
+}
+
+// clusterUpgradeVersion returns the Kubernetes version that the cluster
+// should be upgraded to, which is either the version set in the
+// configuration or the version of a newly selected cluster layout
+func clusterUpgradeVersion(client *morpheus.Client, d *schema.ResourceData) (string, error) {
+	if d.HasChange("kubernetes_version") {
+		if version := d.Get("kubernetes_version").(string); version != "" {
+			return version, nil
+		}
+	}
+	if !d.HasChange("cluster_layout_id") {
+		return "", nil
+	}
+
+	resp, err := client.GetClusterLayout(int64(d.Get("cluster_layout_id").(int)), &morpheus.Request{})
+	if err != nil {
+		log.Printf("API FAILURE: %s - %s", resp, err)
+		return "", err
+	}
+	log.Printf("API RESPONSE: %s", resp)
+
+	var clusterLayout ClusterLayoutPayload
+	if err := json.Unmarshal(resp.Body, &clusterLayout); err != nil {
+		return "", err
+	}
+	return clusterLayout.ClusterLayout.ComputeVersion, nil
+}
+
 func resourceVsphereMKSClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	if diags := updateCluster(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	return resourceVsphereMKSClusterRead(ctx, d, meta)
+}
+
+// updateCluster applies the changes shared by the cluster resources, which
+// are upgrades, node pool plan and count changes, the name and the description
+func updateCluster(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
 	clusterId := toInt64(d.Id())
 
-	// First check for changes in worker node pool
+	// Upgrade the cluster before changing any nodes so that new worker
+	// nodes are added with the upgraded version
+	if d.HasChanges("kubernetes_version", "cluster_layout_id") {
+		targetVersion, err := clusterUpgradeVersion(client, d)
+		if err != nil {
+			return diag.Errorf("error upgrading cluster: %s", err)
+		}
+		if targetVersion != "" {
+			err := doClusterUpgrade(ctx, meta, clusterId, targetVersion, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.Errorf("error upgrading cluster to %s: %s", targetVersion, err)
+			}
+		}
+	}
+
+	if d.HasChange("master_node_pool.0.plan_id") {
+		planId := int64(d.Get("master_node_pool.0.plan_id").(int))
+		err := doClusterMasterResize(ctx, meta, clusterId, planId, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.Errorf("error resizing cluster master node(s): %s", err)
+		}
+	}
+
+	// Then check for changes in worker node pool
 	if d.HasChange("worker_node_pool") {
 		o, n := d.GetChange("worker_node_pool")
 		oldValues, ok := o.([]interface{})[0].(map[string]interface{})
@@ -872,17 +1254,34 @@ func resourceVsphereMKSClusterUpdate(ctx context.Context, d *schema.ResourceData
 			countDelta := newCount - oldCount
 
 			if countDelta > 0 {
-				err := doClusterWorkerAdd(ctx, client, clusterId, countDelta, d)
+				err := doClusterWorkerAdd(ctx, meta, clusterId, countDelta, d, d.Timeout(schema.TimeoutUpdate))
 				if err != nil {
 					return diag.Errorf("error adding cluster worker node(s): %s", err)
 				}
 			} else {
-				err := doClusterWorkerDelete(ctx, client, clusterId, countDelta)
+				err := doClusterWorkerDelete(ctx, meta, clusterId, countDelta, d.Timeout(schema.TimeoutUpdate))
 				if err != nil {
 					return diag.Errorf("error deleting cluster worker node(s): %s", err)
 				}
 			}
 		}
+
+		if oldValues["plan_id"] != newValues["plan_id"] {
+			err := doClusterWorkerReplace(ctx, meta, clusterId, d, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.Errorf("error replacing cluster worker node(s): %s", err)
+			}
+		}
+
+		// Worker nodes added above already have the new tags, this updates
+		// the worker nodes that were kept
+		if d.HasChange("worker_node_pool.0.tags") {
+			tags, _ := newValues["tags"].(map[string]interface{})
+			err := doClusterWorkerTagsUpdate(meta, clusterId, tags)
+			if err != nil {
+				return diag.Errorf("error updating cluster worker node tags: %s", err)
+			}
+		}
 	}
 
 	clusterPayload := map[string]interface{}{}
@@ -908,11 +1307,11 @@ func resourceVsphereMKSClusterUpdate(ctx context.Context, d *schema.ResourceData
 		log.Printf("API RESPONSE: %s", resp)
 	}
 
-	return resourceVsphereMKSClusterRead(ctx, d, meta)
+	return nil
 }
 
 func resourceVsphereMKSClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -939,6 +1338,7 @@ func resourceVsphereMKSClusterDelete(ctx context.Context, d *schema.ResourceData
 	}
 	log.Printf("API RESPONSE: %s", resp)
 
+	pollConfig := meta.(*providerMeta).config
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{statusRemoving, statusPendingRemoval, statusStopping, statusPending, statusWarning, statusDeprovisioning},
 		Target:  []string{statusRemoved},
@@ -954,10 +1354,10 @@ func resourceVsphereMKSClusterDelete(ctx context.Context, d *schema.ResourceData
 			cluster := result.Cluster
 			return result, cluster.Status, nil
 		},
-		Timeout:      30 * time.Minute,
+		Timeout:      d.Timeout(schema.TimeoutDelete),
 		MinTimeout:   1 * time.Minute,
-		Delay:        1 * time.Minute,
-		PollInterval: 30 * time.Second,
+		Delay:        pollConfig.PollDelay,
+		PollInterval: pollConfig.PollInterval,
 	}
 
 	// Wait, catching any errors
@@ -1037,3 +1437,21 @@ func parseTags(variables map[string]interface{}) []map[string]interface{} {
 	}
 	return tags
 }
+
+type ClusterMastersResult struct {
+	Masters []ClusterNode `json:"masters"`
+}
+
+type ClusterNodeResult struct {
+	Server ClusterNode `json:"server"`
+}
+
+type ClusterNode struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Plan   struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"plan"`
+}
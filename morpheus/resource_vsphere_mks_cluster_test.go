@@ -0,0 +1,107 @@
+package morpheus
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKubernetesVersionMatches(t *testing.T) {
+	cases := []struct {
+		version      string
+		otherVersion string
+		expected     bool
+	}{
+		{"1.28.3", "1.28.3", true},
+		{"v1.28.3", "1.28.3", true},
+		{"v1.28", "1.28.3", true},
+		{"1.28.x", "1.28.3", true},
+		{"1.28.3", "1.28.x", true},
+		{"1.28", "1.29.1", false},
+		{"1.2", "1.28.3", false},
+		{"1.28.3", "1.28.4", false},
+	}
+	for _, c := range cases {
+		if matches := kubernetesVersionMatches(c.version, c.otherVersion); matches != c.expected {
+			t.Errorf("kubernetesVersionMatches(%q, %q): expected %t, got %t", c.version, c.otherVersion, c.expected, matches)
+		}
+	}
+}
+
+// testClusterUpgradeAPI emulates an upgrade that is reported by the cluster
+// after the given number of polls and completes after as many polls again,
+// it returns the number of polls since the upgrade was requested
+func testClusterUpgradeAPI(api *fakeMorpheus, clusterId int64, startAfter int, version string) func() int {
+	clusterPath := "/api/clusters/" + int64ToString(clusterId)
+	var mu sync.Mutex
+	upgradeRequested := false
+	polls := 0
+	api.Handle(http.MethodPut, clusterPath+"/upgrade-cluster", func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		upgradeRequested = true
+		return http.StatusOK, map[string]interface{}{"success": true}
+	})
+	api.Handle(http.MethodGet, clusterPath, func(s *fakeStore, body map[string]interface{}) (int, map[string]interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		if upgradeRequested && startAfter >= 0 {
+			polls++
+			switch {
+			case polls == startAfter:
+				s.Update("/api/clusters", clusterId, map[string]interface{}{"status": statusUpgrading})
+			case polls == 2*startAfter:
+				s.Update("/api/clusters", clusterId, map[string]interface{}{"status": statusOk, "serviceVersion": version})
+			}
+		}
+		cluster, _ := s.Get("/api/clusters", clusterId)
+		return http.StatusOK, map[string]interface{}{"cluster": cluster}
+	})
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return polls
+	}
+}
+
+func TestDoClusterUpgrade(t *testing.T) {
+	api := newFakeMorpheus(t)
+	meta := api.Meta()
+	clusterId := api.Seed("/api/clusters", map[string]interface{}{
+		"name":           "tfcluster",
+		"status":         statusOk,
+		"serviceVersion": "1.28.3",
+	})
+	// The cluster reports its ok status for a few polls after the upgrade
+	// request, the wait must not return before the upgrade starts
+	polls := testClusterUpgradeAPI(api, clusterId, 3, "1.29.1")
+
+	if err := doClusterUpgrade(context.Background(), meta, clusterId, "v1.29", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if count := polls(); count < 6 {
+		t.Fatalf("expected the wait to last until the upgrade completed, the cluster was polled %d times", count)
+	}
+}
+
+func TestDoClusterUpgrade_notStarted(t *testing.T) {
+	clusterUpgradeStartTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { clusterUpgradeStartTimeout = 5 * time.Minute })
+
+	api := newFakeMorpheus(t)
+	meta := api.Meta()
+	clusterId := api.Seed("/api/clusters", map[string]interface{}{
+		"name":           "tfcluster",
+		"status":         statusOk,
+		"serviceVersion": "1.28.3",
+	})
+	testClusterUpgradeAPI(api, clusterId, -1, "")
+
+	err := doClusterUpgrade(context.Background(), meta, clusterId, "1.29.1", time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "did not start upgrading") {
+		t.Fatalf("expected an error for an upgrade that never started, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package morpheus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMorpheusWikiPage_basic(t *testing.T) {
+	api := newFakeMorpheus(t)
+	resourceName := "morpheus_wiki_page.tf_example_wiki_page"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFakeCollectionEmpty(api, "/api/wiki/pages"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testAccMorpheusWikiPageConfig("Terraform managed wiki page"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tfwikidemo"),
+					resource.TestCheckResourceAttr(resourceName, "category", "terraform"),
+					resource.TestCheckResourceAttr(resourceName, "content", "Terraform managed wiki page"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testAccMorpheusWikiPageConfig("Updated wiki page"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "content", "Updated wiki page"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMorpheusWikiPageConfig(content string) string {
+	return fmt.Sprintf(`
+resource "morpheus_wiki_page" "tf_example_wiki_page" {
+  name     = "tfwikidemo"
+  category = "terraform"
+  content  = %q
+}
+`, content)
+}
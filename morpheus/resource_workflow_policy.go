@@ -103,7 +103,7 @@ func resourceWorkflowPolicy() *schema.Resource {
 }
 
 func resourceWorkflowPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -174,7 +174,7 @@ func resourceWorkflowPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceWorkflowPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -245,7 +245,7 @@ func resourceWorkflowPolicyRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceWorkflowPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 
 	policy := make(map[string]interface{})
@@ -315,7 +315,7 @@ func resourceWorkflowPolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceWorkflowPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*morpheus.Client)
+	client := meta.(*providerMeta).client
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
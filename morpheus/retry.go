@@ -0,0 +1,184 @@
+package morpheus
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// retryTransport applies the retry and rate limit settings of the provider to
+// every request sent to the Morpheus API. A request rejected with HTTP 429
+// was never processed, so it is retried whatever its method. Gateway errors
+// and connection failures are only retried for GET and HEAD requests, as a
+// create or an action may have been carried out before the response was lost.
+type retryTransport struct {
+	transport    http.RoundTripper
+	limiter      *rateLimiter
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+}
+
+func newRetryTransport(transport http.RoundTripper, c *Config) *retryTransport {
+	return &retryTransport{
+		transport:    transport,
+		limiter:      newRateLimiter(c.MaxRequestsPerSecond),
+		maxRetries:   c.MaxRetries,
+		retryWaitMin: c.RetryWaitMin,
+		retryWaitMax: c.RetryWaitMax,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body is buffered so that it can be sent again on a retry
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		r := req.Clone(req.Context())
+		if body != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+		}
+		resp, err := t.transport.RoundTrip(r)
+		if attempt >= t.maxRetries || !retryableRequest(req.Method, resp, err) {
+			return resp, err
+		}
+
+		wait := t.retryWait(attempt, resp)
+		if err != nil {
+			log.Printf("[WARN] retrying Morpheus API request %s %s in %s (attempt %d of %d): %s", req.Method, req.URL.Path, wait, attempt+1, t.maxRetries, err)
+		} else {
+			log.Printf("[WARN] retrying Morpheus API request %s %s in %s (attempt %d of %d): HTTP %d", req.Method, req.URL.Path, wait, attempt+1, t.maxRetries, resp.StatusCode)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryableRequest reports whether a request can be sent again after it
+// failed with err or was answered with resp
+func retryableRequest(method string, resp *http.Response, err error) bool {
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+	if err != nil {
+		return err != context.Canceled && err != context.DeadlineExceeded
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryWait returns how long to wait before the next attempt, honoring the
+// Retry-After header of the response when it has one and otherwise backing
+// off exponentially between retryWaitMin and retryWaitMax
+func (t *retryTransport) retryWait(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait
+		}
+	}
+	wait := t.retryWaitMin
+	for i := 0; i < attempt && wait < t.retryWaitMax; i++ {
+		wait *= 2
+	}
+	if wait > t.retryWaitMax {
+		wait = t.retryWaitMax
+	}
+	return wait
+}
+
+// retryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// rateLimiter spaces out API requests so that no more than the configured
+// number are sent each second. A zero rate disables the limit.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond int) *rateLimiter {
+	limiter := &rateLimiter{}
+	if requestsPerSecond > 0 {
+		limiter.interval = time.Second / time.Duration(requestsPerSecond)
+	}
+	return limiter
+}
+
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.interval == 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
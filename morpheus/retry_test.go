@@ -0,0 +1,216 @@
+package morpheus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomorpheus/morpheus-go-sdk"
+)
+
+func TestRetryTransport_retryWait(t *testing.T) {
+	transport := &retryTransport{
+		retryWaitMin: 1 * time.Second,
+		retryWaitMax: 5 * time.Second,
+	}
+	for attempt, expected := range []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if wait := transport.retryWait(attempt, nil); wait != expected {
+			t.Errorf("attempt %d: expected a wait of %s, got %s", attempt, expected, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if wait := transport.retryWait(0, resp); wait != 7*time.Second {
+		t.Errorf("expected the Retry-After header to be honored, got %s", wait)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"120", 120 * time.Second, true},
+		{"-1", 0, false},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, c := range cases {
+		wait, ok := retryAfter(c.value, now)
+		if wait != c.expected || ok != c.ok {
+			t.Errorf("retryAfter(%q): expected %s, %t got %s, %t", c.value, c.expected, c.ok, wait, ok)
+		}
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := newRateLimiter(20)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	// The first request is sent immediately and the rest are spaced 50ms apart
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected 5 requests at 20 per second to take at least 200ms, took %s", elapsed)
+	}
+
+	if err := newRateLimiter(0).Wait(context.Background()); err != nil {
+		t.Fatalf("expected a zero rate to disable the limit: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter = newRateLimiter(1)
+	limiter.Wait(ctx)
+	if err := limiter.Wait(ctx); err != context.Canceled {
+		t.Fatalf("expected the wait to stop when the context is cancelled, got %v", err)
+	}
+}
+
+// testRetryServer answers each request with the next status in statuses and
+// records the request bodies it received
+type testRetryServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+}
+
+func newTestRetryServer(t *testing.T, statuses ...int) *testRetryServer {
+	s := &testRetryServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.bodies = append(s.bodies, string(body))
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		s.mu.Unlock()
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Server.Close)
+	return s
+}
+
+func (s *testRetryServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.bodies...)
+}
+
+func testRetryRequest(t *testing.T, server *testRetryServer, method string, body string) int {
+	t.Helper()
+	client := &http.Client{
+		Transport: &retryTransport{
+			transport:    http.DefaultTransport,
+			limiter:      newRateLimiter(0),
+			maxRetries:   2,
+			retryWaitMin: time.Millisecond,
+			retryWaitMax: 5 * time.Millisecond,
+		},
+	}
+	req, err := http.NewRequest(method, server.URL+"/api/instances", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unable to build the request: %s", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestRetryTransport_throttledPost(t *testing.T) {
+	server := newTestRetryServer(t, http.StatusTooManyRequests, http.StatusOK)
+
+	if status := testRetryRequest(t, server, http.MethodPost, `{"instance":{}}`); status != http.StatusOK {
+		t.Fatalf("expected the throttled request to be retried, got HTTP %d", status)
+	}
+	requests := server.requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if requests[1] != `{"instance":{}}` {
+		t.Fatalf("expected the request body to be sent again, got %q", requests[1])
+	}
+}
+
+func TestRetryTransport_unavailablePost(t *testing.T) {
+	server := newTestRetryServer(t, http.StatusServiceUnavailable, http.StatusOK)
+
+	if status := testRetryRequest(t, server, http.MethodPost, `{"instance":{}}`); status != http.StatusServiceUnavailable {
+		t.Fatalf("expected the POST not to be retried, got HTTP %d", status)
+	}
+	if count := len(server.requests()); count != 1 {
+		t.Fatalf("expected 1 request, got %d", count)
+	}
+}
+
+func TestRetryTransport_unavailableGet(t *testing.T) {
+	server := newTestRetryServer(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+
+	if status := testRetryRequest(t, server, http.MethodGet, ""); status != http.StatusOK {
+		t.Fatalf("expected the GET to be retried, got HTTP %d", status)
+	}
+	if count := len(server.requests()); count != 3 {
+		t.Fatalf("expected 3 requests, got %d", count)
+	}
+}
+
+func TestRetryTransport_maxRetries(t *testing.T) {
+	server := newTestRetryServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+
+	if status := testRetryRequest(t, server, http.MethodGet, ""); status != http.StatusServiceUnavailable {
+		t.Fatalf("expected the last failure to be returned, got HTTP %d", status)
+	}
+	if count := len(server.requests()); count != 3 {
+		t.Fatalf("expected 1 request and 2 retries, got %d requests", count)
+	}
+}
+
+func TestConfigClient_retriesThrottledRequests(t *testing.T) {
+	server := newTestRetryServer(t, http.StatusTooManyRequests, http.StatusOK)
+	config := &Config{
+		Url:          server.URL,
+		AccessToken:  fakeMorpheusAccessToken,
+		MaxRetries:   2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	}
+
+	client, diags := config.Client()
+	if diags.HasError() {
+		t.Fatalf("unable to create the client: %v", diags)
+	}
+	t.Cleanup(func() { config.Close() })
+	_, err := client.Execute(&morpheus.Request{
+		Method: "POST",
+		Path:   "/api/instances",
+		Body: map[string]interface{}{
+			"instance": map[string]interface{}{"name": "tfinstance"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected the throttled request to be retried: %s", err)
+	}
+	if count := len(server.requests()); count != 2 {
+		t.Fatalf("expected 2 requests, got %d", count)
+	}
+}
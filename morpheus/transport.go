@@ -0,0 +1,121 @@
+package morpheus
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// applianceProxy is a reverse proxy on the loopback interface that forwards
+// requests to the Morpheus appliance using the TLS and retry settings of the
+// provider.
+//
+// morpheus-go-sdk builds its own HTTP client and does not accept a transport,
+// so this is how the provider applies its TLS settings to the API requests.
+// As the transport presents the configured client certificate, the proxy
+// only forwards requests that carry its token, which is generated for each
+// proxy and never leaves the provider process. The SDK has no way to add a
+// header to every request, so the token is sent as the first segment of the
+// url path.
+type applianceProxy struct {
+	// Url is the url the client is created with in place of the appliance
+	// url
+	Url string
+
+	token  string
+	server *http.Server
+}
+
+// newApplianceProxy starts a proxy that forwards the requests it receives to
+// the appliance at applianceUrl using transport. The proxy runs until it is
+// closed.
+func newApplianceProxy(applianceUrl string, transport http.RoundTripper) (*applianceProxy, error) {
+	target, err := url.Parse(applianceUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %s", applianceUrl, err)
+	}
+	if target.Scheme == "" || target.Host == "" {
+		return nil, fmt.Errorf("invalid url %q: the url must include the scheme and host, such as https://morpheus.example.com", applianceUrl)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("unable to generate the Morpheus API proxy token: %s", err)
+	}
+	token := hex.EncodeToString(secret)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("unable to start the Morpheus API proxy: %s", err)
+	}
+
+	proxy := &httputil.ReverseProxy{
+		// The client url keeps the path of the appliance url, so only the
+		// scheme and host of the request are rewritten once the token has
+		// been removed from the path
+		Director: func(r *http.Request) {
+			r.URL.Scheme = target.Scheme
+			r.URL.Host = target.Host
+			r.Host = target.Host
+		},
+		Transport: transport,
+		// Connection and TLS errors are reported in the response body so
+		// that they surface in the error returned to the resource
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Printf("[ERROR] Morpheus API request %s %s failed: %s", r.Method, r.URL.Path, err)
+			writeProxyError(w, http.StatusBadGateway, fmt.Sprintf("unable to reach the Morpheus appliance: %s", err))
+		},
+	}
+
+	p := &applianceProxy{
+		Url:   fmt.Sprintf("http://%s/%s%s", listener.Addr().String(), token, strings.TrimRight(target.Path, "/")),
+		token: token,
+	}
+	p.server = &http.Server{
+		Handler:           p.authorize(proxy),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go p.server.Serve(listener)
+
+	return p, nil
+}
+
+// authorize rejects the requests that do not carry the token of the proxy
+// and removes the token from the path of the others
+func (p *applianceProxy) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(p.token)) != 1 {
+			writeProxyError(w, http.StatusForbidden, "the request was not sent by the Morpheus provider")
+			return
+		}
+		r.URL.Path = "/" + path
+		if r.URL.RawPath != "" {
+			r.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, "/"+p.token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Close stops the proxy and closes its listener
+func (p *applianceProxy) Close() error {
+	return p.server.Close()
+}
+
+func writeProxyError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"msg":     msg,
+	})
+}
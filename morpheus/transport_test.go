@@ -0,0 +1,110 @@
+package morpheus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestProxyAppliance starts an appliance that echoes the path of each
+// request and counts the requests it received
+func newTestProxyAppliance(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"path": r.URL.Path})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func testProxyGet(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	var body map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&body)
+	path, _ := body["path"].(string)
+	return resp.StatusCode, path
+}
+
+func TestApplianceProxy(t *testing.T) {
+	server, requests := newTestProxyAppliance(t)
+	proxy, err := newApplianceProxy(server.URL+"/morpheus/", http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { proxy.Close() })
+
+	if !strings.HasPrefix(proxy.Url, "http://127.0.0.1:") {
+		t.Fatalf("expected the proxy to listen on the loopback interface, got %s", proxy.Url)
+	}
+	status, path := testProxyGet(t, proxy.Url+"/api/whoami")
+	if status != http.StatusOK || path != "/morpheus/api/whoami" {
+		t.Fatalf("expected the request to be forwarded to /morpheus/api/whoami, got HTTP %d for %q", status, path)
+	}
+
+	// Requests without the token of the proxy are not forwarded
+	base := strings.TrimSuffix(proxy.Url, "/"+proxy.token+"/morpheus")
+	for _, url := range []string{
+		base + "/morpheus/api/whoami",
+		base + "/api/whoami",
+		base + "/" + strings.Repeat("0", len(proxy.token)) + "/morpheus/api/whoami",
+		base + "/" + proxy.token[:len(proxy.token)-1] + "/morpheus/api/whoami",
+	} {
+		if status, _ := testProxyGet(t, url); status != http.StatusForbidden {
+			t.Errorf("%s: expected HTTP 403, got %d", url, status)
+		}
+	}
+	if count := atomic.LoadInt32(requests); count != 1 {
+		t.Fatalf("expected only the request with the token to reach the appliance, got %d requests", count)
+	}
+}
+
+func TestApplianceProxy_token(t *testing.T) {
+	server, _ := newTestProxyAppliance(t)
+	first, err := newApplianceProxy(server.URL, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { first.Close() })
+	second, err := newApplianceProxy(server.URL, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { second.Close() })
+
+	if len(first.token) != 64 || first.token == second.token {
+		t.Fatalf("expected a random token for each proxy, got %q and %q", first.token, second.token)
+	}
+}
+
+func TestApplianceProxy_close(t *testing.T) {
+	server, _ := newTestProxyAppliance(t)
+	proxy, err := newApplianceProxy(server.URL, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := proxy.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := http.Get(proxy.Url + "/api/whoami"); err == nil {
+		t.Fatalf("expected the listener to be closed")
+	}
+}
+
+func TestNewApplianceProxy_invalidUrl(t *testing.T) {
+	for _, url := range []string{"morpheus.example.com", "://morpheus"} {
+		if _, err := newApplianceProxy(url, http.DefaultTransport); err == nil {
+			t.Errorf("%q: expected an error for the invalid url", url)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package morpheus
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 )
 
@@ -42,3 +43,73 @@ func parseEnvironmentVariables(variables []interface{}) []map[string]interface{}
 	}
 	return evars
 }
+
+func parseNetworkInterfaces(interfaces []interface{}) []map[string]interface{} {
+	var networkInterfaces []map[string]interface{}
+	for i := 0; i < len(interfaces); i++ {
+		row := make(map[string]interface{})
+		item := (interfaces)[i].(map[string]interface{})
+		if item["network_id"] != nil {
+			if item["network_group"].(bool) {
+				row["network"] = map[string]interface{}{
+					"id": fmt.Sprintf("networkGroup-%d", item["network_id"].(int)),
+				}
+			} else {
+				row["network"] = map[string]interface{}{
+					"id": fmt.Sprintf("network-%d", item["network_id"].(int)),
+				}
+			}
+		}
+		if item["ip_address"] != nil {
+			row["ipAddress"] = item["ip_address"] //.(string)
+		}
+		if item["ip_mode"] != nil {
+			row["ipMode"] = item["ip_mode"] // .(string)
+		}
+		if item["network_interface_type_id"] != nil {
+			row["networkInterfaceTypeId"] = item["network_interface_type_id"] //.(int)
+		}
+		networkInterfaces = append(networkInterfaces, row)
+	}
+	return networkInterfaces
+}
+
+func parseStorageVolumes(volumes []interface{}) []map[string]interface{} {
+	var storageVolumes []map[string]interface{}
+	for i := 0; i < len(volumes); i++ {
+		row := make(map[string]interface{})
+		item := (volumes)[i].(map[string]interface{})
+		if item["id"] != nil {
+			row["id"] = item["id"]
+		}
+		if item["root"] != nil {
+			row["rootVolume"] = item["root"]
+		}
+		if item["name"] != nil {
+			row["name"] = item["name"] // .(string)
+		}
+		// Check for non-zero value of size
+		if item["size"] != nil && item["size"].(int) != 0 {
+			row["size"] = item["size"] // .(int)
+		}
+		// Check for non-zero value of size_id
+		if item["size_id"] != nil && item["size_id"].(int) != 0 {
+			row["sizeId"] = item["size_id"] // .(int)
+		}
+		// Check for non-zero value of storage_type
+		if item["storage_type"] != nil && item["storage_type"].(int) != 0 {
+			row["storageType"] = item["storage_type"] // .(int)
+		}
+		// Check for non-zero value of datastore_id
+		if item["datastore_id"] != nil && item["datastore_id"].(int) != 0 {
+			row["datastoreId"] = item["datastore_id"] // .(int)
+		}
+		// If "auto" or "autoCluster" have been specified set the datastoreId to the value
+		// Our CustomizeDiff function will ensure that only one of these is set
+		if item["datastore_auto_selection"] != nil && item["datastore_auto_selection"].(string) != "" {
+			row["datastoreId"] = item["datastore_auto_selection"] // .(string)
+		}
+		storageVolumes = append(storageVolumes, row)
+	}
+	return storageVolumes // .([]map[string]interface{})
+}